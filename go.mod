@@ -5,6 +5,7 @@ go 1.24
 require (
 	github.com/fatih/color v1.18.0
 	github.com/goccy/go-yaml v1.17.1
+	github.com/gofrs/flock v0.12.1
 	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/google/go-cmp v0.7.0
 	github.com/google/uuid v1.6.0
@@ -34,7 +35,9 @@ require (
 	github.com/stackitcloud/stackit-sdk-go/services/ske v0.22.3
 	github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex v1.0.3
 	github.com/zalando/go-keyring v0.2.6
+	golang.org/x/crypto v0.38.0
 	golang.org/x/mod v0.24.0
+	golang.org/x/net v0.40.0
 	golang.org/x/oauth2 v0.30.0
 	golang.org/x/term v0.32.0
 	golang.org/x/text v0.25.0
@@ -43,7 +46,6 @@ require (
 )
 
 require (
-	golang.org/x/net v0.40.0 // indirect
 	golang.org/x/time v0.11.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 )
@@ -105,7 +107,6 @@ require (
 	github.com/go-viper/mapstructure/v2 v2.2.1 // indirect
 	github.com/go-xmlfmt/xmlfmt v1.1.3 // indirect
 	github.com/gobwas/glob v0.2.3 // indirect
-	github.com/gofrs/flock v0.12.1 // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/golangci/dupl v0.0.0-20250308024227-f665c8d69b32 // indirect
 	github.com/golangci/go-printf-func-name v0.1.0 // indirect
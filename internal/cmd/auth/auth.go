@@ -2,6 +2,7 @@ package auth
 
 import (
 	activateserviceaccount "github.com/stackitcloud/stackit-cli/internal/cmd/auth/activate-service-account"
+	credentialhelper "github.com/stackitcloud/stackit-cli/internal/cmd/auth/credential-helper"
 	getaccesstoken "github.com/stackitcloud/stackit-cli/internal/cmd/auth/get-access-token"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/login"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/logout"
@@ -29,4 +30,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(logout.NewCmd(params))
 	cmd.AddCommand(activateserviceaccount.NewCmd(params))
 	cmd.AddCommand(getaccesstoken.NewCmd(params))
+	cmd.AddCommand(credentialhelper.NewCmd(params))
 }
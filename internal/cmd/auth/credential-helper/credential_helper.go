@@ -0,0 +1,240 @@
+package credentialhelper
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	protocolArg  = "PROTOCOL"
+	operationArg = "OPERATION"
+
+	gitProtocol       = "git"
+	dockerProtocol    = "docker"
+	terraformProtocol = "terraform"
+
+	getOperation   = "get"
+	storeOperation = "store"
+	eraseOperation = "erase"
+	listOperation  = "list"
+	// Terraform names the erase operation "forget"
+	forgetOperation = "forget"
+
+	defaultUsername = "stackit"
+)
+
+var protocolOperations = map[string][]string{
+	gitProtocol:       {getOperation, storeOperation, eraseOperation},
+	dockerProtocol:    {getOperation, storeOperation, eraseOperation, listOperation},
+	terraformProtocol: {getOperation, storeOperation, forgetOperation},
+}
+
+// argForms are the accepted arguments, the operation defaults to "get"
+var argForms = []string{
+	fmt.Sprintf("%s [%s|%s|%s]", gitProtocol, getOperation, storeOperation, eraseOperation),
+	fmt.Sprintf("%s [%s|%s|%s|%s]", dockerProtocol, getOperation, storeOperation, eraseOperation, listOperation),
+	fmt.Sprintf("%s [%s|%s|%s [HOST]]", terraformProtocol, getOperation, storeOperation, forgetOperation),
+}
+
+type inputModel struct {
+	Protocol  string
+	Operation string
+}
+
+type dockerCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+type terraformCredentials struct {
+	Token string `json:"token"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("credential-helper %s [%s]", protocolArg, operationArg),
+		Short: "Provides access tokens to other tools using their credential helper protocol",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Provides access tokens of the active authentication flow to other tools, implementing their credential helper protocol.",
+			`Supported protocols are "git" (git-credential), "docker" (docker-credential-helpers) and "terraform" (Terraform credentials helper).`,
+			`The operation defaults to "get". The returned access token is refreshed if needed, so the consuming tools stay authenticated as long as the CLI session is valid.`,
+			"The user is never prompted to log in again: if the session expired, the command fails and you need to authenticate the CLI again.",
+		),
+		Args: parseArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Configure git to use the STACKIT CLI as credential helper for a STACKIT Git instance`,
+				`$ git config --global credential.https://my-instance.git.onstackit.cloud.helper "!stackit auth credential-helper git"`),
+			examples.NewExample(
+				`Get credentials for Docker. Docker expects an executable named "docker-credential-<name>" which runs "stackit auth credential-helper docker \"$@\""`,
+				`$ echo "registry.onstackit.cloud" | stackit auth credential-helper docker get`),
+			examples.NewExample(
+				`Get credentials for Terraform. Terraform expects an executable named "terraform-credentials-<name>" which runs "stackit auth credential-helper terraform \"$@\""`,
+				`$ stackit auth credential-helper terraform get my-registry.example.com`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model := parseInput(params.Printer, args)
+
+			// Storing and erasing credentials is handled by the CLI itself
+			switch model.Operation {
+			case storeOperation, eraseOperation, forgetOperation:
+				// The input has to be consumed to not break the pipe of the calling tool
+				_, err := io.Copy(io.Discard, cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("read input: %w", err)
+				}
+				return nil
+			case listOperation:
+				params.Printer.Outputln("{}")
+				return nil
+			}
+
+			accessToken, err := auth.GetValidAccessToken(params.Printer)
+			if err != nil {
+				return err
+			}
+
+			output, err := buildOutput(model, cmd.InOrStdin(), accessToken)
+			if err != nil {
+				return err
+			}
+			params.Printer.Outputf("%s", output)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func parseArgs(cmd *cobra.Command, args []string) error {
+	// Terraform additionally passes the hostname, which is not needed to get the access token
+	maxArgs := 2
+	if len(args) > 0 && args[0] == terraformProtocol {
+		maxArgs = 3
+	}
+	if len(args) < 1 || len(args) > maxArgs {
+		err := fmt.Errorf("expected the arguments %q, %q or %q, %d were provided", argForms[0], argForms[1], argForms[2], len(args))
+		return errors.AppendUsageTip(err, cmd)
+	}
+	operations, ok := protocolOperations[args[0]]
+	if !ok {
+		return &errors.ArgValidationError{
+			Arg:     protocolArg,
+			Details: fmt.Sprintf("must be one of %q, %q or %q", gitProtocol, dockerProtocol, terraformProtocol),
+		}
+	}
+	if len(args) >= 2 && !slices.Contains(operations, args[1]) {
+		return &errors.ArgValidationError{
+			Arg:     operationArg,
+			Details: fmt.Sprintf("must be one of %s for protocol %q", strings.Join(operations, ", "), args[0]),
+		}
+	}
+	return nil
+}
+
+func parseInput(p *print.Printer, inputArgs []string) *inputModel {
+	model := inputModel{
+		Protocol:  inputArgs[0],
+		Operation: getOperation,
+	}
+	if len(inputArgs) > 1 {
+		model.Operation = inputArgs[1]
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model
+}
+
+// buildOutput reads the request of the calling tool from the input and returns the response containing the access token
+func buildOutput(model *inputModel, input io.Reader, accessToken string) (string, error) {
+	switch model.Protocol {
+	case gitProtocol:
+		attributes, err := parseGitAttributes(input)
+		if err != nil {
+			return "", fmt.Errorf("parse git credential input: %w", err)
+		}
+		return buildGitOutput(attributes, accessToken), nil
+	case dockerProtocol:
+		serverURL, err := io.ReadAll(input)
+		if err != nil {
+			return "", fmt.Errorf("read docker credential input: %w", err)
+		}
+		credentials, err := json.Marshal(dockerCredentials{
+			ServerURL: strings.TrimSpace(string(serverURL)),
+			Username:  defaultUsername,
+			Secret:    accessToken,
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshal docker credentials: %w", err)
+		}
+		return fmt.Sprintf("%s\n", credentials), nil
+	case terraformProtocol:
+		credentials, err := json.Marshal(terraformCredentials{
+			Token: accessToken,
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshal terraform credentials: %w", err)
+		}
+		return fmt.Sprintf("%s\n", credentials), nil
+	default:
+		return "", fmt.Errorf("unsupported protocol %q", model.Protocol)
+	}
+}
+
+// parseGitAttributes parses the "key=value" lines sent by git, which are terminated by an empty line or EOF
+func parseGitAttributes(input io.Reader) (map[string]string, error) {
+	attributes := map[string]string{}
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid line %q: expected format key=value", line)
+		}
+		attributes[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func buildGitOutput(attributes map[string]string, accessToken string) string {
+	username := attributes["username"]
+	if username == "" {
+		username = defaultUsername
+	}
+
+	var sb strings.Builder
+	for _, key := range []string{"protocol", "host", "path"} {
+		if value, ok := attributes[key]; ok {
+			sb.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("username=%s\n", username))
+	sb.WriteString(fmt.Sprintf("password=%s\n", accessToken))
+	return sb.String()
+}
@@ -0,0 +1,202 @@
+package credentialhelper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testAccessToken = "access-token"
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		description string
+		args        []string
+		isValid     bool
+		expectedErr string
+	}{
+		{
+			description: "git",
+			args:        []string{gitProtocol},
+			isValid:     true,
+		},
+		{
+			description: "git get",
+			args:        []string{gitProtocol, getOperation},
+			isValid:     true,
+		},
+		{
+			description: "docker list",
+			args:        []string{dockerProtocol, listOperation},
+			isValid:     true,
+		},
+		{
+			description: "terraform forget",
+			args:        []string{terraformProtocol, forgetOperation},
+			isValid:     true,
+		},
+		{
+			description: "terraform get with hostname",
+			args:        []string{terraformProtocol, getOperation, "my-registry.example.com"},
+			isValid:     true,
+		},
+		{
+			description: "no args",
+			args:        []string{},
+			isValid:     false,
+			expectedErr: `"git [get|store|erase]", "docker [get|store|erase|list]" or "terraform [get|store|forget [HOST]]"`,
+		},
+		{
+			description: "too many args",
+			args:        []string{gitProtocol, getOperation, "foo"},
+			isValid:     false,
+			expectedErr: "3 were provided",
+		},
+		{
+			description: "unknown protocol",
+			args:        []string{"foo"},
+			isValid:     false,
+		},
+		{
+			description: "operation not supported by protocol",
+			args:        []string{gitProtocol, listOperation},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := NewCmd(&params.CmdParams{Printer: print.NewPrinter()})
+			err := parseArgs(cmd, tt.args)
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("error parsing args: %v", err)
+			}
+			if tt.expectedErr != "" && !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error to contain %q, got %q", tt.expectedErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		args          []string
+		expectedModel *inputModel
+	}{
+		{
+			description: "default operation",
+			args:        []string{gitProtocol},
+			expectedModel: &inputModel{
+				Protocol:  gitProtocol,
+				Operation: getOperation,
+			},
+		},
+		{
+			description: "with operation",
+			args:        []string{dockerProtocol, eraseOperation},
+			expectedModel: &inputModel{
+				Protocol:  dockerProtocol,
+				Operation: eraseOperation,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			model := parseInput(p, tt.args)
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildOutput(t *testing.T) {
+	tests := []struct {
+		description    string
+		protocol       string
+		input          string
+		isValid        bool
+		expectedOutput string
+	}{
+		{
+			description:    "git",
+			protocol:       gitProtocol,
+			input:          "protocol=https\nhost=my-instance.git.onstackit.cloud\n\n",
+			isValid:        true,
+			expectedOutput: "protocol=https\nhost=my-instance.git.onstackit.cloud\nusername=stackit\npassword=access-token\n",
+		},
+		{
+			description:    "git with username",
+			protocol:       gitProtocol,
+			input:          "protocol=https\nhost=example.com\npath=repo.git\nusername=user\n",
+			isValid:        true,
+			expectedOutput: "protocol=https\nhost=example.com\npath=repo.git\nusername=user\npassword=access-token\n",
+		},
+		{
+			description:    "git empty input",
+			protocol:       gitProtocol,
+			input:          "",
+			isValid:        true,
+			expectedOutput: "username=stackit\npassword=access-token\n",
+		},
+		{
+			description: "git invalid input",
+			protocol:    gitProtocol,
+			input:       "foo\n",
+			isValid:     false,
+		},
+		{
+			description:    "docker",
+			protocol:       dockerProtocol,
+			input:          "registry.onstackit.cloud\n",
+			isValid:        true,
+			expectedOutput: `{"ServerURL":"registry.onstackit.cloud","Username":"stackit","Secret":"access-token"}` + "\n",
+		},
+		{
+			description:    "terraform",
+			protocol:       terraformProtocol,
+			input:          "",
+			isValid:        true,
+			expectedOutput: `{"token":"access-token"}` + "\n",
+		},
+		{
+			description: "unknown protocol",
+			protocol:    "foo",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			model := &inputModel{
+				Protocol:  tt.protocol,
+				Operation: getOperation,
+			}
+			output, err := buildOutput(model, strings.NewReader(tt.input), testAccessToken)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building output: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(output, tt.expectedOutput)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
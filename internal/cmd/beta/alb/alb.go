@@ -5,10 +5,12 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/describe"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/listener"
 	observabilitycredentials "github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/observability-credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/plans"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/pool"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/quotas"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/rule"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/template"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/update"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
@@ -43,5 +45,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 		pool.NewCmd(params),
 		plans.NewCmd(params),
 		quotas.NewCmd(params),
+		listener.NewCmd(params),
+		rule.NewCmd(params),
 	)
 }
@@ -0,0 +1,182 @@
+package add
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/client"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+const (
+	albNameFlag       = "name"
+	portFlag          = "port"
+	protocolFlag      = "protocol"
+	certificateIdFlag = "certificate-id"
+
+	protocolHttp  = "http"
+	protocolHttps = "https"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	AlbName        string
+	Port           int64
+	Protocol       string
+	CertificateIds []string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Adds a listener to an application loadbalancer",
+		Long: fmt.Sprintf("%s\n%s",
+			"Adds a listener to an application loadbalancer.",
+			"HTTPS listeners need the IDs of the certificates to use for TLS. Routing rules are added to the listener with \"stackit beta alb rule add\".",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Add an HTTP listener on port 80 to the application loadbalancer "my-load-balancer"`,
+				"$ stackit beta alb listener add --name my-load-balancer --port 80 --protocol http"),
+			examples.NewExample(
+				`Add an HTTPS listener on port 443 with the certificate with ID "xxx" to the application loadbalancer "my-load-balancer"`,
+				"$ stackit beta alb listener add --name my-load-balancer --port 443 --protocol https --certificate-id xxx"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to add a %s listener on port %d to application loadbalancer %q?", model.Protocol, model.Port, model.AlbName)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req, err := buildRequest(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("update application loadbalancer: %w", err)
+			}
+
+			params.Printer.Info("Added listener on port %d to application loadbalancer %q\n", model.Port, model.AlbName)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(albNameFlag, "n", "", "Name of the application loadbalancer")
+	cmd.Flags().Int64(portFlag, 0, "Port of the listener")
+	cmd.Flags().Var(flags.EnumFlag(true, "", protocolHttp, protocolHttps), protocolFlag, fmt.Sprintf("Protocol of the listener, one of %q", []string{protocolHttp, protocolHttps}))
+	cmd.Flags().StringSlice(certificateIdFlag, nil, "IDs of the certificates of an HTTPS listener")
+
+	err := flags.MarkFlagsRequired(cmd, albNameFlag, portFlag, protocolFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		AlbName:         flags.FlagToStringValue(p, cmd, albNameFlag),
+		Port:            utils.PtrValue(flags.FlagToInt64Pointer(p, cmd, portFlag)),
+		Protocol:        flags.FlagToStringValue(p, cmd, protocolFlag),
+		CertificateIds:  flags.FlagToStringSliceValue(p, cmd, certificateIdFlag),
+	}
+
+	if model.Port < 1 || model.Port > 65535 {
+		return nil, &errors.FlagValidationError{
+			Flag:    portFlag,
+			Details: "must be between 1 and 65535",
+		}
+	}
+	if model.Protocol == protocolHttps && len(model.CertificateIds) == 0 {
+		return nil, &errors.FlagValidationError{
+			Flag:    certificateIdFlag,
+			Details: "must be set for HTTPS listeners",
+		}
+	}
+	if model.Protocol == protocolHttp && len(model.CertificateIds) > 0 {
+		return nil, &errors.FlagValidationError{
+			Flag:    certificateIdFlag,
+			Details: "can only be set for HTTPS listeners",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient albUtils.AlbClient) (req alb.ApiUpdateLoadBalancerRequest, err error) {
+	loadBalancer, err := apiClient.GetLoadBalancerExecute(ctx, model.ProjectId, model.Region, model.AlbName)
+	if err != nil {
+		return req, fmt.Errorf("get application loadbalancer: %w", err)
+	}
+
+	err = albUtils.AddListener(loadBalancer, buildListener(model))
+	if err != nil {
+		return req, err
+	}
+
+	req = apiClient.UpdateLoadBalancer(ctx, model.ProjectId, model.Region, model.AlbName)
+	return req.UpdateLoadBalancerPayload(*albUtils.ToPayloadLoadBalancer(loadBalancer)), nil
+}
+
+func buildListener(model *inputModel) *alb.Listener {
+	listener := &alb.Listener{
+		Port: utils.Ptr(model.Port),
+		Http: &alb.ProtocolOptionsHTTP{},
+	}
+	if model.Protocol == protocolHttps {
+		listener.Protocol = utils.Ptr(albUtils.ProtocolHTTPS)
+		listener.Https = &alb.ProtocolOptionsHTTPS{
+			CertificateConfig: &alb.CertificateConfig{
+				CertificateIds: &model.CertificateIds,
+			},
+		}
+		return listener
+	}
+	listener.Protocol = utils.Ptr(albUtils.ProtocolHTTP)
+	return listener
+}
@@ -0,0 +1,316 @@
+package add
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx       = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient    = &alb.APIClient{}
+	testProjectId = uuid.NewString()
+)
+
+const (
+	testRegion        = "eu01"
+	testAlbName       = "my-load-balancer"
+	testCertificateId = "my-certificate"
+)
+
+type albClientMocked struct {
+	getLoadBalancerFails bool
+	getLoadBalancerResp  *alb.LoadBalancer
+}
+
+func (m *albClientMocked) GetLoadBalancerExecute(_ context.Context, _, _, _ string) (*alb.LoadBalancer, error) {
+	if m.getLoadBalancerFails {
+		return nil, fmt.Errorf("could not get load balancer")
+	}
+	return m.getLoadBalancerResp, nil
+}
+
+func (m *albClientMocked) UpdateLoadBalancer(ctx context.Context, projectId, region, name string) alb.ApiUpdateLoadBalancerRequest {
+	return testClient.UpdateLoadBalancer(ctx, projectId, region, name)
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		albNameFlag:               testAlbName,
+		portFlag:                  "443",
+		protocolFlag:              protocolHttps,
+		certificateIdFlag:         testCertificateId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		AlbName:        testAlbName,
+		Port:           443,
+		Protocol:       protocolHttps,
+		CertificateIds: []string{testCertificateId},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureLoadBalancer(mods ...func(lb *alb.LoadBalancer)) *alb.LoadBalancer {
+	lb := &alb.LoadBalancer{
+		Name:    utils.Ptr(testAlbName),
+		Status:  utils.Ptr("STATUS_READY"),
+		Version: utils.Ptr("1"),
+		Listeners: &[]alb.Listener{
+			{
+				Port:     utils.Ptr(int64(80)),
+				Protocol: utils.Ptr(albUtils.ProtocolHTTP),
+				Http:     &alb.ProtocolOptionsHTTP{},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(lb)
+	}
+	return lb
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "http",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[portFlag] = "8080"
+				flagValues[protocolFlag] = protocolHttp
+				delete(flagValues, certificateIdFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Port = 8080
+				model.Protocol = protocolHttp
+				model.CertificateIds = nil
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "name missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, albNameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "port invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[portFlag] = "70000"
+			}),
+			isValid: false,
+		},
+		{
+			description: "protocol invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[protocolFlag] = "tcp"
+			}),
+			isValid: false,
+		},
+		{
+			description: "https without certificate",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, certificateIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "http with certificate",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[protocolFlag] = protocolHttp
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description          string
+		model                *inputModel
+		getLoadBalancerFails bool
+		getLoadBalancerResp  *alb.LoadBalancer
+		isValid              bool
+		expectedListeners    []alb.Listener
+	}{
+		{
+			description:         "https",
+			model:               fixtureInputModel(),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedListeners: []alb.Listener{
+				(*fixtureLoadBalancer().Listeners)[0],
+				{
+					Port:     utils.Ptr(int64(443)),
+					Protocol: utils.Ptr(albUtils.ProtocolHTTPS),
+					Http:     &alb.ProtocolOptionsHTTP{},
+					Https: &alb.ProtocolOptionsHTTPS{
+						CertificateConfig: &alb.CertificateConfig{
+							CertificateIds: &[]string{testCertificateId},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "http",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Port = 8080
+				model.Protocol = protocolHttp
+				model.CertificateIds = nil
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedListeners: []alb.Listener{
+				(*fixtureLoadBalancer().Listeners)[0],
+				{
+					Port:     utils.Ptr(int64(8080)),
+					Protocol: utils.Ptr(albUtils.ProtocolHTTP),
+					Http:     &alb.ProtocolOptionsHTTP{},
+				},
+			},
+		},
+		{
+			description: "port in use",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Port = 80
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description:          "get load balancer fails",
+			model:                fixtureInputModel(),
+			getLoadBalancerFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &albClientMocked{
+				getLoadBalancerFails: tt.getLoadBalancerFails,
+				getLoadBalancerResp:  tt.getLoadBalancerResp,
+			}
+			request, err := buildRequest(testCtx, tt.model, client)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			payload := albUtils.ToPayloadLoadBalancer(fixtureLoadBalancer(func(lb *alb.LoadBalancer) {
+				lb.Listeners = &tt.expectedListeners
+			}))
+			expectedRequest := testClient.UpdateLoadBalancer(testCtx, testProjectId, testRegion, testAlbName).UpdateLoadBalancerPayload(*payload)
+			diff := cmp.Diff(request, expectedRequest,
+				cmp.AllowUnexported(expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
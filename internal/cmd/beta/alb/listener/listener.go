@@ -0,0 +1,29 @@
+package listener
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/listener/add"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb/listener/remove"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listener",
+		Short: "Manages listeners of application loadbalancers",
+		Long:  "Manage the listeners of application loadbalancers, without editing the whole configuration.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(add.NewCmd(params))
+	cmd.AddCommand(remove.NewCmd(params))
+}
@@ -0,0 +1,260 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx       = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient    = &alb.APIClient{}
+	testProjectId = uuid.NewString()
+)
+
+const (
+	testRegion  = "eu01"
+	testAlbName = "my-load-balancer"
+)
+
+type albClientMocked struct {
+	getLoadBalancerFails bool
+	getLoadBalancerResp  *alb.LoadBalancer
+}
+
+func (m *albClientMocked) GetLoadBalancerExecute(_ context.Context, _, _, _ string) (*alb.LoadBalancer, error) {
+	if m.getLoadBalancerFails {
+		return nil, fmt.Errorf("could not get load balancer")
+	}
+	return m.getLoadBalancerResp, nil
+}
+
+func (m *albClientMocked) UpdateLoadBalancer(ctx context.Context, projectId, region, name string) alb.ApiUpdateLoadBalancerRequest {
+	return testClient.UpdateLoadBalancer(ctx, projectId, region, name)
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		albNameFlag:               testAlbName,
+		portFlag:                  "443",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		AlbName: testAlbName,
+		Port:    443,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureLoadBalancer(mods ...func(lb *alb.LoadBalancer)) *alb.LoadBalancer {
+	lb := &alb.LoadBalancer{
+		Name:    utils.Ptr(testAlbName),
+		Status:  utils.Ptr("STATUS_READY"),
+		Version: utils.Ptr("1"),
+		Listeners: &[]alb.Listener{
+			{
+				Port:     utils.Ptr(int64(80)),
+				Protocol: utils.Ptr(albUtils.ProtocolHTTP),
+				Http:     &alb.ProtocolOptionsHTTP{},
+			},
+			{
+				Port:     utils.Ptr(int64(443)),
+				Protocol: utils.Ptr(albUtils.ProtocolHTTPS),
+				Http:     &alb.ProtocolOptionsHTTP{},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(lb)
+	}
+	return lb
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "name missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, albNameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "port missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, portFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "port invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[portFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description          string
+		model                *inputModel
+		getLoadBalancerFails bool
+		getLoadBalancerResp  *alb.LoadBalancer
+		isValid              bool
+		expectedListeners    []alb.Listener
+	}{
+		{
+			description:         "base",
+			model:               fixtureInputModel(),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedListeners: []alb.Listener{
+				(*fixtureLoadBalancer().Listeners)[0],
+			},
+		},
+		{
+			description: "listener not found",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Port = 8080
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description:          "get load balancer fails",
+			model:                fixtureInputModel(),
+			getLoadBalancerFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &albClientMocked{
+				getLoadBalancerFails: tt.getLoadBalancerFails,
+				getLoadBalancerResp:  tt.getLoadBalancerResp,
+			}
+			request, err := buildRequest(testCtx, tt.model, client)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			payload := albUtils.ToPayloadLoadBalancer(fixtureLoadBalancer(func(lb *alb.LoadBalancer) {
+				lb.Listeners = &tt.expectedListeners
+			}))
+			expectedRequest := testClient.UpdateLoadBalancer(testCtx, testProjectId, testRegion, testAlbName).UpdateLoadBalancerPayload(*payload)
+			diff := cmp.Diff(request, expectedRequest,
+				cmp.AllowUnexported(expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package add
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/client"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+const (
+	albNameFlag      = "name"
+	listenerPortFlag = "listener-port"
+	hostFlag         = "host"
+	pathPrefixFlag   = "path-prefix"
+	poolFlag         = "pool"
+	webSocketFlag    = "web-socket"
+
+	defaultPathPrefix = "/"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	AlbName      string
+	ListenerPort int64
+	Host         string
+	PathPrefix   string
+	Pool         string
+	WebSocket    bool
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Adds a routing rule to a listener of an application loadbalancer",
+		Long: fmt.Sprintf("%s\n%s",
+			"Adds a routing rule to a listener of an application loadbalancer, which forwards the requests for a host and path prefix to a target pool.",
+			"The rule is added after the existing rules of the host.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Forward all requests for "example.com" on port 443 of the application loadbalancer "my-load-balancer" to the target pool "web"`,
+				"$ stackit beta alb rule add --name my-load-balancer --listener-port 443 --host example.com --pool web"),
+			examples.NewExample(
+				`Forward the requests for "example.com/api" on port 443 of the application loadbalancer "my-load-balancer" to the target pool "api"`,
+				"$ stackit beta alb rule add --name my-load-balancer --listener-port 443 --host example.com --path-prefix /api --pool api"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to forward the requests for %q with path prefix %q on port %d of application loadbalancer %q to target pool %q?", model.Host, model.PathPrefix, model.ListenerPort, model.AlbName, model.Pool)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req, err := buildRequest(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("update application loadbalancer: %w", err)
+			}
+
+			params.Printer.Info("Added routing rule for %q with path prefix %q to application loadbalancer %q\n", model.Host, model.PathPrefix, model.AlbName)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(albNameFlag, "n", "", "Name of the application loadbalancer")
+	cmd.Flags().Int64(listenerPortFlag, 0, "Port of the listener")
+	cmd.Flags().String(hostFlag, "", "Host name of the requests to forward")
+	cmd.Flags().String(pathPrefixFlag, defaultPathPrefix, "Path prefix of the requests to forward")
+	cmd.Flags().String(poolFlag, "", "Name of the target pool to forward the requests to")
+	cmd.Flags().Bool(webSocketFlag, false, "Allow WebSocket connections")
+
+	err := flags.MarkFlagsRequired(cmd, albNameFlag, listenerPortFlag, hostFlag, poolFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		AlbName:         flags.FlagToStringValue(p, cmd, albNameFlag),
+		ListenerPort:    utils.PtrValue(flags.FlagToInt64Pointer(p, cmd, listenerPortFlag)),
+		Host:            flags.FlagToStringValue(p, cmd, hostFlag),
+		PathPrefix:      flags.FlagWithDefaultToStringValue(p, cmd, pathPrefixFlag),
+		Pool:            flags.FlagToStringValue(p, cmd, poolFlag),
+		WebSocket:       flags.FlagToBoolValue(p, cmd, webSocketFlag),
+	}
+
+	if model.ListenerPort < 1 || model.ListenerPort > 65535 {
+		return nil, &errors.FlagValidationError{
+			Flag:    listenerPortFlag,
+			Details: "must be between 1 and 65535",
+		}
+	}
+	if !strings.HasPrefix(model.PathPrefix, "/") {
+		return nil, &errors.FlagValidationError{
+			Flag:    pathPrefixFlag,
+			Details: `must start with "/"`,
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient albUtils.AlbClient) (req alb.ApiUpdateLoadBalancerRequest, err error) {
+	loadBalancer, err := apiClient.GetLoadBalancerExecute(ctx, model.ProjectId, model.Region, model.AlbName)
+	if err != nil {
+		return req, fmt.Errorf("get application loadbalancer: %w", err)
+	}
+
+	rule := &alb.Rule{
+		PathPrefix: utils.Ptr(model.PathPrefix),
+		TargetPool: utils.Ptr(model.Pool),
+	}
+	if model.WebSocket {
+		rule.WebSocket = utils.Ptr(true)
+	}
+	err = albUtils.AddRule(loadBalancer, model.ListenerPort, model.Host, rule)
+	if err != nil {
+		return req, err
+	}
+
+	req = apiClient.UpdateLoadBalancer(ctx, model.ProjectId, model.Region, model.AlbName)
+	return req.UpdateLoadBalancerPayload(*albUtils.ToPayloadLoadBalancer(loadBalancer)), nil
+}
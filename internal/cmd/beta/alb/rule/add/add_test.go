@@ -0,0 +1,353 @@
+package add
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx       = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient    = &alb.APIClient{}
+	testProjectId = uuid.NewString()
+)
+
+const (
+	testRegion  = "eu01"
+	testAlbName = "my-load-balancer"
+	testHost    = "example.com"
+)
+
+type albClientMocked struct {
+	getLoadBalancerFails bool
+	getLoadBalancerResp  *alb.LoadBalancer
+}
+
+func (m *albClientMocked) GetLoadBalancerExecute(_ context.Context, _, _, _ string) (*alb.LoadBalancer, error) {
+	if m.getLoadBalancerFails {
+		return nil, fmt.Errorf("could not get load balancer")
+	}
+	return m.getLoadBalancerResp, nil
+}
+
+func (m *albClientMocked) UpdateLoadBalancer(ctx context.Context, projectId, region, name string) alb.ApiUpdateLoadBalancerRequest {
+	return testClient.UpdateLoadBalancer(ctx, projectId, region, name)
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		albNameFlag:               testAlbName,
+		listenerPortFlag:          "443",
+		hostFlag:                  testHost,
+		pathPrefixFlag:            "/static",
+		poolFlag:                  "web",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		AlbName:      testAlbName,
+		ListenerPort: 443,
+		Host:         testHost,
+		PathPrefix:   "/static",
+		Pool:         "web",
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureLoadBalancer(mods ...func(lb *alb.LoadBalancer)) *alb.LoadBalancer {
+	lb := &alb.LoadBalancer{
+		Name:    utils.Ptr(testAlbName),
+		Status:  utils.Ptr("STATUS_READY"),
+		Version: utils.Ptr("1"),
+		Listeners: &[]alb.Listener{
+			{
+				Port:     utils.Ptr(int64(443)),
+				Protocol: utils.Ptr(albUtils.ProtocolHTTPS),
+				Http: &alb.ProtocolOptionsHTTP{
+					Hosts: &[]alb.HostConfig{
+						{
+							Host: utils.Ptr(testHost),
+							Rules: &[]alb.Rule{
+								{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("web")},
+								{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")},
+							},
+						},
+					},
+				},
+			},
+		},
+		TargetPools: &[]alb.TargetPool{
+			{Name: utils.Ptr("web")},
+			{Name: utils.Ptr("api")},
+		},
+	}
+	for _, mod := range mods {
+		mod(lb)
+	}
+	return lb
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "default path prefix",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, pathPrefixFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.PathPrefix = "/"
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "name missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, albNameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "host missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, hostFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "listener port invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[listenerPortFlag] = "70000"
+			}),
+			isValid: false,
+		},
+		{
+			description: "web socket",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[webSocketFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.WebSocket = true
+			}),
+		},
+		{
+			description: "pool missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, poolFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "path prefix invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[pathPrefixFlag] = "static"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description          string
+		model                *inputModel
+		getLoadBalancerFails bool
+		getLoadBalancerResp  *alb.LoadBalancer
+		isValid              bool
+		expectedHosts        []alb.HostConfig
+	}{
+		{
+			description:         "existing host",
+			model:               fixtureInputModel(),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedHosts: []alb.HostConfig{
+				{
+					Host: utils.Ptr(testHost),
+					Rules: &[]alb.Rule{
+						{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("web")},
+						{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")},
+						{PathPrefix: utils.Ptr("/static"), TargetPool: utils.Ptr("web")},
+					},
+				},
+			},
+		},
+		{
+			description: "new host with web socket",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Host = "ws.example.com"
+				model.PathPrefix = "/"
+				model.Pool = "api"
+				model.WebSocket = true
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedHosts: []alb.HostConfig{
+				(*(*fixtureLoadBalancer().Listeners)[0].Http.Hosts)[0],
+				{
+					Host: utils.Ptr("ws.example.com"),
+					Rules: &[]alb.Rule{
+						{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("api"), WebSocket: utils.Ptr(true)},
+					},
+				},
+			},
+		},
+		{
+			description: "duplicate path prefix",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.PathPrefix = "/api"
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description: "unknown pool",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Pool = "unknown"
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description: "unknown listener",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.ListenerPort = 80
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description:          "get load balancer fails",
+			model:                fixtureInputModel(),
+			getLoadBalancerFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &albClientMocked{
+				getLoadBalancerFails: tt.getLoadBalancerFails,
+				getLoadBalancerResp:  tt.getLoadBalancerResp,
+			}
+			request, err := buildRequest(testCtx, tt.model, client)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			payload := albUtils.ToPayloadLoadBalancer(fixtureLoadBalancer(func(lb *alb.LoadBalancer) {
+				(*lb.Listeners)[0].Http.Hosts = &tt.expectedHosts
+			}))
+			expectedRequest := testClient.UpdateLoadBalancer(testCtx, testProjectId, testRegion, testAlbName).UpdateLoadBalancerPayload(*payload)
+			diff := cmp.Diff(request, expectedRequest,
+				cmp.AllowUnexported(expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
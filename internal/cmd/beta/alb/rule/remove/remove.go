@@ -0,0 +1,145 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/client"
+	albUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/alb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+const (
+	albNameFlag      = "name"
+	listenerPortFlag = "listener-port"
+	hostFlag         = "host"
+	pathPrefixFlag   = "path-prefix"
+
+	defaultPathPrefix = "/"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	AlbName      string
+	ListenerPort int64
+	Host         string
+	PathPrefix   string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Removes a routing rule from a listener of an application loadbalancer",
+		Long:  "Removes the routing rule for a host and path prefix from a listener of an application loadbalancer.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Remove the routing rule for "example.com/api" from the listener on port 443 of the application loadbalancer "my-load-balancer"`,
+				"$ stackit beta alb rule remove --name my-load-balancer --listener-port 443 --host example.com --path-prefix /api"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to remove the routing rule for %q with path prefix %q from port %d of application loadbalancer %q?", model.Host, model.PathPrefix, model.ListenerPort, model.AlbName)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req, err := buildRequest(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("update application loadbalancer: %w", err)
+			}
+
+			params.Printer.Info("Removed routing rule for %q with path prefix %q from application loadbalancer %q\n", model.Host, model.PathPrefix, model.AlbName)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(albNameFlag, "n", "", "Name of the application loadbalancer")
+	cmd.Flags().Int64(listenerPortFlag, 0, "Port of the listener")
+	cmd.Flags().String(hostFlag, "", "Host name of the rule")
+	cmd.Flags().String(pathPrefixFlag, defaultPathPrefix, "Path prefix of the rule")
+
+	err := flags.MarkFlagsRequired(cmd, albNameFlag, listenerPortFlag, hostFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		AlbName:         flags.FlagToStringValue(p, cmd, albNameFlag),
+		ListenerPort:    utils.PtrValue(flags.FlagToInt64Pointer(p, cmd, listenerPortFlag)),
+		Host:            flags.FlagToStringValue(p, cmd, hostFlag),
+		PathPrefix:      flags.FlagWithDefaultToStringValue(p, cmd, pathPrefixFlag),
+	}
+
+	if model.ListenerPort < 1 || model.ListenerPort > 65535 {
+		return nil, &errors.FlagValidationError{
+			Flag:    listenerPortFlag,
+			Details: "must be between 1 and 65535",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient albUtils.AlbClient) (req alb.ApiUpdateLoadBalancerRequest, err error) {
+	loadBalancer, err := apiClient.GetLoadBalancerExecute(ctx, model.ProjectId, model.Region, model.AlbName)
+	if err != nil {
+		return req, fmt.Errorf("get application loadbalancer: %w", err)
+	}
+
+	err = albUtils.RemoveRule(loadBalancer, model.ListenerPort, model.Host, model.PathPrefix)
+	if err != nil {
+		return req, err
+	}
+
+	req = apiClient.UpdateLoadBalancer(ctx, model.ProjectId, model.Region, model.AlbName)
+	return req.UpdateLoadBalancerPayload(*albUtils.ToPayloadLoadBalancer(loadBalancer)), nil
+}
@@ -23,6 +23,10 @@ const (
 	identityProviderCustomClientIdFlag               = "identity-provider-custom-client-id"
 	allowedUrlDomainFlag                             = "allowed-url-domain"
 
+	sshUserFlag          = "ssh-user"
+	sshBastionServerFlag = "ssh-bastion-server"
+	sshIdentityFilesFlag = "ssh-identity-files"
+
 	authorizationCustomEndpointFlag     = "authorization-custom-endpoint"
 	dnsCustomEndpointFlag               = "dns-custom-endpoint"
 	loadBalancerCustomEndpointFlag      = "load-balancer-custom-endpoint"
@@ -136,6 +140,9 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(identityProviderCustomWellKnownConfigurationFlag, "", "Identity Provider well-known OpenID configuration URL, used for user authentication")
 	cmd.Flags().String(identityProviderCustomClientIdFlag, "", "Identity Provider client ID, used for user authentication")
 	cmd.Flags().String(allowedUrlDomainFlag, "", `Domain name, used for the verification of the URLs that are given in the custom identity provider endpoint and "STACKIT curl" command`)
+	cmd.Flags().String(sshUserFlag, "", `User name, used by "stackit server ssh" and "stackit server ssh-config" to log in to servers`)
+	cmd.Flags().String(sshBastionServerFlag, "", `ID or name of the server, used by "stackit server ssh" and "stackit server ssh-config" as jump host to reach servers without a public IP`)
+	cmd.Flags().String(sshIdentityFilesFlag, "", `Local private keys of key pairs, used by "stackit server ssh" and "stackit server ssh-config". Comma separated list of KEY_PAIR_NAME=PATH pairs`)
 	cmd.Flags().String(observabilityCustomEndpointFlag, "", "Observability API base URL, used in calls to this API")
 	cmd.Flags().String(authorizationCustomEndpointFlag, "", "Authorization API base URL, used in calls to this API")
 	cmd.Flags().String(dnsCustomEndpointFlag, "", "DNS API base URL, used in calls to this API")
@@ -169,6 +176,13 @@ func configureFlags(cmd *cobra.Command) {
 	err = viper.BindPFlag(config.AllowedUrlDomainKey, cmd.Flags().Lookup(allowedUrlDomainFlag))
 	cobra.CheckErr(err)
 
+	err = viper.BindPFlag(config.SSHUserKey, cmd.Flags().Lookup(sshUserFlag))
+	cobra.CheckErr(err)
+	err = viper.BindPFlag(config.SSHBastionServerKey, cmd.Flags().Lookup(sshBastionServerFlag))
+	cobra.CheckErr(err)
+	err = viper.BindPFlag(config.SSHIdentityFilesKey, cmd.Flags().Lookup(sshIdentityFilesFlag))
+	cobra.CheckErr(err)
+
 	err = viper.BindPFlag(config.ObservabilityCustomEndpointKey, cmd.Flags().Lookup(observabilityCustomEndpointFlag))
 	cobra.CheckErr(err)
 	err = viper.BindPFlag(config.AuthorizationCustomEndpointKey, cmd.Flags().Lookup(authorizationCustomEndpointFlag))
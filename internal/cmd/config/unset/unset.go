@@ -27,6 +27,10 @@ const (
 	identityProviderCustomClientIdFlag               = "identity-provider-custom-client-id"
 	allowedUrlDomainFlag                             = "allowed-url-domain"
 
+	sshUserFlag          = "ssh-user"
+	sshBastionServerFlag = "ssh-bastion-server"
+	sshIdentityFilesFlag = "ssh-identity-files"
+
 	authorizationCustomEndpointFlag     = "authorization-custom-endpoint"
 	dnsCustomEndpointFlag               = "dns-custom-endpoint"
 	loadBalancerCustomEndpointFlag      = "load-balancer-custom-endpoint"
@@ -64,6 +68,10 @@ type inputModel struct {
 	IdentityProviderCustomClientID bool
 	AllowedUrlDomain               bool
 
+	SSHUser          bool
+	SSHBastionServer bool
+	SSHIdentityFiles bool
+
 	AuthorizationCustomEndpoint     bool
 	DNSCustomEndpoint               bool
 	LoadBalancerCustomEndpoint      bool
@@ -138,6 +146,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				viper.Set(config.AllowedUrlDomainKey, config.AllowedUrlDomainDefault)
 			}
 
+			if model.SSHUser {
+				viper.Set(config.SSHUserKey, "")
+			}
+			if model.SSHBastionServer {
+				viper.Set(config.SSHBastionServerKey, "")
+			}
+			if model.SSHIdentityFiles {
+				viper.Set(config.SSHIdentityFilesKey, "")
+			}
+
 			if model.ObservabilityCustomEndpoint {
 				viper.Set(config.ObservabilityCustomEndpointKey, "")
 			}
@@ -230,6 +248,9 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(identityProviderCustomWellKnownConfigurationFlag, false, "Identity Provider well-known OpenID configuration URL. If unset, uses the default identity provider")
 	cmd.Flags().Bool(identityProviderCustomClientIdFlag, false, "Identity Provider client ID, used for user authentication")
 	cmd.Flags().Bool(allowedUrlDomainFlag, false, fmt.Sprintf("Domain name, used for the verification of the URLs that are given in the IDP endpoint and curl commands. If unset, defaults to %s", config.AllowedUrlDomainDefault))
+	cmd.Flags().Bool(sshUserFlag, false, `User name, used by "stackit server ssh" and "stackit server ssh-config" to log in to servers. If unset, ssh uses its default user`)
+	cmd.Flags().Bool(sshBastionServerFlag, false, `Server used by "stackit server ssh" and "stackit server ssh-config" as jump host. If unset, only servers with a public IP can be reached`)
+	cmd.Flags().Bool(sshIdentityFilesFlag, false, `Local private keys of key pairs, used by "stackit server ssh" and "stackit server ssh-config". If unset, keys are looked up in ~/.ssh by the key pair name`)
 
 	cmd.Flags().Bool(observabilityCustomEndpointFlag, false, "Observability API base URL. If unset, uses the default base URL")
 	cmd.Flags().Bool(authorizationCustomEndpointFlag, false, "Authorization API base URL. If unset, uses the default base URL")
@@ -269,6 +290,10 @@ func parseInput(p *print.Printer, cmd *cobra.Command) *inputModel {
 		IdentityProviderCustomClientID: flags.FlagToBoolValue(p, cmd, identityProviderCustomClientIdFlag),
 		AllowedUrlDomain:               flags.FlagToBoolValue(p, cmd, allowedUrlDomainFlag),
 
+		SSHUser:          flags.FlagToBoolValue(p, cmd, sshUserFlag),
+		SSHBastionServer: flags.FlagToBoolValue(p, cmd, sshBastionServerFlag),
+		SSHIdentityFiles: flags.FlagToBoolValue(p, cmd, sshIdentityFilesFlag),
+
 		AuthorizationCustomEndpoint:     flags.FlagToBoolValue(p, cmd, authorizationCustomEndpointFlag),
 		DNSCustomEndpoint:               flags.FlagToBoolValue(p, cmd, dnsCustomEndpointFlag),
 		LoadBalancerCustomEndpoint:      flags.FlagToBoolValue(p, cmd, loadBalancerCustomEndpointFlag),
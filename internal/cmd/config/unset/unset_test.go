@@ -23,6 +23,10 @@ func fixtureFlagValues(mods ...func(flagValues map[string]bool)) map[string]bool
 		identityProviderCustomClientIdFlag:               true,
 		allowedUrlDomainFlag:                             true,
 
+		sshUserFlag:          true,
+		sshBastionServerFlag: true,
+		sshIdentityFilesFlag: true,
+
 		authorizationCustomEndpointFlag:   true,
 		dnsCustomEndpointFlag:             true,
 		loadBalancerCustomEndpointFlag:    true,
@@ -62,6 +66,10 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		IdentityProviderCustomClientID: true,
 		AllowedUrlDomain:               true,
 
+		SSHUser:          true,
+		SSHBastionServer: true,
+		SSHIdentityFiles: true,
+
 		AuthorizationCustomEndpoint:   true,
 		DNSCustomEndpoint:             true,
 		LoadBalancerCustomEndpoint:    true,
@@ -117,6 +125,10 @@ func TestParseInput(t *testing.T) {
 				model.IdentityProviderCustomClientID = false
 				model.AllowedUrlDomain = false
 
+				model.SSHUser = false
+				model.SSHBastionServer = false
+				model.SSHIdentityFiles = false
+
 				model.AuthorizationCustomEndpoint = false
 				model.DNSCustomEndpoint = false
 				model.LoadBalancerCustomEndpoint = false
@@ -189,6 +201,16 @@ func TestParseInput(t *testing.T) {
 				model.AllowedUrlDomain = false
 			}),
 		},
+		{
+			description: "ssh bastion server empty",
+			flagValues: fixtureFlagValues(func(flagValues map[string]bool) {
+				flagValues[sshBastionServerFlag] = false
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.SSHBastionServer = false
+			}),
+		},
 		{
 			description: "observability custom endpoint empty",
 			flagValues: fixtureFlagValues(func(flagValues map[string]bool) {
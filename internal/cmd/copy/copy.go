@@ -0,0 +1,28 @@
+package copy
+
+import (
+	keypair "github.com/stackitcloud/stackit-cli/internal/cmd/copy/key-pair"
+	securitygroup "github.com/stackitcloud/stackit-cli/internal/cmd/copy/security-group"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy",
+		Short: "Copies resources to other projects or regions",
+		Long:  "Copies resources to other projects or regions, by reading them and creating them again in the target.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(keypair.NewCmd(params))
+	cmd.AddCommand(securitygroup.NewCmd(params))
+}
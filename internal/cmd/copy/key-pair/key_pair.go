@@ -0,0 +1,192 @@
+package keypair
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	keyPairNameArg = "KEY_PAIR_NAME"
+
+	toRegionFlag = "to-region"
+	nameFlag     = "name"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	KeyPairName string
+	ToRegion    string
+	Name        *string
+}
+
+// copyResult maps the source key pair to the copy in the target region
+type copyResult struct {
+	SourceName   string `json:"sourceName"`
+	SourceRegion string `json:"sourceRegion"`
+	TargetName   string `json:"targetName"`
+	TargetRegion string `json:"targetRegion"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("key-pair %s", keyPairNameArg),
+		Short: "Copies a key pair to another region",
+		Long: fmt.Sprintf("%s\n%s",
+			"Copies a key pair to another region, with its public key and labels.",
+			"Key pairs belong to the user and not to a project, so they are available in all projects of a region and can only be copied between regions.",
+		),
+		Args: args.SingleArg(keyPairNameArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Copy the key pair with name "KEY_PAIR_NAME" to the region "eu02"`,
+				"$ stackit copy key-pair KEY_PAIR_NAME --to-region eu02"),
+			examples.NewExample(
+				`Copy the key pair with name "KEY_PAIR_NAME" to the region "eu02" with the name "NEW_KEY_PAIR_NAME"`,
+				"$ stackit copy key-pair KEY_PAIR_NAME --to-region eu02 --name NEW_KEY_PAIR_NAME"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API clients
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+			targetClient, err := client.ConfigureClientForRegion(params.Printer, params.CliVersion, model.ToRegion)
+			if err != nil {
+				return err
+			}
+
+			keyPair, err := apiClient.GetKeyPairExecute(ctx, model.KeyPairName)
+			if err != nil {
+				return fmt.Errorf("read key pair: %w", err)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to copy the key pair %q to region %q?", model.KeyPairName, model.ToRegion)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, targetClient, keyPair)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("create key pair: %w", err)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, copyResult{
+				SourceName:   model.KeyPairName,
+				SourceRegion: model.Region,
+				TargetName:   utils.PtrString(resp.Name),
+				TargetRegion: model.ToRegion,
+			})
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(toRegionFlag, "", "Region to copy the key pair to")
+	cmd.Flags().String(nameFlag, "", "Name of the copied key pair, defaults to the name of the source key pair")
+
+	err := flags.MarkFlagsRequired(cmd, toRegionFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	keyPairName := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		KeyPairName:     keyPairName,
+		ToRegion:        flags.FlagToStringValue(p, cmd, toRegionFlag),
+		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
+	}
+
+	if model.ToRegion == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    toRegionFlag,
+			Details: "must not be empty",
+		}
+	}
+	if model.ToRegion == model.Region && (model.Name == nil || *model.Name == model.KeyPairName) {
+		return nil, &errors.FlagValidationError{
+			Flag:    nameFlag,
+			Details: "must be set to a new name when copying the key pair within the same region",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, targetClient *iaas.APIClient, keyPair *iaas.Keypair) iaas.ApiCreateKeyPairRequest {
+	name := model.Name
+	if name == nil {
+		name = utils.Ptr(model.KeyPairName)
+	}
+
+	payload := iaas.CreateKeyPairPayload{
+		Name:      name,
+		PublicKey: keyPair.PublicKey,
+		Labels:    keyPair.Labels,
+	}
+	return targetClient.CreateKeyPair(ctx).CreateKeyPairPayload(payload)
+}
+
+func outputResult(p *print.Printer, outputFormat string, result copyResult) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal key pair copy: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal key pair copy: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		p.Outputf("Copied key pair %q in region %q to key pair %q in region %q\n", result.SourceName, result.SourceRegion, result.TargetName, result.TargetRegion)
+		return nil
+	}
+}
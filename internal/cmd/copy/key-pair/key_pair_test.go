@@ -0,0 +1,305 @@
+package keypair
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var regionFlag = globalflags.RegionFlag
+
+type testCtxKey struct{}
+
+var (
+	testCtx         = context.WithValue(context.Background(), testCtxKey{}, "test")
+	testClient      = &iaas.APIClient{}
+	testKeyPairName = "foobar"
+	testRegion      = "eu01"
+	testToRegion    = "eu02"
+	testPublicKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFoo"
+	testLabels      = map[string]interface{}{"foo": "bar"}
+)
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testKeyPairName,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		regionFlag:   testRegion,
+		toRegionFlag: testToRegion,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		KeyPairName: testKeyPairName,
+		ToRegion:    testToRegion,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureKeyPair() *iaas.Keypair {
+	return &iaas.Keypair{
+		Name:        utils.Ptr(testKeyPairName),
+		PublicKey:   utils.Ptr(testPublicKey),
+		Labels:      &testLabels,
+		Fingerprint: utils.Ptr("fingerprint"),
+	}
+}
+
+func fixtureRequest(mods ...func(payload *iaas.CreateKeyPairPayload)) iaas.ApiCreateKeyPairRequest {
+	payload := iaas.CreateKeyPairPayload{
+		Name:      utils.Ptr(testKeyPairName),
+		PublicKey: utils.Ptr(testPublicKey),
+		Labels:    &testLabels,
+	}
+	for _, mod := range mods {
+		mod(&payload)
+	}
+	return testClient.CreateKeyPair(testCtx).CreateKeyPairPayload(payload)
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "to region missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, toRegionFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "to region empty",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toRegionFlag] = ""
+			}),
+			isValid: false,
+		},
+		{
+			description: "with name",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[nameFlag] = "new-name"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Name = utils.Ptr("new-name")
+			}),
+		},
+		{
+			description: "same region without name",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toRegionFlag] = testRegion
+			}),
+			isValid: false,
+		},
+		{
+			description: "same region with same name",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toRegionFlag] = testRegion
+				flagValues[nameFlag] = testKeyPairName
+			}),
+			isValid: false,
+		},
+		{
+			description: "same region with new name",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toRegionFlag] = testRegion
+				flagValues[nameFlag] = "new-name"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ToRegion = testRegion
+				model.Name = utils.Ptr("new-name")
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err = cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description     string
+		model           *inputModel
+		expectedRequest iaas.ApiCreateKeyPairRequest
+	}{
+		{
+			description:     "base",
+			model:           fixtureInputModel(),
+			expectedRequest: fixtureRequest(),
+		},
+		{
+			description: "with name",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Name = utils.Ptr("new-name")
+			}),
+			expectedRequest: fixtureRequest(func(payload *iaas.CreateKeyPairPayload) {
+				payload.Name = utils.Ptr("new-name")
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			request := buildRequest(testCtx, tt.model, testClient, fixtureKeyPair())
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		result       copyResult
+		wantErr      bool
+	}{
+		{
+			description: "empty",
+			result:      copyResult{},
+		},
+		{
+			description: "base",
+			result: copyResult{
+				SourceName:   testKeyPairName,
+				SourceRegion: testRegion,
+				TargetName:   testKeyPairName,
+				TargetRegion: testToRegion,
+			},
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result:       copyResult{SourceName: testKeyPairName},
+		},
+		{
+			description:  "yaml",
+			outputFormat: print.YAMLOutputFormat,
+			result:       copyResult{SourceName: testKeyPairName},
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := outputResult(p, tt.outputFormat, tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package securitygroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	securityGroupIdArg = "SECURITY_GROUP_ID"
+
+	toProjectIdFlag = "to-project-id"
+	toRegionFlag    = "to-region"
+
+	resourceTypeSecurityGroup     = "security-group"
+	resourceTypeSecurityGroupRule = "security-group-rule"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	SecurityGroupId string
+	ToProjectId     string
+	ToRegion        string
+}
+
+type securityGroupClient interface {
+	GetSecurityGroupExecute(ctx context.Context, projectId, securityGroupId string) (*iaas.SecurityGroup, error)
+}
+
+// resourceMapping maps a source resource to its copy
+type resourceMapping struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	SourceId string `json:"sourceId"`
+	TargetId string `json:"targetId"`
+}
+
+type copyResult struct {
+	SourceProjectId string            `json:"sourceProjectId"`
+	SourceRegion    string            `json:"sourceRegion"`
+	TargetProjectId string            `json:"targetProjectId"`
+	TargetRegion    string            `json:"targetRegion"`
+	Mappings        []resourceMapping `json:"mappings"`
+}
+
+// ruleCopy is a rule of a source security group to create in its copy
+type ruleCopy struct {
+	SourceId string
+	Rule     rulefile.Rule
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("security-group %s", securityGroupIdArg),
+		Short: "Copies a security group with its rules to another project or region",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Copies a security group with its rules to another project or region.",
+			"Security groups referenced as remote security group by a rule are copied as well, and the rules of the copies reference the copied security groups.",
+			"Rules which already exist in a copy, like the default rules of a new security group, are not created again, and rules of a copy which don't exist in the source, like default rules removed from the source, are deleted. The output maps the IDs of the source resources to the IDs of their copies.",
+		),
+		Args: args.SingleArg(securityGroupIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Copy the security group with ID "xxx" to the project with ID "yyy"`,
+				"$ stackit copy security-group xxx --to-project-id yyy"),
+			examples.NewExample(
+				`Copy the security group with ID "xxx" to the project with ID "yyy" in the region "eu02"`,
+				"$ stackit copy security-group xxx --to-project-id yyy --to-region eu02"),
+			examples.NewExample(
+				`Copy the security group with ID "xxx" to the project with ID "yyy" and show the ID mapping in JSON format`,
+				"$ stackit copy security-group xxx --to-project-id yyy --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API clients
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+			targetClient, err := client.ConfigureClientForRegion(params.Printer, params.CliVersion, model.ToRegion)
+			if err != nil {
+				return err
+			}
+
+			groups, err := collectSecurityGroups(ctx, apiClient, model.ProjectId, model.SecurityGroupId)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				names := make([]string, 0, len(groups))
+				for i := range groups {
+					names = append(names, fmt.Sprintf("%q", utils.PtrString(groups[i].Name)))
+				}
+				prompt := fmt.Sprintf("Are you sure you want to copy the security groups %s to project %q in region %q?", strings.Join(names, ", "), model.ToProjectId, model.ToRegion)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			s := spinner.New(params.Printer)
+			s.Start("Copying security groups")
+			mappings, err := copySecurityGroups(ctx, model, targetClient, groups)
+			if err != nil {
+				s.StopWithError()
+				for _, mapping := range mappings {
+					if mapping.Type == resourceTypeSecurityGroup {
+						params.Printer.Warn("Security group %q has already been copied to %s in the target project\n", mapping.Name, mapping.TargetId)
+					}
+				}
+				return err
+			}
+			s.Stop()
+
+			return outputResult(params.Printer, model.OutputFormat, copyResult{
+				SourceProjectId: model.ProjectId,
+				SourceRegion:    model.Region,
+				TargetProjectId: model.ToProjectId,
+				TargetRegion:    model.ToRegion,
+				Mappings:        mappings,
+			})
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), toProjectIdFlag, "ID of the project to copy the security group to")
+	cmd.Flags().String(toRegionFlag, "", "Region to copy the security group to, defaults to the region of the source security group")
+
+	err := flags.MarkFlagsRequired(cmd, toProjectIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	securityGroupId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	toRegion := flags.FlagToStringValue(p, cmd, toRegionFlag)
+	if toRegion == "" {
+		toRegion = globalFlags.Region
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		SecurityGroupId: securityGroupId,
+		ToProjectId:     flags.FlagToStringValue(p, cmd, toProjectIdFlag),
+		ToRegion:        toRegion,
+	}
+
+	if model.ToProjectId == model.ProjectId && model.ToRegion == model.Region {
+		return nil, &errors.FlagValidationError{
+			Flag:    toProjectIdFlag,
+			Details: "the target project and region must differ from the source",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// collectSecurityGroups returns the security group and all security groups its rules reference, directly or indirectly
+func collectSecurityGroups(ctx context.Context, apiClient securityGroupClient, projectId, securityGroupId string) ([]iaas.SecurityGroup, error) {
+	groups := []iaas.SecurityGroup{}
+	queued := map[string]bool{securityGroupId: true}
+	queue := []string{securityGroupId}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		group, err := apiClient.GetSecurityGroupExecute(ctx, projectId, id)
+		if err != nil {
+			return nil, fmt.Errorf("get security group %q: %w", id, err)
+		}
+		groups = append(groups, *group)
+
+		for _, rule := range utils.PtrValue(group.Rules) {
+			remoteId := utils.PtrString(rule.RemoteSecurityGroupId)
+			if remoteId != "" && !queued[remoteId] {
+				queued[remoteId] = true
+				queue = append(queue, remoteId)
+			}
+		}
+	}
+	return groups, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, targetClient *iaas.APIClient, group *iaas.SecurityGroup) iaas.ApiCreateSecurityGroupRequest {
+	payload := iaas.CreateSecurityGroupPayload{
+		Name:        group.Name,
+		Description: group.Description,
+		Labels:      group.Labels,
+		Stateful:    group.Stateful,
+	}
+	return targetClient.CreateSecurityGroup(ctx, model.ToProjectId).CreateSecurityGroupPayload(payload)
+}
+
+// copySecurityGroups creates all security groups before their rules, so that remote security groups can be rewritten to the copies.
+// The mappings of the resources copied so far are returned on errors as well.
+func copySecurityGroups(ctx context.Context, model *inputModel, targetClient *iaas.APIClient, groups []iaas.SecurityGroup) ([]resourceMapping, error) {
+	mappings := []resourceMapping{}
+	groupIds := map[string]string{}
+	for i := range groups {
+		group := &groups[i]
+		resp, err := buildRequest(ctx, model, targetClient, group).Execute()
+		if err != nil {
+			return mappings, fmt.Errorf("create security group %q: %w", utils.PtrString(group.Name), err)
+		}
+		groupIds[utils.PtrString(group.Id)] = utils.PtrString(resp.Id)
+		mappings = append(mappings, resourceMapping{
+			Type:     resourceTypeSecurityGroup,
+			Name:     utils.PtrString(group.Name),
+			SourceId: utils.PtrString(group.Id),
+			TargetId: utils.PtrString(resp.Id),
+		})
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		targetGroupId := groupIds[utils.PtrString(group.Id)]
+		existing, err := targetClient.ListSecurityGroupRulesExecute(ctx, model.ToProjectId, targetGroupId)
+		if err != nil {
+			return mappings, fmt.Errorf("list security group rules of %q: %w", targetGroupId, err)
+		}
+
+		toCreate, toDelete, existingMappings := planRules(utils.PtrValue(group.Rules), utils.PtrValue(existing.Items), groupIds)
+		mappings = append(mappings, existingMappings...)
+		for j := range toCreate {
+			resp, err := targetClient.CreateSecurityGroupRule(ctx, model.ToProjectId, targetGroupId).
+				CreateSecurityGroupRulePayload(toCreate[j].Rule.CreatePayload()).
+				Execute()
+			if err != nil {
+				return mappings, fmt.Errorf("create security group rule in %q: %w", targetGroupId, err)
+			}
+			mappings = append(mappings, resourceMapping{
+				Type:     resourceTypeSecurityGroupRule,
+				Name:     toCreate[j].Rule.Description,
+				SourceId: toCreate[j].SourceId,
+				TargetId: utils.PtrString(resp.Id),
+			})
+		}
+		for _, ruleId := range toDelete {
+			err = targetClient.DeleteSecurityGroupRuleExecute(ctx, model.ToProjectId, targetGroupId, ruleId)
+			if err != nil {
+				return mappings, fmt.Errorf("delete security group rule %q in %q: %w", ruleId, targetGroupId, err)
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// planRules rewrites the remote security groups of the source rules to the IDs of their copies.
+// Rules which already exist in the copy are mapped to the existing rules, the others are returned to be created.
+// The IDs of the existing rules which don't match a source rule are returned to be deleted.
+func planRules(source, existing []iaas.SecurityGroupRule, groupIds map[string]string) (toCreate []ruleCopy, toDelete []string, mappings []resourceMapping) {
+	existingIds := map[string][]string{}
+	for i := range existing {
+		rule := rulefile.FromSecurityGroupRule(&existing[i])
+		key := rule.Key()
+		existingIds[key] = append(existingIds[key], utils.PtrString(existing[i].Id))
+	}
+
+	toCreate = []ruleCopy{}
+	mappings = []resourceMapping{}
+	for i := range source {
+		rule := rulefile.FromSecurityGroupRule(&source[i])
+		if rule.RemoteSecurityGroupId != "" {
+			rule.RemoteSecurityGroupId = groupIds[rule.RemoteSecurityGroupId]
+		}
+
+		key := rule.Key()
+		if ids := existingIds[key]; len(ids) > 0 {
+			existingIds[key] = ids[1:]
+			mappings = append(mappings, resourceMapping{
+				Type:     resourceTypeSecurityGroupRule,
+				Name:     rule.Description,
+				SourceId: utils.PtrString(source[i].Id),
+				TargetId: ids[0],
+			})
+			continue
+		}
+		toCreate = append(toCreate, ruleCopy{
+			SourceId: utils.PtrString(source[i].Id),
+			Rule:     rule,
+		})
+	}
+
+	matched := map[string]bool{}
+	for i := range mappings {
+		matched[mappings[i].TargetId] = true
+	}
+	toDelete = []string{}
+	for i := range existing {
+		id := utils.PtrString(existing[i].Id)
+		if !matched[id] {
+			toDelete = append(toDelete, id)
+		}
+	}
+	return toCreate, toDelete, mappings
+}
+
+func outputResult(p *print.Printer, outputFormat string, result copyResult) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal security group copy: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal security group copy: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("TYPE", "NAME", "SOURCE ID", "TARGET ID")
+		for _, mapping := range result.Mappings {
+			table.AddRow(mapping.Type, mapping.Name, mapping.SourceId, mapping.TargetId)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		p.Outputf("Copied security groups from project %q in region %q to project %q in region %q\n", result.SourceProjectId, result.SourceRegion, result.TargetProjectId, result.TargetRegion)
+		return nil
+	}
+}
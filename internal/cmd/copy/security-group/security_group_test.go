@@ -0,0 +1,537 @@
+package securitygroup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var (
+	projectIdFlag = globalflags.ProjectIdFlag
+	regionFlag    = globalflags.RegionFlag
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx             = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient          = &iaas.APIClient{}
+	testProjectId       = uuid.NewString()
+	testToProjectId     = uuid.NewString()
+	testSecurityGroupId = uuid.NewString()
+	testRegion          = "eu01"
+	testToRegion        = "eu02"
+)
+
+type mockSecurityGroupClient struct {
+	groups map[string]*iaas.SecurityGroup
+}
+
+func (m *mockSecurityGroupClient) GetSecurityGroupExecute(_ context.Context, _, securityGroupId string) (*iaas.SecurityGroup, error) {
+	group, ok := m.groups[securityGroupId]
+	if !ok {
+		return nil, fmt.Errorf("security group %s not found", securityGroupId)
+	}
+	return group, nil
+}
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testSecurityGroupId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag:   testProjectId,
+		regionFlag:      testRegion,
+		toProjectIdFlag: testToProjectId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		SecurityGroupId: testSecurityGroupId,
+		ToProjectId:     testToProjectId,
+		ToRegion:        testRegion,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureSecurityGroup() *iaas.SecurityGroup {
+	return &iaas.SecurityGroup{
+		Id:          utils.Ptr(testSecurityGroupId),
+		Name:        utils.Ptr("web"),
+		Description: utils.Ptr("web servers"),
+		Labels:      &map[string]interface{}{"foo": "bar"},
+		Stateful:    utils.Ptr(true),
+		Rules:       &[]iaas.SecurityGroupRule{},
+	}
+}
+
+func fixtureRequest(mods ...func(payload *iaas.CreateSecurityGroupPayload)) iaas.ApiCreateSecurityGroupRequest {
+	payload := iaas.CreateSecurityGroupPayload{
+		Name:        utils.Ptr("web"),
+		Description: utils.Ptr("web servers"),
+		Labels:      &map[string]interface{}{"foo": "bar"},
+		Stateful:    utils.Ptr(true),
+	}
+	for _, mod := range mods {
+		mod(&payload)
+	}
+	return testClient.CreateSecurityGroup(testCtx, testToProjectId).CreateSecurityGroupPayload(payload)
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "security group id invalid",
+			argValues:   []string{"invalid-uuid"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "to project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, toProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "to project id invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "to region",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toRegionFlag] = testToRegion
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ToRegion = testToRegion
+			}),
+		},
+		{
+			description: "same project and region",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toProjectIdFlag] = testProjectId
+			}),
+			isValid: false,
+		},
+		{
+			description: "same project in other region",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toProjectIdFlag] = testProjectId
+				flagValues[toRegionFlag] = testToRegion
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ToProjectId = testProjectId
+				model.ToRegion = testToRegion
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err = cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description     string
+		model           *inputModel
+		group           *iaas.SecurityGroup
+		expectedRequest iaas.ApiCreateSecurityGroupRequest
+	}{
+		{
+			description:     "base",
+			model:           fixtureInputModel(),
+			group:           fixtureSecurityGroup(),
+			expectedRequest: fixtureRequest(),
+		},
+		{
+			description: "only name",
+			model:       fixtureInputModel(),
+			group:       &iaas.SecurityGroup{Id: utils.Ptr(testSecurityGroupId), Name: utils.Ptr("web")},
+			expectedRequest: fixtureRequest(func(payload *iaas.CreateSecurityGroupPayload) {
+				payload.Description = nil
+				payload.Labels = nil
+				payload.Stateful = nil
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			request := buildRequest(testCtx, tt.model, testClient, tt.group)
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectSecurityGroups(t *testing.T) {
+	webId, dbId, cacheId := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	groups := map[string]*iaas.SecurityGroup{
+		webId: {
+			Id: utils.Ptr(webId),
+			Rules: &[]iaas.SecurityGroupRule{
+				{Direction: utils.Ptr("ingress"), RemoteSecurityGroupId: utils.Ptr(webId)},
+				{Direction: utils.Ptr("egress"), RemoteSecurityGroupId: utils.Ptr(dbId)},
+				{Direction: utils.Ptr("egress"), RemoteSecurityGroupId: utils.Ptr(cacheId)},
+			},
+		},
+		dbId: {
+			Id: utils.Ptr(dbId),
+			Rules: &[]iaas.SecurityGroupRule{
+				{Direction: utils.Ptr("ingress"), RemoteSecurityGroupId: utils.Ptr(webId)},
+			},
+		},
+		cacheId: {
+			Id: utils.Ptr(cacheId),
+			Rules: &[]iaas.SecurityGroupRule{
+				{Direction: utils.Ptr("ingress"), RemoteSecurityGroupId: utils.Ptr(dbId)},
+			},
+		},
+	}
+
+	tests := []struct {
+		description string
+		groupId     string
+		groups      map[string]*iaas.SecurityGroup
+		isValid     bool
+		expectedIds []string
+	}{
+		{
+			description: "referenced groups",
+			groupId:     webId,
+			groups:      groups,
+			isValid:     true,
+			expectedIds: []string{webId, dbId, cacheId},
+		},
+		{
+			description: "referenced groups from other group",
+			groupId:     cacheId,
+			groups:      groups,
+			isValid:     true,
+			expectedIds: []string{cacheId, dbId, webId},
+		},
+		{
+			description: "no rules",
+			groupId:     webId,
+			groups:      map[string]*iaas.SecurityGroup{webId: {Id: utils.Ptr(webId)}},
+			isValid:     true,
+			expectedIds: []string{webId},
+		},
+		{
+			description: "referenced group not found",
+			groupId:     webId,
+			groups:      map[string]*iaas.SecurityGroup{webId: groups[webId]},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mockSecurityGroupClient{groups: tt.groups}
+			result, err := collectSecurityGroups(testCtx, client, testProjectId, tt.groupId)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error collecting security groups: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			ids := []string{}
+			for i := range result {
+				ids = append(ids, utils.PtrString(result[i].Id))
+			}
+			diff := cmp.Diff(ids, tt.expectedIds)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPlanRules(t *testing.T) {
+	sourceGroupId, targetGroupId := uuid.NewString(), uuid.NewString()
+	groupIds := map[string]string{sourceGroupId: targetGroupId}
+
+	defaultEgress := iaas.SecurityGroupRule{Id: utils.Ptr("source-egress"), Direction: utils.Ptr("egress"), Ethertype: utils.Ptr("IPv4")}
+	ssh := iaas.SecurityGroupRule{
+		Id:          utils.Ptr("source-ssh"),
+		Description: utils.Ptr("ssh"),
+		Direction:   utils.Ptr("ingress"),
+		Ethertype:   utils.Ptr("IPv4"),
+		Protocol:    &iaas.Protocol{Name: utils.Ptr("tcp")},
+		PortRange:   &iaas.PortRange{Min: utils.Ptr(int64(22)), Max: utils.Ptr(int64(22))},
+		IpRange:     utils.Ptr("10.0.0.0/8"),
+	}
+	internal := iaas.SecurityGroupRule{
+		Id:                    utils.Ptr("source-internal"),
+		Direction:             utils.Ptr("ingress"),
+		Ethertype:             utils.Ptr("IPv4"),
+		RemoteSecurityGroupId: utils.Ptr(sourceGroupId),
+	}
+	targetEgress := iaas.SecurityGroupRule{Id: utils.Ptr("target-egress"), Direction: utils.Ptr("egress"), Ethertype: utils.Ptr("IPv4")}
+
+	tests := []struct {
+		description      string
+		source           []iaas.SecurityGroupRule
+		existing         []iaas.SecurityGroupRule
+		expectedCreate   []ruleCopy
+		expectedDelete   []string
+		expectedMappings []resourceMapping
+	}{
+		{
+			description:      "no rules",
+			source:           []iaas.SecurityGroupRule{},
+			existing:         []iaas.SecurityGroupRule{},
+			expectedCreate:   []ruleCopy{},
+			expectedDelete:   []string{},
+			expectedMappings: []resourceMapping{},
+		},
+		{
+			description: "default rule exists",
+			source:      []iaas.SecurityGroupRule{defaultEgress, ssh},
+			existing:    []iaas.SecurityGroupRule{targetEgress},
+			expectedCreate: []ruleCopy{
+				{SourceId: "source-ssh", Rule: rulefile.FromSecurityGroupRule(&ssh)},
+			},
+			expectedDelete: []string{},
+			expectedMappings: []resourceMapping{
+				{Type: resourceTypeSecurityGroupRule, SourceId: "source-egress", TargetId: "target-egress"},
+			},
+		},
+		{
+			description: "existing rule is only matched once",
+			source:      []iaas.SecurityGroupRule{defaultEgress, defaultEgress},
+			existing:    []iaas.SecurityGroupRule{targetEgress},
+			expectedCreate: []ruleCopy{
+				{SourceId: "source-egress", Rule: rulefile.FromSecurityGroupRule(&defaultEgress)},
+			},
+			expectedDelete: []string{},
+			expectedMappings: []resourceMapping{
+				{Type: resourceTypeSecurityGroupRule, SourceId: "source-egress", TargetId: "target-egress"},
+			},
+		},
+		{
+			description: "remote security group is rewritten",
+			source:      []iaas.SecurityGroupRule{internal},
+			existing:    []iaas.SecurityGroupRule{},
+			expectedCreate: []ruleCopy{
+				{
+					SourceId: "source-internal",
+					Rule: rulefile.Rule{
+						Direction:             "ingress",
+						Ethertype:             "IPv4",
+						RemoteSecurityGroupId: targetGroupId,
+					},
+				},
+			},
+			expectedDelete:   []string{},
+			expectedMappings: []resourceMapping{},
+		},
+		{
+			description:      "default rule removed from source",
+			source:           []iaas.SecurityGroupRule{ssh},
+			existing:         []iaas.SecurityGroupRule{targetEgress},
+			expectedCreate:   []ruleCopy{{SourceId: "source-ssh", Rule: rulefile.FromSecurityGroupRule(&ssh)}},
+			expectedDelete:   []string{"target-egress"},
+			expectedMappings: []resourceMapping{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			toCreate, toDelete, mappings := planRules(tt.source, tt.existing, groupIds)
+			diff := cmp.Diff(toCreate, tt.expectedCreate)
+			if diff != "" {
+				t.Fatalf("Rules to create do not match: %s", diff)
+			}
+			diff = cmp.Diff(toDelete, tt.expectedDelete)
+			if diff != "" {
+				t.Fatalf("Rules to delete do not match: %s", diff)
+			}
+			diff = cmp.Diff(mappings, tt.expectedMappings)
+			if diff != "" {
+				t.Fatalf("Mappings do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	result := copyResult{
+		SourceProjectId: testProjectId,
+		SourceRegion:    testRegion,
+		TargetProjectId: testToProjectId,
+		TargetRegion:    testRegion,
+		Mappings: []resourceMapping{
+			{Type: resourceTypeSecurityGroup, Name: "web", SourceId: testSecurityGroupId, TargetId: uuid.NewString()},
+			{Type: resourceTypeSecurityGroupRule, SourceId: uuid.NewString(), TargetId: uuid.NewString()},
+		},
+	}
+
+	tests := []struct {
+		description  string
+		outputFormat string
+		result       copyResult
+		wantErr      bool
+	}{
+		{
+			description: "empty",
+			result:      copyResult{},
+		},
+		{
+			description: "base",
+			result:      result,
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result:       result,
+		},
+		{
+			description:  "yaml",
+			outputFormat: print.YAMLOutputFormat,
+			result:       result,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := outputResult(p, tt.outputFormat, tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/db/utils"
+)
+
+const (
+	serviceFlag       = "service"
+	instanceIdFlag    = "instance-id"
+	userIdFlag        = "user-id"
+	databaseFlag      = "database"
+	resetPasswordFlag = "reset-password"
+	clientFlag        = "client"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	Service       string
+	InstanceId    string
+	UserId        string
+	Database      *string
+	ResetPassword bool
+	Client        string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connect",
+		Short: "Connects to a database instance using the matching local client",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Connects to a database instance by launching the matching local client: psql for PostgreSQL Flex, mongosh for MongoDB Flex, sqlcmd for SQLServer Flex, mysql for MariaDB and redis-cli for Redis.",
+			"The password is passed to the client through the environment and never on the command line.",
+			fmt.Sprintf("The password of a PostgreSQL Flex, MongoDB Flex or SQLServer Flex user can't be retrieved after its creation, so the client prompts for it, unless the --%s flag is set.", resetPasswordFlag),
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Connect to the PostgreSQL Flex instance with ID "xxx" as the user with ID "yyy"`,
+				"$ stackit db connect --service postgresflex --instance-id xxx --user-id yyy --database mydb"),
+			examples.NewExample(
+				`Connect to the Redis instance with ID "xxx" using the credentials with ID "yyy"`,
+				"$ stackit db connect --service redis --instance-id xxx --user-id yyy"),
+			examples.NewExample(
+				`Connect to the MariaDB instance with ID "xxx" using the credentials with ID "yyy" and the mariadb client`,
+				"$ stackit db connect --service mariadb --instance-id xxx --user-id yyy --client mariadb"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			if model.ResetPassword && !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to reset the password of user %q of instance %q? (This cannot be undone)", model.UserId, model.InstanceId)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			info, err := dbUtils.GetConnectionInfo(ctx, params.Printer, params.CliVersion, buildConnectionInfoOptions(model))
+			if err != nil {
+				return err
+			}
+			if model.Database != nil {
+				info.Database = *model.Database
+			}
+
+			clientCommand, err := dbUtils.BuildClientCommand(info, model.Client)
+			if err != nil {
+				return err
+			}
+			clientPath, err := exec.LookPath(clientCommand.Name)
+			if err != nil {
+				return fmt.Errorf("find client %q, make sure it is installed or set its path with --%s: %w", clientCommand.Name, clientFlag, err)
+			}
+
+			params.Printer.Debug(print.DebugLevel, "running %s with arguments %q", clientPath, clientCommand.Args)
+			execCmd := exec.Command(clientPath, clientCommand.Args...) // #nosec G204
+			execCmd.Env = append(os.Environ(), clientCommand.Env...)
+			execCmd.Stdin = os.Stdin
+			execCmd.Stdout = os.Stdout
+			execCmd.Stderr = os.Stderr
+			err = execCmd.Run()
+			if err != nil {
+				return fmt.Errorf("run %s: %w", clientCommand.Name, err)
+			}
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	services := dbUtils.AvailableServices()
+
+	cmd.Flags().Var(flags.EnumFlag(false, "", services...), serviceFlag, fmt.Sprintf("Database service, one of %q", services))
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+	cmd.Flags().String(userIdFlag, "", "ID of the user (for PostgreSQL Flex, MongoDB Flex and SQLServer Flex) or of the credentials (for the other services)")
+	cmd.Flags().String(databaseFlag, "", "Name of the database to connect to. If not set, the default database of the user is used, if any")
+	cmd.Flags().Bool(resetPasswordFlag, false, "Reset the password of the user and pass the new one to the client. Only needed for PostgreSQL Flex, MongoDB Flex and SQLServer Flex")
+	cmd.Flags().String(clientFlag, "", "Name or path of the client executable. If not set, the default client of the service is used")
+
+	err := flags.MarkFlagsRequired(cmd, serviceFlag, instanceIdFlag, userIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Service:         flags.FlagToStringValue(p, cmd, serviceFlag),
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		UserId:          flags.FlagToStringValue(p, cmd, userIdFlag),
+		Database:        flags.FlagToStringPointer(p, cmd, databaseFlag),
+		ResetPassword:   flags.FlagToBoolValue(p, cmd, resetPasswordFlag),
+		Client:          flags.FlagToStringValue(p, cmd, clientFlag),
+	}
+
+	if !dbUtils.SupportsClient(model.Service) {
+		return nil, &errors.FlagValidationError{
+			Flag:    serviceFlag,
+			Details: fmt.Sprintf("connecting to service %q is not supported, use \"stackit db connection-string\" instead", model.Service),
+		}
+	}
+	if model.ResetPassword && dbUtils.SupportsPasswordRetrieval(model.Service) {
+		return nil, &errors.FlagValidationError{
+			Flag:    resetPasswordFlag,
+			Details: fmt.Sprintf("resetting the password is not supported for service %q, the password is part of the credentials", model.Service),
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildConnectionInfoOptions(model *inputModel) *dbUtils.ConnectionInfoOptions {
+	return &dbUtils.ConnectionInfoOptions{
+		Service:       model.Service,
+		ProjectId:     model.ProjectId,
+		Region:        model.Region,
+		InstanceId:    model.InstanceId,
+		UserId:        model.UserId,
+		ResetPassword: model.ResetPassword,
+	}
+}
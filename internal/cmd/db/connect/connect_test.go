@@ -0,0 +1,192 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/db/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testUserId = "12345"
+var testRegion = "eu01"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		serviceFlag:               dbUtils.ServicePostgresFlex,
+		instanceIdFlag:            testInstanceId,
+		userIdFlag:                testUserId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Service:    dbUtils.ServicePostgresFlex,
+		InstanceId: testInstanceId,
+		UserId:     testUserId,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[databaseFlag] = "mydb"
+				flagValues[resetPasswordFlag] = "true"
+				flagValues[clientFlag] = "/usr/local/bin/psql"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Database = utils.Ptr("mydb")
+				model.ResetPassword = true
+				model.Client = "/usr/local/bin/psql"
+			}),
+		},
+		{
+			description: "redis",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceRedis
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Service = dbUtils.ServiceRedis
+			}),
+		},
+		{
+			description: "service without client",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceOpenSearch
+			}),
+			isValid: false,
+		},
+		{
+			description: "reset password not supported by service",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceRedis
+				flagValues[resetPasswordFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "user id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, userIdFlag)
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildConnectionInfoOptions(t *testing.T) {
+	model := fixtureInputModel()
+	expected := &dbUtils.ConnectionInfoOptions{
+		Service:    dbUtils.ServicePostgresFlex,
+		ProjectId:  testProjectId,
+		Region:     testRegion,
+		InstanceId: testInstanceId,
+		UserId:     testUserId,
+	}
+
+	diff := cmp.Diff(buildConnectionInfoOptions(model), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
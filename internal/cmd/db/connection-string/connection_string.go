@@ -0,0 +1,202 @@
+package connectionstring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/db/utils"
+)
+
+const (
+	serviceFlag       = "service"
+	instanceIdFlag    = "instance-id"
+	userIdFlag        = "user-id"
+	formatFlag        = "format"
+	databaseFlag      = "database"
+	resetPasswordFlag = "reset-password"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	Service       string
+	InstanceId    string
+	UserId        string
+	Format        string
+	Database      *string
+	ResetPassword bool
+}
+
+type connectionString struct {
+	Service          string `json:"service"`
+	Format           string `json:"format"`
+	ConnectionString string `json:"connectionString"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connection-string",
+		Short: "Generates a connection string for a database instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Generates a connection string for a user (or credentials) of a database instance.",
+			"The password of a PostgreSQL Flex, MongoDB Flex or SQLServer Flex user can't be retrieved after its creation, so it is not part of the connection string.",
+			fmt.Sprintf("Use the --%s flag to reset the password of the user and include the new one.", resetPasswordFlag),
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Generate a URI to connect to the PostgreSQL Flex instance with ID "xxx" as the user with ID "yyy"`,
+				"$ stackit db connection-string --service postgresflex --instance-id xxx --user-id yyy"),
+			examples.NewExample(
+				`Generate a JDBC connection string for the database "mydb" of the PostgreSQL Flex instance with ID "xxx", resetting the password of the user with ID "yyy"`,
+				"$ stackit db connection-string --service postgresflex --instance-id xxx --user-id yyy --database mydb --format jdbc --reset-password"),
+			examples.NewExample(
+				`Generate environment variables to connect to the Redis instance with ID "xxx" using the credentials with ID "yyy"`,
+				"$ stackit db connection-string --service redis --instance-id xxx --user-id yyy --format env"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			if model.ResetPassword && !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to reset the password of user %q of instance %q? (This cannot be undone)", model.UserId, model.InstanceId)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			info, err := dbUtils.GetConnectionInfo(ctx, params.Printer, params.CliVersion, buildConnectionInfoOptions(model))
+			if err != nil {
+				return err
+			}
+			if model.Database != nil {
+				info.Database = *model.Database
+			}
+			if info.Password == "" && !dbUtils.SupportsPasswordRetrieval(model.Service) {
+				params.Printer.Warn("The password of the user can't be retrieved, so it is not included in the connection string. Use --%s to reset it.\n", resetPasswordFlag)
+			}
+
+			connStr, err := dbUtils.BuildConnectionString(info, model.Format)
+			if err != nil {
+				return fmt.Errorf("build connection string: %w", err)
+			}
+
+			return outputResult(params.Printer, model, connStr)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	services := dbUtils.AvailableServices()
+	formats := dbUtils.AvailableFormats()
+
+	cmd.Flags().Var(flags.EnumFlag(false, "", services...), serviceFlag, fmt.Sprintf("Database service, one of %q", services))
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+	cmd.Flags().String(userIdFlag, "", "ID of the user (for PostgreSQL Flex, MongoDB Flex and SQLServer Flex) or of the credentials (for the other services)")
+	cmd.Flags().Var(flags.EnumFlag(false, dbUtils.FormatURI, formats...), formatFlag, fmt.Sprintf("Format of the connection string, one of %q", formats))
+	cmd.Flags().String(databaseFlag, "", "Name of the database to connect to. If not set, the default database of the user is used, if any")
+	cmd.Flags().Bool(resetPasswordFlag, false, "Reset the password of the user to include it in the connection string. Only needed for PostgreSQL Flex, MongoDB Flex and SQLServer Flex")
+
+	err := flags.MarkFlagsRequired(cmd, serviceFlag, instanceIdFlag, userIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Service:         flags.FlagToStringValue(p, cmd, serviceFlag),
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		UserId:          flags.FlagToStringValue(p, cmd, userIdFlag),
+		Format:          flags.FlagWithDefaultToStringValue(p, cmd, formatFlag),
+		Database:        flags.FlagToStringPointer(p, cmd, databaseFlag),
+		ResetPassword:   flags.FlagToBoolValue(p, cmd, resetPasswordFlag),
+	}
+
+	err := dbUtils.ValidateFormat(model.Service, model.Format)
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    formatFlag,
+			Details: err.Error(),
+		}
+	}
+	if model.ResetPassword && dbUtils.SupportsPasswordRetrieval(model.Service) {
+		return nil, &errors.FlagValidationError{
+			Flag:    resetPasswordFlag,
+			Details: fmt.Sprintf("resetting the password is not supported for service %q, the password is part of the credentials", model.Service),
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildConnectionInfoOptions(model *inputModel) *dbUtils.ConnectionInfoOptions {
+	return &dbUtils.ConnectionInfoOptions{
+		Service:       model.Service,
+		ProjectId:     model.ProjectId,
+		Region:        model.Region,
+		InstanceId:    model.InstanceId,
+		UserId:        model.UserId,
+		ResetPassword: model.ResetPassword,
+	}
+}
+
+func outputResult(p *print.Printer, model *inputModel, connStr string) error {
+	result := connectionString{
+		Service:          model.Service,
+		Format:           model.Format,
+		ConnectionString: connStr,
+	}
+
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal connection string: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal connection string: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		p.Outputln(connStr)
+
+		return nil
+	}
+}
@@ -0,0 +1,269 @@
+package connectionstring
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/db/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testUserId = "12345"
+var testRegion = "eu01"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		serviceFlag:               dbUtils.ServicePostgresFlex,
+		instanceIdFlag:            testInstanceId,
+		userIdFlag:                testUserId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Service:    dbUtils.ServicePostgresFlex,
+		InstanceId: testInstanceId,
+		UserId:     testUserId,
+		Format:     dbUtils.FormatURI,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[formatFlag] = dbUtils.FormatJDBC
+				flagValues[databaseFlag] = "mydb"
+				flagValues[resetPasswordFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Format = dbUtils.FormatJDBC
+				model.Database = utils.Ptr("mydb")
+				model.ResetPassword = true
+			}),
+		},
+		{
+			description: "dsa service",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceRedis
+				flagValues[formatFlag] = dbUtils.FormatEnv
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Service = dbUtils.ServiceRedis
+				model.Format = dbUtils.FormatEnv
+			}),
+		},
+		{
+			description: "format not supported by service",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceRabbitMQ
+				flagValues[formatFlag] = dbUtils.FormatJDBC
+			}),
+			isValid: false,
+		},
+		{
+			description: "reset password not supported by service",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = dbUtils.ServiceMariaDB
+				flagValues[resetPasswordFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid service",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serviceFlag] = "mysql"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[formatFlag] = "xml"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "service missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, serviceFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "user id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, userIdFlag)
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildConnectionInfoOptions(t *testing.T) {
+	model := fixtureInputModel(func(model *inputModel) {
+		model.ResetPassword = true
+	})
+	expected := &dbUtils.ConnectionInfoOptions{
+		Service:       dbUtils.ServicePostgresFlex,
+		ProjectId:     testProjectId,
+		Region:        testRegion,
+		InstanceId:    testInstanceId,
+		UserId:        testUserId,
+		ResetPassword: true,
+	}
+
+	diff := cmp.Diff(buildConnectionInfoOptions(model), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		model   *inputModel
+		connStr string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "default output",
+			args: args{
+				model:   fixtureInputModel(),
+				connStr: "postgresql://username@host:5432",
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.OutputFormat = print.JSONOutputFormat
+				}),
+				connStr: "postgresql://username@host:5432",
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.OutputFormat = print.YAMLOutputFormat
+				}),
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, tt.args.connStr); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/db/connect"
+	connectionstring "github.com/stackitcloud/stackit-cli/internal/cmd/db/connection-string"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Provides functionality to connect to the database services",
+		Long:  "Provides functionality to connect to the instances of the STACKIT database services (PostgreSQL Flex, MongoDB Flex, SQLServer Flex, MariaDB, Redis, RabbitMQ, OpenSearch and LogMe).",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(connectionstring.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
+}
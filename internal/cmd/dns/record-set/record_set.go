@@ -5,6 +5,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/record-set/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/record-set/describe"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/record-set/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/record-set/sync"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/record-set/update"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(sync.NewCmd(params))
 }
@@ -0,0 +1,315 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+const (
+	zoneIdFlag      = "zone-id"
+	fileFlag        = "file"
+	ownerTagFlag    = "owner-tag"
+	dryRunFlag      = "dry-run"
+	parallelismFlag = "parallelism"
+
+	defaultParallelism = 5
+	txtType            = "TXT"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ZoneId      string
+	FilePath    string
+	OwnerTag    *string
+	DryRun      bool
+	Parallelism int64
+}
+
+// recordSetsFile is the format of the file with the desired record sets
+type recordSetsFile struct {
+	RecordSets []recordSetEntry `yaml:"recordSets"`
+}
+
+type recordSetEntry struct {
+	Name    string   `yaml:"name"`
+	Type    string   `yaml:"type"`
+	TTL     *int64   `yaml:"ttl"`
+	Records []string `yaml:"records"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Syncs the record sets of a DNS zone with a file",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Syncs the record sets of a DNS zone with the record sets defined in a YAML or JSON file.",
+			"Record sets of the file which don't exist in the zone are created, record sets with different records or TTL are updated and record sets of the zone which are not in the file are deleted.",
+			fmt.Sprintf("If the --%s flag is set, only record sets whose comment contains \"managed-by=<OWNER_TAG>\" are updated or deleted, and created record sets get this comment.", ownerTagFlag),
+			"The SOA and NS record sets at the zone apex are managed by STACKIT DNS and are never changed.",
+			`The file has the format: {"recordSets": [{"name": "www", "type": "A", "ttl": 300, "records": ["1.2.3.4"]}]}. Names are relative to the zone, unless they end with a dot. If the TTL is not set, the default TTL of the zone is used.`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Sync the record sets of the DNS zone with ID "xxx" with the file "records.yaml", only touching record sets with owner tag "infra"`,
+				"$ stackit dns record-set sync --zone-id xxx --file records.yaml --owner-tag infra"),
+			examples.NewExample(
+				`Show the changes needed to sync the DNS zone with ID "xxx" with the file "records.yaml", without applying them`,
+				"$ stackit dns record-set sync --zone-id xxx --file records.yaml --owner-tag infra --dry-run"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			content, err := os.ReadFile(model.FilePath)
+			if err != nil {
+				return fmt.Errorf("read record sets file %q: %w", model.FilePath, err)
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			zoneResp, err := apiClient.GetZoneExecute(ctx, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return fmt.Errorf("get DNS zone: %w", err)
+			}
+			zoneLabel := utils.PtrString(zoneResp.Zone.Name)
+			zoneDnsName := utils.PtrString(zoneResp.Zone.DnsName)
+
+			desired, err := parseRecordSetsFile(content, zoneDnsName, utils.PtrValue(zoneResp.Zone.DefaultTTL))
+			if err != nil {
+				return fmt.Errorf("parse record sets file %q: %w", model.FilePath, err)
+			}
+			existing, err := dnsUtils.ListAllRecordSets(ctx, apiClient, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return err
+			}
+
+			changes, err := dnsUtils.PlanRecordSetChanges(zoneDnsName, desired, existing, dnsUtils.RecordSetPlanOptions{
+				Prune:    true,
+				OwnerTag: utils.PtrString(model.OwnerTag),
+			})
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				params.Printer.Info("Record sets of zone %s are already in sync\n", zoneLabel)
+				return nil
+			}
+			if model.DryRun {
+				return outputResult(params.Printer, model, zoneLabel, changes)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to %s in zone %s?", dnsUtils.DescribeRecordSetChanges(changes), zoneLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API, waiting for the changes if async mode not enabled
+			s := spinner.New(params.Printer)
+			s.Start("Syncing record sets")
+			err = dnsUtils.ApplyRecordSetChanges(ctx, apiClient, model.ProjectId, model.ZoneId, changes, int(model.Parallelism), !model.Async)
+			if err != nil {
+				s.StopWithError()
+				return fmt.Errorf("sync DNS record sets: %w", err)
+			}
+			s.Stop()
+
+			return outputResult(params.Printer, model, zoneLabel, changes)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), zoneIdFlag, "Zone ID")
+	cmd.Flags().StringP(fileFlag, "f", "", "Path of the YAML or JSON file with the desired record sets")
+	cmd.Flags().String(ownerTagFlag, "", "Owner tag of the record sets managed by the sync. If unset, all record sets of the zone are managed")
+	cmd.Flags().Bool(dryRunFlag, false, "Only show the changes, without applying them")
+	cmd.Flags().Int64(parallelismFlag, defaultParallelism, "Maximum number of changes applied at the same time")
+
+	err := flags.MarkFlagsRequired(cmd, zoneIdFlag, fileFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	parallelism := flags.FlagWithDefaultToInt64Value(p, cmd, parallelismFlag)
+	if parallelism < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    parallelismFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ZoneId:          flags.FlagToStringValue(p, cmd, zoneIdFlag),
+		FilePath:        flags.FlagToStringValue(p, cmd, fileFlag),
+		OwnerTag:        flags.FlagToStringPointer(p, cmd, ownerTagFlag),
+		DryRun:          flags.FlagToBoolValue(p, cmd, dryRunFlag),
+		Parallelism:     parallelism,
+	}
+
+	if model.FilePath == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    fileFlag,
+			Details: "must not be empty",
+		}
+	}
+	if model.OwnerTag != nil && (*model.OwnerTag == "" || strings.ContainsAny(*model.OwnerTag, " \t\n")) {
+		return nil, &errors.FlagValidationError{
+			Flag:    ownerTagFlag,
+			Details: "must not be empty or contain white space",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// parseRecordSetsFile reads the desired record sets, qualifying their names and the domain names in their records with the zone DNS name
+func parseRecordSetsFile(content []byte, zoneDnsName string, defaultTTL int64) ([]zonefile.RecordSet, error) {
+	var file recordSetsFile
+	err := yaml.UnmarshalWithOptions(content, &file, yaml.Strict())
+	if err != nil {
+		return nil, err
+	}
+
+	origin := zonefile.Qualify(zoneDnsName, ".")
+	recordSets := []zonefile.RecordSet{}
+	seen := map[string]bool{}
+	for i, entry := range file.RecordSets {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("record set %d: name is missing", i+1)
+		}
+		recordType := strings.ToUpper(entry.Type)
+		if !zonefile.IsSupportedType(recordType) {
+			return nil, fmt.Errorf("record set %q: record type %q is not supported", entry.Name, entry.Type)
+		}
+		if len(entry.Records) == 0 {
+			return nil, fmt.Errorf("record set %q: records are missing", entry.Name)
+		}
+		name := zonefile.Qualify(entry.Name, origin)
+		key := name + " " + recordType
+		if seen[key] {
+			return nil, fmt.Errorf("record set %s %s is defined more than once", name, recordType)
+		}
+		seen[key] = true
+
+		records := []string{}
+		for _, record := range entry.Records {
+			if recordType == txtType {
+				// Based on RFC 1035 section 2.3.4, TXT Records are limited to 255 Characters
+				record, err = dnsUtils.FormatTxtRecord(zonefile.NormalizeRecord(recordType, record))
+				if err != nil {
+					return nil, fmt.Errorf("record set %q: %w", entry.Name, err)
+				}
+			} else {
+				record = zonefile.QualifyRecord(recordType, record, origin)
+			}
+			records = append(records, record)
+		}
+
+		ttl := defaultTTL
+		if entry.TTL != nil {
+			ttl = *entry.TTL
+		}
+		recordSets = append(recordSets, zonefile.RecordSet{
+			Name:    name,
+			Type:    recordType,
+			TTL:     ttl,
+			Records: records,
+		})
+	}
+	return recordSets, nil
+}
+
+func outputResult(p *print.Printer, model *inputModel, zoneLabel string, changes []dnsUtils.RecordSetChange) error {
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal DNS record set changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(changes, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal DNS record set changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ACTION", "NAME", "TYPE", "TTL", "RECORDS")
+		for i := range changes {
+			change := changes[i]
+			table.AddRow(
+				change.Action,
+				change.Name,
+				change.Type,
+				change.TTL,
+				strings.Join(change.Records, ", "),
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		switch {
+		case model.DryRun:
+			p.Outputf("Dry run, no changes were applied to zone %s\n", zoneLabel)
+		case model.Async:
+			p.Outputf("Triggered %d record set changes in zone %s\n", len(changes), zoneLabel)
+		default:
+			p.Outputf("Applied %d record set changes in zone %s\n", len(changes), zoneLabel)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,346 @@
+package sync
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testZoneId = uuid.NewString()
+
+const testFilePath = "records.yaml"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag: testProjectId,
+		zoneIdFlag:    testZoneId,
+		fileFlag:      testFilePath,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ZoneId:      testZoneId,
+		FilePath:    testFilePath,
+		Parallelism: defaultParallelism,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[ownerTagFlag] = "infra"
+				flagValues[dryRunFlag] = "true"
+				flagValues[parallelismFlag] = "10"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.OwnerTag = utils.Ptr("infra")
+				model.DryRun = true
+				model.Parallelism = 10
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "zone id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[zoneIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "file missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, fileFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "owner tag with white space",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[ownerTagFlag] = "my infra"
+			}),
+			isValid: false,
+		},
+		{
+			description: "owner tag empty",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[ownerTagFlag] = ""
+			}),
+			isValid: false,
+		},
+		{
+			description: "parallelism invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[parallelismFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRecordSetsFile(t *testing.T) {
+	tests := []struct {
+		description string
+		content     string
+		isValid     bool
+		expected    []zonefile.RecordSet
+	}{
+		{
+			description: "yaml",
+			content: `recordSets:
+  - name: www
+    type: a
+    ttl: 300
+    records:
+      - 1.2.3.4
+      - 5.6.7.8
+  - name: example.com.
+    type: TXT
+    records:
+      - '"v=spf1 -all"'
+`,
+			isValid: true,
+			expected: []zonefile.RecordSet{
+				{Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4", "5.6.7.8"}},
+				{Name: "example.com.", Type: "TXT", TTL: 3600, Records: []string{"v=spf1 -all"}},
+			},
+		},
+		{
+			description: "json",
+			content:     `{"recordSets": [{"name": "@", "type": "MX", "records": ["10 mail.example.com."]}]}`,
+			isValid:     true,
+			expected: []zonefile.RecordSet{
+				{Name: "example.com.", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com."}},
+			},
+		},
+		{
+			description: "relative domain names in records",
+			content: `recordSets:
+  - name: www
+    type: CNAME
+    records: [web]
+  - name: "@"
+    type: MX
+    records: ["10 mail", "20 mail.other.com."]
+  - name: _sip._tcp
+    type: SRV
+    records: ["10 60 5060 sip"]
+`,
+			isValid: true,
+			expected: []zonefile.RecordSet{
+				{Name: "www.example.com.", Type: "CNAME", TTL: 3600, Records: []string{"web.example.com."}},
+				{Name: "example.com.", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com.", "20 mail.other.com."}},
+				{Name: "_sip._tcp.example.com.", Type: "SRV", TTL: 3600, Records: []string{"10 60 5060 sip.example.com."}},
+			},
+		},
+		{
+			description: "long txt record",
+			content:     "recordSets:\n  - name: txt\n    type: TXT\n    records:\n      - " + strings.Repeat("a", 256) + "\n",
+			isValid:     true,
+			expected: []zonefile.RecordSet{
+				{Name: "txt.example.com.", Type: "TXT", TTL: 3600, Records: []string{`"` + strings.Repeat("a", 255) + `" "a"`}},
+			},
+		},
+		{
+			description: "empty",
+			content:     "recordSets: []\n",
+			isValid:     true,
+			expected:    []zonefile.RecordSet{},
+		},
+		{
+			description: "unknown field",
+			content:     "recordSets:\n  - name: www\n    type: A\n    record: [1.2.3.4]\n",
+			isValid:     false,
+		},
+		{
+			description: "name missing",
+			content:     "recordSets:\n  - type: A\n    records: [1.2.3.4]\n",
+			isValid:     false,
+		},
+		{
+			description: "unsupported type",
+			content:     "recordSets:\n  - name: www\n    type: HINFO\n    records: [foo]\n",
+			isValid:     false,
+		},
+		{
+			description: "records missing",
+			content:     "recordSets:\n  - name: www\n    type: A\n",
+			isValid:     false,
+		},
+		{
+			description: "duplicate record set",
+			content:     "recordSets:\n  - name: www\n    type: A\n    records: [1.2.3.4]\n  - name: www.example.com.\n    type: A\n    records: [5.6.7.8]\n",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			recordSets, err := parseRecordSetsFile([]byte(tt.content), "example.com", 3600)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing file: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(recordSets, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testChanges := []dnsUtils.RecordSetChange{
+		{
+			Action:  dnsUtils.RecordSetActionCreate,
+			Name:    "www.example.com.",
+			Type:    "A",
+			TTL:     300,
+			Records: []string{"1.2.3.4"},
+			Comment: utils.Ptr("managed-by=infra"),
+		},
+	}
+
+	type args struct {
+		model   *inputModel
+		changes []dnsUtils.RecordSetChange
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				model: fixtureInputModel(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "changes",
+			args: args{
+				model:   fixtureInputModel(),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.OutputFormat = print.JSONOutputFormat
+				}),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, "zone", tt.args.changes); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
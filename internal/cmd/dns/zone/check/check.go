@@ -0,0 +1,333 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsCheck "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/check"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+)
+
+const (
+	zoneIdArg = "ZONE_ID"
+
+	nameserverFlag       = "nameserver"
+	parentNameserverFlag = "parent-nameserver"
+	skipDelegationFlag   = "skip-delegation"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ZoneId            string
+	Nameservers       []string
+	ParentNameservers []string
+	SkipDelegation    bool
+}
+
+type checkResult struct {
+	ZoneId     string                     `json:"zoneId"`
+	DnsName    string                     `json:"dnsName"`
+	Delegation []dnsCheck.DelegationCheck `json:"delegation"`
+	Serials    []dnsCheck.SerialCheck     `json:"serials"`
+	RecordSets []dnsCheck.RecordCheck     `json:"recordSets"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("check %s", zoneIdArg),
+		Short: "Checks that a DNS zone is served by its nameservers",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Checks that a DNS zone is served by its nameservers, by querying them directly over DNS.",
+			"The SOA serial and the records served by each authoritative nameserver are compared to the zone and record sets stored in STACKIT, and the NS delegation in the parent zone is compared to the NS records of the zone.",
+			"The command fails if any check doesn't pass, so it can be used to wait for changes to be live.",
+		),
+		Args: args.SingleArg(zoneIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Check the DNS zone with ID "xxx"`,
+				"$ stackit dns zone check xxx"),
+			examples.NewExample(
+				`Check the DNS zone with ID "xxx" on a specific nameserver, without checking the delegation`,
+				"$ stackit dns zone check xxx --nameserver ns1.stackit.cloud --skip-delegation"),
+			examples.NewExample(
+				`Check the DNS zone with ID "xxx" and show the results in JSON format`,
+				"$ stackit dns zone check xxx --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			zoneResp, err := apiClient.GetZoneExecute(ctx, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return fmt.Errorf("get DNS zone: %w", err)
+			}
+			if zoneResp.Zone == nil {
+				return fmt.Errorf("zone response is empty")
+			}
+			recordSets, err := dnsUtils.ListAllRecordSets(ctx, apiClient, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return err
+			}
+
+			s := spinner.New(params.Printer)
+			s.Start("Checking nameservers")
+			result, err := runChecks(ctx, model, zoneResp.Zone, recordSets)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			err = outputResult(params.Printer, model.OutputFormat, result)
+			if err != nil {
+				return err
+			}
+
+			failed, total := countFailedChecks(result)
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks did not pass", failed, total)
+			}
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(nameserverFlag, nil, "Nameservers to query, as host name or IP address with optional port. If unset, the nameservers in the NS records of the zone are queried")
+	cmd.Flags().StringSlice(parentNameserverFlag, nil, "Parent zone nameservers to query for the delegation, as host name or IP address with optional port. If unset, they are looked up with the system resolver")
+	cmd.Flags().Bool(skipDelegationFlag, false, "Skip the check of the NS delegation in the parent zone")
+
+	cmd.MarkFlagsMutuallyExclusive(parentNameserverFlag, skipDelegationFlag)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	zoneId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel:   globalFlags,
+		ZoneId:            zoneId,
+		Nameservers:       flags.FlagToStringSliceValue(p, cmd, nameserverFlag),
+		ParentNameservers: flags.FlagToStringSliceValue(p, cmd, parentNameserverFlag),
+		SkipDelegation:    flags.FlagToBoolValue(p, cmd, skipDelegationFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func runChecks(ctx context.Context, model *inputModel, zone *dns.Zone, recordSets []dns.RecordSet) (*checkResult, error) {
+	zoneDnsName := utils.PtrString(zone.DnsName)
+	result := &checkResult{
+		ZoneId:     model.ZoneId,
+		DnsName:    zoneDnsName,
+		Delegation: []dnsCheck.DelegationCheck{},
+	}
+
+	zoneNameservers := apexNameservers(zoneDnsName, recordSets)
+	nameservers := model.Nameservers
+	if len(nameservers) == 0 {
+		nameservers = zoneNameservers
+	}
+	if len(nameservers) == 0 {
+		return nil, fmt.Errorf("zone has no NS records, specify the nameservers to query with --%s", nameserverFlag)
+	}
+	addresses, err := resolveNameservers(ctx, nameservers)
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.SkipDelegation {
+		parentNameservers := model.ParentNameservers
+		if len(parentNameservers) == 0 {
+			parentNameservers, err = dnsCheck.ParentNameservers(ctx, zoneDnsName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		parentAddresses, err := resolveNameservers(ctx, parentNameservers)
+		if err != nil {
+			return nil, err
+		}
+		result.Delegation = dnsCheck.CheckDelegation(ctx, parentAddresses, zoneDnsName, zoneNameservers)
+	}
+
+	result.Serials = dnsCheck.CheckSerials(ctx, addresses, zoneDnsName, utils.PtrValue(zone.SerialNumber))
+
+	// Records are only checked on nameservers which answered the SOA query, to avoid waiting for a timeout on each record set
+	reachable := []string{}
+	for _, serial := range result.Serials {
+		if serial.Status != dnsCheck.StatusError {
+			reachable = append(reachable, serial.Nameserver)
+		}
+	}
+	result.RecordSets = dnsCheck.CheckRecordSets(ctx, reachable, zoneDnsName, recordSets)
+
+	return result, nil
+}
+
+// apexNameservers returns the nameservers in the NS record set at the apex of the zone
+func apexNameservers(zoneDnsName string, recordSets []dns.RecordSet) []string {
+	origin := zonefile.Qualify(zoneDnsName, ".")
+	nameservers := []string{}
+	for i := range recordSets {
+		rs := recordSets[i]
+		if utils.PtrString(rs.Type) != "NS" || zonefile.Qualify(utils.PtrString(rs.Name), origin) != origin {
+			continue
+		}
+		for _, record := range utils.PtrValue(rs.Records) {
+			nameservers = append(nameservers, zonefile.Qualify(utils.PtrString(record.Content), origin))
+		}
+	}
+	return nameservers
+}
+
+func resolveNameservers(ctx context.Context, nameservers []string) ([]string, error) {
+	addresses := []string{}
+	for _, ns := range nameservers {
+		address, err := dnsCheck.ResolveNameserver(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+func countFailedChecks(result *checkResult) (failed, total int) {
+	for _, c := range result.Delegation {
+		total++
+		if c.Status != dnsCheck.StatusOK {
+			failed++
+		}
+	}
+	for _, c := range result.Serials {
+		total++
+		if c.Status != dnsCheck.StatusOK {
+			failed++
+		}
+	}
+	for _, c := range result.RecordSets {
+		if c.Status == dnsCheck.StatusSkipped {
+			continue
+		}
+		total++
+		if c.Status != dnsCheck.StatusOK {
+			failed++
+		}
+	}
+	return failed, total
+}
+
+func outputResult(p *print.Printer, outputFormat string, result *checkResult) error {
+	if result == nil {
+		return fmt.Errorf("check result is empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal DNS zone check: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal DNS zone check: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		var content []tables.Table
+
+		if len(result.Delegation) > 0 {
+			table := tables.NewTable()
+			table.SetTitle("DELEGATION")
+			table.SetHeader("PARENT NAMESERVER", "NAMESERVERS", "STATUS", "DETAILS")
+			for _, c := range result.Delegation {
+				table.AddRow(c.ParentNameserver, strings.Join(c.Actual, "\n"), c.Status, c.Details)
+				table.AddSeparator()
+			}
+			content = append(content, table)
+		}
+
+		table := tables.NewTable()
+		table.SetTitle("SOA SERIALS")
+		table.SetHeader("NAMESERVER", "SERIAL", "STATUS", "DETAILS")
+		for _, c := range result.Serials {
+			table.AddRow(c.Nameserver, c.Actual, c.Status, c.Details)
+			table.AddSeparator()
+		}
+		content = append(content, table)
+
+		// Only the records which don't match are listed, as zones can have many records
+		table = tables.NewTable()
+		table.SetTitle("RECORDS")
+		table.SetHeader("NAMESERVER", "NAME", "TYPE", "STATUS", "DETAILS")
+		passed := 0
+		for _, c := range result.RecordSets {
+			if c.Status == dnsCheck.StatusOK {
+				passed++
+				continue
+			}
+			table.AddRow(c.Nameserver, c.Name, c.Type, c.Status, c.Details)
+			table.AddSeparator()
+		}
+		if passed < len(result.RecordSets) {
+			content = append(content, table)
+		}
+
+		err := tables.DisplayTables(p, content)
+		if err != nil {
+			return fmt.Errorf("display output: %w", err)
+		}
+		p.Outputf("%d of %d record checks passed\n", passed, len(result.RecordSets))
+
+		return nil
+	}
+}
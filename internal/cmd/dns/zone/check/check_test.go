@@ -0,0 +1,292 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsCheck "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/check"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testZoneId = uuid.NewString()
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testZoneId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag:        testProjectId,
+		nameserverFlag:       "ns1.example.net,127.0.0.1:5353",
+		parentNameserverFlag: "a.gtld-servers.net",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ZoneId:            testZoneId,
+		Nameservers:       []string{"ns1.example.net", "127.0.0.1:5353"},
+		ParentNameservers: []string{"a.gtld-servers.net"},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "required only",
+			argValues:   fixtureArgValues(),
+			flagValues: map[string]string{
+				projectIdFlag: testProjectId,
+			},
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Nameservers = nil
+				model.ParentNameservers = nil
+			}),
+		},
+		{
+			description: "skip delegation",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, parentNameserverFlag)
+				flagValues[skipDelegationFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ParentNameservers = nil
+				model.SkipDelegation = true
+			}),
+		},
+		{
+			description: "skip delegation with parent nameservers",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[skipDelegationFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "zone id invalid",
+			argValues:   []string{"invalid-uuid"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApexNameservers(t *testing.T) {
+	recordSets := []dns.RecordSet{
+		{
+			Name:    utils.Ptr("example.com."),
+			Type:    utils.Ptr("NS"),
+			Records: &[]dns.Record{{Content: utils.Ptr("ns1.stackit.cloud.")}, {Content: utils.Ptr("ns2.stackit.cloud.")}},
+		},
+		{
+			Name:    utils.Ptr("sub.example.com."),
+			Type:    utils.Ptr("NS"),
+			Records: &[]dns.Record{{Content: utils.Ptr("ns.example.net.")}},
+		},
+		{
+			Name:    utils.Ptr("example.com."),
+			Type:    utils.Ptr("A"),
+			Records: &[]dns.Record{{Content: utils.Ptr("1.2.3.4")}},
+		},
+	}
+
+	nameservers := apexNameservers("example.com", recordSets)
+	diff := cmp.Diff(nameservers, []string{"ns1.stackit.cloud.", "ns2.stackit.cloud."})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestCountFailedChecks(t *testing.T) {
+	result := &checkResult{
+		Delegation: []dnsCheck.DelegationCheck{{Status: dnsCheck.StatusOK}},
+		Serials:    []dnsCheck.SerialCheck{{Status: dnsCheck.StatusOK}, {Status: dnsCheck.StatusMismatch}},
+		RecordSets: []dnsCheck.RecordCheck{
+			{Status: dnsCheck.StatusOK},
+			{Status: dnsCheck.StatusMissing},
+			{Status: dnsCheck.StatusSkipped},
+		},
+	}
+
+	failed, total := countFailedChecks(result)
+	if failed != 2 || total != 5 {
+		t.Fatalf("expected 2 of 5 failed checks, got %d of %d", failed, total)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testResult := &checkResult{
+		ZoneId:     testZoneId,
+		DnsName:    "example.com",
+		Delegation: []dnsCheck.DelegationCheck{{ParentNameserver: "192.0.2.1:53", Status: dnsCheck.StatusOK, Actual: []string{"ns1.stackit.cloud."}}},
+		Serials:    []dnsCheck.SerialCheck{{Nameserver: "192.0.2.2:53", Status: dnsCheck.StatusOK, Expected: 1, Actual: 1}},
+		RecordSets: []dnsCheck.RecordCheck{
+			{Nameserver: "192.0.2.2:53", Name: "www.example.com.", Type: "A", Status: dnsCheck.StatusOK},
+			{Nameserver: "192.0.2.2:53", Name: "new.example.com.", Type: "A", Status: dnsCheck.StatusMissing, Details: "no records found"},
+		},
+	}
+
+	type args struct {
+		outputFormat string
+		result       *checkResult
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "empty result",
+			args: args{
+				result: &checkResult{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "result",
+			args: args{
+				result: testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				result:       testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				result:       testResult,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+)
+
+const (
+	zoneIdArg = "ZONE_ID"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ZoneId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("export %s", zoneIdArg),
+		Short: "Exports the record sets of a DNS zone in zone file format",
+		Long: fmt.Sprintf("%s\n%s",
+			"Exports the record sets of a DNS zone in the zone file format of RFC 1035, as used by BIND.",
+			"Names within the zone are written relative to its origin. The zone file can be imported again with \"stackit dns zone import\".",
+		),
+		Args: args.SingleArg(zoneIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Export the DNS zone with ID "xxx" to the file "example.com.zone"`,
+				"$ stackit dns zone export xxx > example.com.zone"),
+			examples.NewExample(
+				`Export the record sets of the DNS zone with ID "xxx" in JSON format`,
+				"$ stackit dns zone export xxx --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			zoneResp, err := apiClient.GetZoneExecute(ctx, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return fmt.Errorf("get DNS zone: %w", err)
+			}
+			recordSets, err := dnsUtils.ListAllRecordSets(ctx, apiClient, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return err
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, zoneResp.Zone, recordSets)
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	zoneId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ZoneId:          zoneId,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func outputResult(p *print.Printer, outputFormat string, zone *dns.Zone, recordSets []dns.RecordSet) error {
+	if zone == nil {
+		return fmt.Errorf("zone response is empty")
+	}
+	zoneDnsName := utils.PtrString(zone.DnsName)
+	zoneFileRecordSets := dnsUtils.ToZoneFileRecordSets(zoneDnsName, recordSets)
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(zoneFileRecordSets, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal DNS record sets: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(zoneFileRecordSets, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal DNS record sets: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		p.Outputf("%s", zonefile.Format(zoneDnsName, utils.PtrValue(zone.DefaultTTL), zoneFileRecordSets))
+
+		return nil
+	}
+}
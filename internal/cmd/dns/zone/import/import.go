@@ -0,0 +1,231 @@
+package importZone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+)
+
+const (
+	zoneIdFlag = "zone-id"
+	fileFlag   = "file"
+	dryRunFlag = "dry-run"
+	pruneFlag  = "prune"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ZoneId   string
+	FilePath string
+	DryRun   bool
+	Prune    bool
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports record sets into a DNS zone from a zone file",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Imports record sets into a DNS zone from a zone file in the format of RFC 1035, as used by BIND.",
+			"Record sets of the file which don't exist in the zone are created, record sets with different records or TTL are updated.",
+			fmt.Sprintf("Record sets of the zone which are not in the file are only deleted if the --%s flag is set.", pruneFlag),
+			"The SOA and NS record sets at the zone apex are managed by STACKIT DNS and are never changed.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Import the record sets of the zone file "example.com.zone" into the DNS zone with ID "xxx"`,
+				"$ stackit dns zone import --zone-id xxx --file example.com.zone"),
+			examples.NewExample(
+				`Show the changes needed to import the zone file "example.com.zone" into the DNS zone with ID "xxx", without applying them`,
+				"$ stackit dns zone import --zone-id xxx --file example.com.zone --dry-run"),
+			examples.NewExample(
+				`Import the zone file "example.com.zone" into the DNS zone with ID "xxx" and delete the record sets which are not in the file`,
+				"$ stackit dns zone import --zone-id xxx --file example.com.zone --prune"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			content, err := os.ReadFile(model.FilePath)
+			if err != nil {
+				return fmt.Errorf("read zone file %q: %w", model.FilePath, err)
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			zoneResp, err := apiClient.GetZoneExecute(ctx, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return fmt.Errorf("get DNS zone: %w", err)
+			}
+			zoneLabel := utils.PtrString(zoneResp.Zone.Name)
+			zoneDnsName := utils.PtrString(zoneResp.Zone.DnsName)
+
+			desired, err := zonefile.Parse(string(content), zoneDnsName, utils.PtrValue(zoneResp.Zone.DefaultTTL))
+			if err != nil {
+				return fmt.Errorf("parse zone file %q: %w", model.FilePath, err)
+			}
+			existing, err := dnsUtils.ListAllRecordSets(ctx, apiClient, model.ProjectId, model.ZoneId)
+			if err != nil {
+				return err
+			}
+
+			changes, err := dnsUtils.PlanRecordSetChanges(zoneDnsName, desired, existing, dnsUtils.RecordSetPlanOptions{Prune: model.Prune})
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				params.Printer.Info("Zone %s is already up to date\n", zoneLabel)
+				return nil
+			}
+			if model.DryRun {
+				return outputResult(params.Printer, model, zoneLabel, changes)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to %s in zone %s?", dnsUtils.DescribeRecordSetChanges(changes), zoneLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API, waiting for each change if async mode not enabled
+			s := spinner.New(params.Printer)
+			s.Start("Importing record sets")
+			err = applyChanges(ctx, model, apiClient, changes)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			return outputResult(params.Printer, model, zoneLabel, changes)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), zoneIdFlag, "Zone ID")
+	cmd.Flags().StringP(fileFlag, "f", "", "Path of the zone file")
+	cmd.Flags().Bool(dryRunFlag, false, "Only show the changes, without applying them")
+	cmd.Flags().Bool(pruneFlag, false, "Delete the record sets of the zone which are not in the zone file")
+
+	err := flags.MarkFlagsRequired(cmd, zoneIdFlag, fileFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ZoneId:          flags.FlagToStringValue(p, cmd, zoneIdFlag),
+		FilePath:        flags.FlagToStringValue(p, cmd, fileFlag),
+		DryRun:          flags.FlagToBoolValue(p, cmd, dryRunFlag),
+		Prune:           flags.FlagToBoolValue(p, cmd, pruneFlag),
+	}
+
+	if model.FilePath == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    fileFlag,
+			Details: "must not be empty",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// applyChanges applies the changes one at a time, deleting record sets before updating and creating
+// others, so that a record set can be replaced by one of another type with the same name
+func applyChanges(ctx context.Context, model *inputModel, apiClient *dns.APIClient, changes []dnsUtils.RecordSetChange) error {
+	return dnsUtils.ApplyRecordSetChanges(ctx, apiClient, model.ProjectId, model.ZoneId, changes, 1, !model.Async)
+}
+
+func outputResult(p *print.Printer, model *inputModel, zoneLabel string, changes []dnsUtils.RecordSetChange) error {
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal DNS record set changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(changes, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal DNS record set changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ACTION", "NAME", "TYPE", "TTL", "RECORDS")
+		for i := range changes {
+			change := changes[i]
+			table.AddRow(
+				change.Action,
+				change.Name,
+				change.Type,
+				change.TTL,
+				strings.Join(change.Records, ", "),
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		switch {
+		case model.DryRun:
+			p.Outputf("Dry run, no changes were applied to zone %s\n", zoneLabel)
+		case model.Async:
+			p.Outputf("Triggered import of %d record set changes into zone %s\n", len(changes), zoneLabel)
+		default:
+			p.Outputf("Imported %d record set changes into zone %s\n", len(changes), zoneLabel)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,303 @@
+package importZone
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testZoneId = uuid.NewString()
+
+const testFilePath = "example.com.zone"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag: testProjectId,
+		zoneIdFlag:    testZoneId,
+		fileFlag:      testFilePath,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ZoneId:   testZoneId,
+		FilePath: testFilePath,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "dry run and prune",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[dryRunFlag] = "true"
+				flagValues[pruneFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.DryRun = true
+				model.Prune = true
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "zone id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[zoneIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "file missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, fileFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "file empty",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fileFlag] = ""
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	// the zone file replaces the A record set "www" by a CNAME record set
+	desired, err := zonefile.Parse("$ORIGIN example.com.\nwww 300 IN CNAME web.example.com.\n", "example.com", 3600)
+	if err != nil {
+		t.Fatalf("failed to parse zone file: %v", err)
+	}
+	existing := []dns.RecordSet{
+		{
+			Id:      utils.Ptr("a-record-set-id"),
+			Name:    utils.Ptr("www.example.com."),
+			Type:    utils.Ptr("A"),
+			Ttl:     utils.Ptr(int64(300)),
+			Records: &[]dns.Record{{Content: utils.Ptr("1.2.3.4")}},
+		},
+	}
+	changes, err := dnsUtils.PlanRecordSetChanges("example.com", desired, existing, dnsUtils.RecordSetPlanOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("failed to plan changes: %v", err)
+	}
+
+	var mu sync.Mutex
+	deleted := false
+	calls := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodDelete:
+			deleted = true
+		case http.MethodPost:
+			// a CNAME record set can't be created while another record set with the same name exists
+			if !deleted {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		respBytes, err := json.Marshal(dns.RecordSetResponse{
+			Rrset: &dns.RecordSet{Id: utils.Ptr("cname-record-set-id")},
+		})
+		if err != nil {
+			t.Errorf("Failed to marshal mocked response: %v", err)
+		}
+		_, err = w.Write(respBytes)
+		if err != nil {
+			t.Errorf("Failed to write response: %v", err)
+		}
+	})
+	mockedServer := httptest.NewServer(handler)
+	defer mockedServer.Close()
+	client, err := dns.NewAPIClient(
+		sdkConfig.WithEndpoint(mockedServer.URL),
+		sdkConfig.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to initialize client: %v", err)
+	}
+
+	model := fixtureInputModel(func(model *inputModel) {
+		model.Async = true
+		model.Prune = true
+	})
+	err = applyChanges(context.Background(), model, client, changes)
+	if err != nil {
+		t.Fatalf("failed on valid input: %v", err)
+	}
+	diff := cmp.Diff(calls, []string{http.MethodDelete, http.MethodPost})
+	if diff != "" {
+		t.Fatalf("API calls do not match: %s", diff)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testChanges := []dnsUtils.RecordSetChange{
+		{
+			Action:  dnsUtils.RecordSetActionCreate,
+			Name:    "www.example.com.",
+			Type:    "A",
+			TTL:     300,
+			Records: []string{"1.2.3.4", "5.6.7.8"},
+		},
+	}
+
+	type args struct {
+		model   *inputModel
+		changes []dnsUtils.RecordSetChange
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				model: fixtureInputModel(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "changes",
+			args: args{
+				model:   fixtureInputModel(),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dry run",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.DryRun = true
+				}),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.OutputFormat = print.YAMLOutputFormat
+				}),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, "zone", tt.args.changes); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
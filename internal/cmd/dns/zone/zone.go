@@ -1,10 +1,13 @@
 package zone
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/check"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/clone"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/export"
+	importZone "github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/import"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/list"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns/zone/update"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
@@ -33,4 +36,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(update.NewCmd(params))
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(clone.NewCmd(params))
+	cmd.AddCommand(export.NewCmd(params))
+	cmd.AddCommand(importZone.NewCmd(params))
+	cmd.AddCommand(check.NewCmd(params))
 }
@@ -3,12 +3,18 @@ package create
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-yaml"
@@ -29,6 +35,8 @@ const (
 	nameFlag                = "name"
 	diskFormatFlag          = "disk-format"
 	localFilePathFlag       = "local-file-path"
+	sourceUrlFlag           = "source-url"
+	checksumFlag            = "checksum"
 	noProgressIndicatorFlag = "no-progress"
 
 	bootMenuFlag               = "boot-menu"
@@ -50,6 +58,9 @@ const (
 	minDiskSizeFlag = "min-disk-size"
 	minRamFlag      = "min-ram"
 	protectedFlag   = "protected"
+
+	checksumAlgorithmSha256 = "sha256"
+	checksumAlgorithmSha512 = "sha512"
 )
 
 type imageConfig struct {
@@ -67,6 +78,12 @@ type imageConfig struct {
 	VideoModel             *string
 	VirtioScsi             *bool
 }
+
+type imageChecksum struct {
+	Algorithm string
+	Digest    string
+}
+
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
@@ -74,6 +91,8 @@ type inputModel struct {
 	Name                string
 	DiskFormat          string
 	LocalFilePath       string
+	SourceUrl           string
+	Checksum            *imageChecksum
 	Labels              *map[string]string
 	Config              *imageConfig
 	MinDiskSize         *int64
@@ -86,8 +105,12 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Creates images",
-		Long:  "Creates images.",
-		Args:  args.NoArgs,
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Creates images.",
+			"The image is either uploaded from a local file or streamed from a URL, without storing it on disk in between.",
+			"If a checksum is given, the uploaded data is verified against it and the image is deleted again on a mismatch.",
+		),
+		Args: args.NoArgs,
 		Example: examples.Build(
 			examples.NewExample(
 				`Create an image with name 'my-new-image' from a raw disk image located in '/my/raw/image'`,
@@ -97,6 +120,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Create an image with name 'my-new-image' from a qcow2 image read from '/my/qcow2/image' with labels describing its contents`,
 				`$ stackit image create --name my-new-image --disk-format=qcow2 --local-file-path=/my/qcow2/image --labels os=linux,distro=alpine,version=3.12`,
 			),
+			examples.NewExample(
+				`Create an image with name 'my-new-image' from a qcow2 image downloaded from 'https://example.com/images/jammy.qcow2' and verify its SHA-256 checksum`,
+				`$ stackit image create --name my-new-image --disk-format=qcow2 --source-url=https://example.com/images/jammy.qcow2 --checksum=sha256:xxx`,
+			),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) (err error) {
 			ctx := context.Background()
@@ -112,15 +139,18 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			}
 
 			// we open input file first to fail fast, if it is not readable
-			file, err := os.Open(model.LocalFilePath)
-			if err != nil {
-				return fmt.Errorf("create image: file %q is not readable: %w", model.LocalFilePath, err)
-			}
-			defer func() {
-				if inner := file.Close(); inner != nil {
-					err = fmt.Errorf("error closing input file: %w (%w)", inner, err)
+			var file *os.File
+			if model.LocalFilePath != "" {
+				file, err = os.Open(model.LocalFilePath)
+				if err != nil {
+					return fmt.Errorf("create image: file %q is not readable: %w", model.LocalFilePath, err)
 				}
-			}()
+				defer func() {
+					if inner := file.Close(); inner != nil {
+						err = fmt.Errorf("error closing input file: %w (%w)", inner, err)
+					}
+				}()
+			}
 
 			if !model.AssumeYes {
 				prompt := fmt.Sprintf("Are you sure you want to create the image %q?", model.Name)
@@ -130,6 +160,36 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				}
 			}
 
+			var source io.Reader
+			var size int64
+			if file != nil {
+				stat, statErr := file.Stat()
+				if statErr != nil {
+					return fmt.Errorf("create image: file %q is not readable: %w", model.LocalFilePath, statErr)
+				}
+				source, size = file, stat.Size()
+			} else {
+				// the download is started before the image is created, so that an
+				// unreachable source doesn't leave an empty image behind
+				var body io.ReadCloser
+				body, size, err = openSourceUrl(ctx, params.Printer, model.SourceUrl)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if inner := body.Close(); inner != nil {
+						err = fmt.Errorf("error closing download: %w (%w)", inner, err)
+					}
+				}()
+				source = body
+			}
+
+			var checksumHash hash.Hash
+			if model.Checksum != nil {
+				checksumHash = newChecksumHash(model.Checksum.Algorithm)
+				source = io.TeeReader(source, checksumHash)
+			}
+
 			// Call API
 			request := buildRequest(ctx, model, apiClient)
 
@@ -142,10 +202,20 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			if !ok {
 				return fmt.Errorf("create image: no upload URL has been provided")
 			}
-			if err := uploadAsync(ctx, params.Printer, model, file, url); err != nil {
+			if err := uploadAsync(ctx, params.Printer, model, source, size, url); err != nil {
 				return err
 			}
 
+			if checksumHash != nil {
+				if err := verifyChecksum(model.Checksum, checksumHash); err != nil {
+					params.Printer.Debug(print.DebugLevel, "deleting image %s after failed checksum verification", utils.PtrString(model.Id))
+					if inner := apiClient.DeleteImage(ctx, model.ProjectId, utils.PtrString(model.Id)).Execute(); inner != nil {
+						return fmt.Errorf("create image: %w, deleting the image failed: %w", err, inner)
+					}
+					return fmt.Errorf("create image: %w, the image has been deleted", err)
+				}
+			}
+
 			if err := outputResult(params.Printer, model, result); err != nil {
 				return err
 			}
@@ -158,18 +228,52 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 	return cmd
 }
 
-func uploadAsync(ctx context.Context, p *print.Printer, model *inputModel, file *os.File, url string) error {
-	stat, err := file.Stat()
+// openSourceUrl starts the download of the image from the given URL. The size of the image
+// must be known upfront, as the upload requires the content length to be set.
+func openSourceUrl(ctx context.Context, p *print.Printer, sourceUrl string) (body io.ReadCloser, size int64, err error) {
+	p.Debug(print.DebugLevel, "downloading image from %s", sourceUrl)
+
+	downloadRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceUrl, http.NoBody)
 	if err != nil {
-		return fmt.Errorf("upload file: %w", err)
+		return nil, 0, fmt.Errorf("create image: cannot create download request: %w", err)
 	}
+	downloadResponse, err := http.DefaultClient.Do(downloadRequest)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create image: error downloading image from %q: %w", sourceUrl, err)
+	}
+	if downloadResponse.StatusCode != http.StatusOK {
+		_ = downloadResponse.Body.Close()
+		return nil, 0, fmt.Errorf("create image: download of image from %q failed with %s", sourceUrl, downloadResponse.Status)
+	}
+	if downloadResponse.ContentLength < 0 {
+		_ = downloadResponse.Body.Close()
+		return nil, 0, fmt.Errorf("create image: the size of the image at %q is unknown, download it and use --%s instead", sourceUrl, localFilePathFlag)
+	}
+	return downloadResponse.Body, downloadResponse.ContentLength, nil
+}
+
+func newChecksumHash(algorithm string) hash.Hash {
+	if algorithm == checksumAlgorithmSha512 {
+		return sha512.New()
+	}
+	return sha256.New()
+}
 
+func verifyChecksum(expected *imageChecksum, actual hash.Hash) error {
+	digest := hex.EncodeToString(actual.Sum(nil))
+	if digest != expected.Digest {
+		return fmt.Errorf("%s checksum mismatch: expected %s, got %s", expected.Algorithm, expected.Digest, digest)
+	}
+	return nil
+}
+
+func uploadAsync(ctx context.Context, p *print.Printer, model *inputModel, source io.Reader, size int64, url string) error {
 	var reader io.Reader
 	if model.NoProgressIndicator != nil && *model.NoProgressIndicator {
-		reader = file
+		reader = source
 	} else {
 		var ch <-chan int
-		reader, ch = newProgressReader(file)
+		reader, ch = newProgressReader(source)
 		go func() {
 			ticker := time.NewTicker(2 * time.Second)
 			var uploaded int
@@ -177,7 +281,7 @@ func uploadAsync(ctx context.Context, p *print.Printer, model *inputModel, file
 			for {
 				select {
 				case <-ticker.C:
-					p.Info("uploaded %3.1f%%\r", 100.0/float64(stat.Size())*float64(uploaded))
+					p.Info("uploaded %3.1f%%\r", 100.0/float64(size)*float64(uploaded))
 				case n, ok := <-ch:
 					if !ok {
 						break done
@@ -190,7 +294,7 @@ func uploadAsync(ctx context.Context, p *print.Printer, model *inputModel, file
 		}()
 	}
 
-	if err = uploadFile(ctx, p, reader, stat.Size(), url); err != nil {
+	if err := uploadFile(ctx, p, reader, size, url); err != nil {
 		return fmt.Errorf("upload file: %w", err)
 	}
 
@@ -259,6 +363,8 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(nameFlag, "", "The name of the image.")
 	cmd.Flags().String(diskFormatFlag, "", "The disk format of the image. ")
 	cmd.Flags().String(localFilePathFlag, "", "The path to the local disk image file.")
+	cmd.Flags().String(sourceUrlFlag, "", "The HTTP(S) URL to download the disk image from. The download is streamed into the upload.")
+	cmd.Flags().String(checksumFlag, "", fmt.Sprintf("The checksum to verify the uploaded image data against, in the format '<algorithm>:<digest>'. Supported algorithms are %q and %q.", checksumAlgorithmSha256, checksumAlgorithmSha512))
 	cmd.Flags().Bool(noProgressIndicatorFlag, false, "Show no progress indicator for upload.")
 
 	cmd.Flags().Bool(bootMenuFlag, false, "Enables the BIOS bootmenu.")
@@ -281,9 +387,11 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64(minRamFlag, 0, "Size in Megabyte.")
 	cmd.Flags().Bool(protectedFlag, false, "Protected VM.")
 
-	if err := flags.MarkFlagsRequired(cmd, nameFlag, diskFormatFlag, localFilePathFlag); err != nil {
+	if err := flags.MarkFlagsRequired(cmd, nameFlag, diskFormatFlag); err != nil {
 		cobra.CheckErr(err)
 	}
+	cmd.MarkFlagsOneRequired(localFilePathFlag, sourceUrlFlag)
+	cmd.MarkFlagsMutuallyExclusive(localFilePathFlag, sourceUrlFlag)
 	cmd.MarkFlagsRequiredTogether(rescueBusFlag, rescueDeviceFlag)
 }
 
@@ -294,11 +402,36 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	}
 	name := flags.FlagToStringValue(p, cmd, nameFlag)
 
+	sourceUrl := flags.FlagToStringValue(p, cmd, sourceUrlFlag)
+	if sourceUrl != "" {
+		u, err := url.Parse(sourceUrl)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, &errors.FlagValidationError{
+				Flag:    sourceUrlFlag,
+				Details: "must be an HTTP or HTTPS URL",
+			}
+		}
+	}
+
+	var checksum *imageChecksum
+	if value := flags.FlagToStringValue(p, cmd, checksumFlag); value != "" {
+		var err error
+		checksum, err = parseChecksum(value)
+		if err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    checksumFlag,
+				Details: err.Error(),
+			}
+		}
+	}
+
 	model := inputModel{
 		GlobalFlagModel:     globalFlags,
 		Name:                name,
 		DiskFormat:          flags.FlagToStringValue(p, cmd, diskFormatFlag),
 		LocalFilePath:       flags.FlagToStringValue(p, cmd, localFilePathFlag),
+		SourceUrl:           sourceUrl,
+		Checksum:            checksum,
 		Labels:              flags.FlagToStringToStringPointer(p, cmd, labelsFlag),
 		NoProgressIndicator: flags.FlagToBoolPointer(p, cmd, noProgressIndicatorFlag),
 		Config: &imageConfig{
@@ -333,6 +466,34 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	return &model, nil
 }
 
+// parseChecksum parses a checksum in the format "<algorithm>:<digest>"
+func parseChecksum(value string) (*imageChecksum, error) {
+	algorithm, digest, found := strings.Cut(value, ":")
+	if !found {
+		return nil, fmt.Errorf("must be in the format '<algorithm>:<digest>'")
+	}
+	algorithm = strings.ToLower(algorithm)
+	digest = strings.ToLower(digest)
+
+	var digestLength int
+	switch algorithm {
+	case checksumAlgorithmSha256:
+		digestLength = sha256.Size
+	case checksumAlgorithmSha512:
+		digestLength = sha512.Size
+	default:
+		return nil, fmt.Errorf("algorithm %q is not supported, use %q or %q", algorithm, checksumAlgorithmSha256, checksumAlgorithmSha512)
+	}
+	decoded, err := hex.DecodeString(digest)
+	if err != nil || len(decoded) != digestLength {
+		return nil, fmt.Errorf("digest must be a hex encoded %s checksum", algorithm)
+	}
+	return &imageChecksum{
+		Algorithm: algorithm,
+		Digest:    digest,
+	}, nil
+}
+
 func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiCreateImageRequest {
 	request := apiClient.CreateImage(ctx, model.ProjectId).
 		CreateImagePayload(createPayload(ctx, model))
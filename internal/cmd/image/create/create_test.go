@@ -2,6 +2,12 @@ package create
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
@@ -45,6 +51,8 @@ var (
 	testVideoModel                   = "test-video-model"
 	testVirtioScsi                   = true
 	testLabels                       = "foo=FOO,bar=BAR,baz=BAZ"
+	testSourceUrl                    = "https://example.com/images/test.qcow2"
+	testSha256Digest                 = hex.EncodeToString(sha256.New().Sum(nil))
 )
 
 func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
@@ -211,6 +219,89 @@ func TestParseInput(t *testing.T) {
 			}),
 			isValid: false,
 		},
+		{
+			description: "source url",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, localFilePathFlag)
+				flagValues[sourceUrlFlag] = testSourceUrl
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.LocalFilePath = ""
+				model.SourceUrl = testSourceUrl
+			}),
+		},
+		{
+			description: "source url and local file path",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sourceUrlFlag] = testSourceUrl
+			}),
+			isValid: false,
+		},
+		{
+			description: "no source url and no local file path",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, localFilePathFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "source url invalid scheme",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, localFilePathFlag)
+				flagValues[sourceUrlFlag] = "ftp://example.com/images/test.qcow2"
+			}),
+			isValid: false,
+		},
+		{
+			description: "source url without host",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, localFilePathFlag)
+				flagValues[sourceUrlFlag] = "test.qcow2"
+			}),
+			isValid: false,
+		},
+		{
+			description: "checksum",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[checksumFlag] = "SHA256:" + strings.ToUpper(testSha256Digest)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Checksum = &imageChecksum{
+					Algorithm: checksumAlgorithmSha256,
+					Digest:    testSha256Digest,
+				}
+			}),
+		},
+		{
+			description: "checksum without algorithm",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[checksumFlag] = testSha256Digest
+			}),
+			isValid: false,
+		},
+		{
+			description: "checksum unsupported algorithm",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[checksumFlag] = "md5:d41d8cd98f00b204e9800998ecf8427e"
+			}),
+			isValid: false,
+		},
+		{
+			description: "checksum digest of wrong length",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[checksumFlag] = "sha512:" + testSha256Digest
+			}),
+			isValid: false,
+		},
+		{
+			description: "checksum digest not hex",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[checksumFlag] = "sha256:" + strings.Repeat("x", 64)
+			}),
+			isValid: false,
+		},
 		{
 			description: "no labels",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
@@ -432,3 +523,108 @@ func TestOutputResult(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenSourceUrl(t *testing.T) {
+	content := "test-image-content"
+	tests := []struct {
+		description  string
+		handler      http.HandlerFunc
+		isValid      bool
+		expectedSize int64
+	}{
+		{
+			description: "base",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, content)
+			},
+			isValid:      true,
+			expectedSize: int64(len(content)),
+		},
+		{
+			description: "not found",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			isValid: false,
+		},
+		{
+			description: "unknown size",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				// flushing before writing the body forces a chunked response without content length
+				w.(http.Flusher).Flush()
+				_, _ = fmt.Fprint(w, content)
+			},
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			p := print.NewPrinter()
+			p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+			body, size, err := openSourceUrl(testCtx, p, server.URL)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error opening source url: %v", err)
+			}
+			defer func() { _ = body.Close() }()
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if size != tt.expectedSize {
+				t.Fatalf("size does not match: expected %d, got %d", tt.expectedSize, size)
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("error reading body: %v", err)
+			}
+			if string(data) != content {
+				t.Fatalf("body does not match: expected %q, got %q", content, string(data))
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	content := "test-image-content"
+	sum := sha256.Sum256([]byte(content))
+	tests := []struct {
+		description string
+		expected    *imageChecksum
+		isValid     bool
+	}{
+		{
+			description: "sha256 matches",
+			expected:    &imageChecksum{Algorithm: checksumAlgorithmSha256, Digest: hex.EncodeToString(sum[:])},
+			isValid:     true,
+		},
+		{
+			description: "sha256 mismatch",
+			expected:    &imageChecksum{Algorithm: checksumAlgorithmSha256, Digest: testSha256Digest},
+			isValid:     false,
+		},
+		{
+			description: "sha512 mismatch",
+			expected:    &imageChecksum{Algorithm: checksumAlgorithmSha512, Digest: hex.EncodeToString(sum[:])},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			checksumHash := newChecksumHash(tt.expected.Algorithm)
+			if _, err := io.Copy(io.Discard, io.TeeReader(strings.NewReader(content), checksumHash)); err != nil {
+				t.Fatalf("error hashing content: %v", err)
+			}
+			err := verifyChecksum(tt.expected, checksumHash)
+			if (err == nil) != tt.isValid {
+				t.Fatalf("verifyChecksum() error = %v, isValid %v", err, tt.isValid)
+			}
+		})
+	}
+}
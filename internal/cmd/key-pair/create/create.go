@@ -6,11 +6,13 @@ import (
 	"fmt"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasSsh "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ssh"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/goccy/go-yaml"
@@ -22,21 +24,31 @@ const (
 	nameFlag      = "name"
 	publicKeyFlag = "public-key"
 	labelFlag     = "labels"
+
+	generateFlag      = "generate"
+	privateKeyOutFlag = "private-key-out"
+	encryptFlag       = "encrypt"
 )
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
-	Name      *string
-	PublicKey *string
-	Labels    *map[string]string
+	Name          *string
+	PublicKey     *string
+	Labels        *map[string]string
+	Generate      string
+	PrivateKeyOut string
+	Encrypt       bool
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Creates a key pair",
-		Long:  "Creates a key pair.",
-		Args:  cobra.NoArgs,
+		Long: fmt.Sprintf("%s\n%s",
+			"Creates a key pair from an existing public key.",
+			"With --generate, a new key pair is generated locally instead. The private key is written in the OpenSSH format to the path of --private-key-out, the public key next to it with the suffix \".pub\", and only the public key is uploaded.",
+		),
+		Args: cobra.NoArgs,
 		Example: examples.Build(
 			examples.NewExample(
 				`Create a new key pair with public-key "ssh-rsa xxx"`,
@@ -54,6 +66,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Create a new key pair with public-key "ssh-rsa xxx" and labels "key=value,key1=value1"`,
 				"$ stackit key-pair create --public-key `ssh-rsa xxx` --labels key=value,key1=value1",
 			),
+			examples.NewExample(
+				`Generate a new ed25519 key pair with name "KEY_PAIR_NAME" and write the private key to "~/.ssh/stackit_id"`,
+				"$ stackit key-pair create --name KEY_PAIR_NAME --generate ed25519 --private-key-out ~/.ssh/stackit_id",
+			),
+			examples.NewExample(
+				`Generate a new RSA key pair and write the private key encrypted with a passphrase to "~/.ssh/stackit_id"`,
+				"$ stackit key-pair create --generate rsa-4096 --private-key-out ~/.ssh/stackit_id --encrypt",
+			),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -68,6 +88,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
+			if model.Generate != "" {
+				// check the key files first, so that nothing is generated if they can't be written
+				err = iaasSsh.CheckKeyFiles(model.PrivateKeyOut)
+				if err != nil {
+					return err
+				}
+			}
+
 			if !model.AssumeYes {
 				prompt := "Are your sure you want to create a key pair?"
 				err = params.Printer.PromptForConfirmation(prompt)
@@ -76,6 +104,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				}
 			}
 
+			var key *iaasSsh.GeneratedKey
+			if model.Generate != "" {
+				key, err = generateKey(params.Printer, model)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Call API
 			req := buildRequest(ctx, model, apiClient)
 			resp, err := req.Execute()
@@ -83,6 +119,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("create key pair: %w", err)
 			}
 
+			// the key files are only written once the key pair exists, so that a failed request leaves no files behind
+			if key != nil {
+				err = writeKeyFiles(params.Printer, model, key)
+				if err != nil {
+					return err
+				}
+			}
+
 			return outputResult(params.Printer, model.GlobalFlagModel.OutputFormat, resp)
 		},
 	}
@@ -94,9 +138,13 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(nameFlag, "", "Key pair name")
 	cmd.Flags().Var(flags.ReadFromFileFlag(), publicKeyFlag, "Public key to be imported (format: ssh-rsa|ssh-ed25519)")
 	cmd.Flags().StringToString(labelFlag, nil, "Labels are key-value string pairs which can be attached to a key pair. E.g. '--labels key1=value1,key2=value2,...'")
+	cmd.Flags().Var(flags.EnumFlag(false, "", iaasSsh.KeyTypes...), generateFlag, fmt.Sprintf("Generate a new key pair locally instead of importing a public key, one of %q", iaasSsh.KeyTypes))
+	cmd.Flags().String(privateKeyOutFlag, "", "Path to write the private key of a generated key pair to")
+	cmd.Flags().Bool(encryptFlag, false, "Encrypt the private key of a generated key pair with a passphrase, which is prompted for")
 
-	err := cmd.MarkFlagRequired(publicKeyFlag)
-	cobra.CheckErr(err)
+	cmd.MarkFlagsOneRequired(publicKeyFlag, generateFlag)
+	cmd.MarkFlagsMutuallyExclusive(publicKeyFlag, generateFlag)
+	cmd.MarkFlagsRequiredTogether(generateFlag, privateKeyOutFlag)
 }
 
 func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
@@ -107,6 +155,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		Labels:          flags.FlagToStringToStringPointer(p, cmd, labelFlag),
 		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
 		PublicKey:       flags.FlagToStringPointer(p, cmd, publicKeyFlag),
+		Generate:        flags.FlagToStringValue(p, cmd, generateFlag),
+		PrivateKeyOut:   flags.FlagToStringValue(p, cmd, privateKeyOutFlag),
+		Encrypt:         flags.FlagToBoolValue(p, cmd, encryptFlag),
+	}
+
+	if model.Encrypt && model.Generate == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    encryptFlag,
+			Details: fmt.Sprintf("can only be used with --%s", generateFlag),
+		}
 	}
 
 	if p.IsVerbosityDebug() {
@@ -121,6 +179,46 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	return &model, nil
 }
 
+// generateKey generates the key pair and sets its public key in the model
+func generateKey(p *print.Printer, model *inputModel) (*iaasSsh.GeneratedKey, error) {
+	var passphrase []byte
+	if model.Encrypt {
+		value, err := p.PromptForPassword("Passphrase for the private key: ")
+		if err != nil {
+			return nil, err
+		}
+		confirmation, err := p.PromptForPassword("Repeat the passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if value != confirmation {
+			return nil, fmt.Errorf("the passphrases don't match")
+		}
+		if value == "" {
+			return nil, fmt.Errorf("the passphrase must not be empty")
+		}
+		passphrase = []byte(value)
+	}
+
+	key, err := iaasSsh.GenerateKey(model.Generate, utils.PtrString(model.Name), passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	model.PublicKey = utils.Ptr(key.PublicKey)
+	return key, nil
+}
+
+// writeKeyFiles writes the key files of the generated key pair
+func writeKeyFiles(p *print.Printer, model *inputModel, key *iaasSsh.GeneratedKey) error {
+	err := iaasSsh.WriteKeyFiles(model.PrivateKeyOut, key)
+	if err != nil {
+		return fmt.Errorf("the key pair has been created, but its private key could not be written, delete the key pair and create it again: %w", err)
+	}
+	p.Info("Wrote the private key to %q\n", model.PrivateKeyOut)
+	return nil
+}
+
 func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiCreateKeyPairRequest {
 	req := apiClient.CreateKeyPair(ctx)
 
@@ -3,6 +3,8 @@ package create
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
@@ -119,6 +121,68 @@ func TestParseInput(t *testing.T) {
 			flagValues:  map[string]string{},
 			isValid:     false,
 		},
+		{
+			description: "generate",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, publicKeyFlag)
+				flagValues[generateFlag] = "ed25519"
+				flagValues[privateKeyOutFlag] = "~/.ssh/stackit_id"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.PublicKey = nil
+				model.Generate = "ed25519"
+				model.PrivateKeyOut = "~/.ssh/stackit_id"
+			}),
+		},
+		{
+			description: "generate encrypted",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, publicKeyFlag)
+				flagValues[generateFlag] = "rsa-4096"
+				flagValues[privateKeyOutFlag] = "~/.ssh/stackit_id"
+				flagValues[encryptFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.PublicKey = nil
+				model.Generate = "rsa-4096"
+				model.PrivateKeyOut = "~/.ssh/stackit_id"
+				model.Encrypt = true
+			}),
+		},
+		{
+			description: "generate invalid key type",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, publicKeyFlag)
+				flagValues[generateFlag] = "dsa"
+				flagValues[privateKeyOutFlag] = "~/.ssh/stackit_id"
+			}),
+			isValid: false,
+		},
+		{
+			description: "generate without private key out",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, publicKeyFlag)
+				flagValues[generateFlag] = "ed25519"
+			}),
+			isValid: false,
+		},
+		{
+			description: "generate with public key",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[generateFlag] = "ed25519"
+				flagValues[privateKeyOutFlag] = "~/.ssh/stackit_id"
+			}),
+			isValid: false,
+		},
+		{
+			description: "encrypt without generate",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[encryptFlag] = "true"
+			}),
+			isValid: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +212,14 @@ func TestParseInput(t *testing.T) {
 				t.Fatalf("error validating flags: %v", err)
 			}
 
+			err = cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flag groups: %v", err)
+			}
+
 			model, err := parseInput(p, cmd)
 			if err != nil {
 				if !tt.isValid {
@@ -196,6 +268,52 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestGenerateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stackit_id")
+	model := fixtureInputModel(func(model *inputModel) {
+		model.PublicKey = nil
+		model.Generate = "ed25519"
+		model.PrivateKeyOut = path
+	})
+
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	key, err := generateKey(p, model)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	if model.PublicKey == nil || !strings.HasPrefix(*model.PublicKey, "ssh-ed25519 ") {
+		t.Fatalf("public key is not set in the model: %v", model.PublicKey)
+	}
+	if !strings.HasSuffix(*model.PublicKey, " "+testKeyPairName) {
+		t.Fatalf("public key doesn't have the key pair name as comment: %s", *model.PublicKey)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("key files are written before the key pair is created: %v", err)
+	}
+
+	err = writeKeyFiles(p, model, key)
+	if err != nil {
+		t.Fatalf("error writing key files: %v", err)
+	}
+	publicKeyFile, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		t.Fatalf("error reading public key file: %v", err)
+	}
+	if strings.TrimSpace(string(publicKeyFile)) != *model.PublicKey {
+		t.Fatalf("public key file doesn't match the uploaded public key")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("private key file is missing: %v", err)
+	}
+
+	err = writeKeyFiles(p, model, key)
+	if err == nil {
+		t.Fatalf("existing key files were overwritten")
+	}
+}
+
 func Test_outputResult(t *testing.T) {
 	type args struct {
 		item         *iaas.Keypair
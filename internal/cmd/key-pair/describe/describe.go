@@ -15,6 +15,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasSsh "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ssh"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
 
 	"github.com/goccy/go-yaml"
@@ -40,7 +41,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("describe %s", keyPairNameArg),
 		Short: "Describes a key pair",
-		Long:  "Describes a key pair.",
+		Long:  "Describes a key pair. The fingerprints are shown in the formats of \"ssh-keygen -l\".",
 		Args:  args.SingleArg(keyPairNameArg, nil),
 		Example: examples.Build(
 			examples.NewExample(
@@ -164,7 +165,16 @@ func outputResult(p *print.Printer, outputFormat string, showOnlyPublicKey bool,
 			table.AddSeparator()
 		}
 
-		table.AddRow("FINGERPRINT", utils.PtrString(keyPair.Fingerprint))
+		// the fingerprints are computed locally, to show them in the formats of "ssh-keygen -l"
+		sha256Fingerprint, md5Fingerprint, err := iaasSsh.Fingerprints(utils.PtrString(keyPair.PublicKey))
+		if err != nil {
+			p.Debug(print.ErrorLevel, "compute fingerprints of public key: %v", err)
+			table.AddRow("FINGERPRINT", utils.PtrString(keyPair.Fingerprint))
+		} else {
+			table.AddRow("FINGERPRINT (SHA256)", sha256Fingerprint)
+			table.AddSeparator()
+			table.AddRow("FINGERPRINT (MD5)", md5Fingerprint)
+		}
 		table.AddSeparator()
 
 		truncatedPublicKey := ""
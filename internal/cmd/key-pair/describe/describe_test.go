@@ -7,6 +7,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -208,6 +209,19 @@ func Test_outputResult(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with public key",
+			args: args{
+				outputFormat:      "",
+				showOnlyPublicKey: false,
+				keyPair: iaas.Keypair{
+					Name:        utils.Ptr(testKeyPairName),
+					PublicKey:   utils.Ptr("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKqsh3YBljguJga+BgZeMju/7TDz3VmfG6iAZF6XlJro test"),
+					Fingerprint: utils.Ptr("d1:f5:49:49:9f:6e:63:06:1c:44:92:fb:10:dc:c8:5d"),
+				},
+			},
+			wantErr: false,
+		},
 	}
 	p := print.NewPrinter()
 	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
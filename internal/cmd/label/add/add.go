@@ -0,0 +1,239 @@
+package add
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/labels"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	labelsArg = "KEY=VALUE"
+
+	resourceFlag     = "resource"
+	selectorFlag     = "selector"
+	resourceTypeFlag = "resource-type"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Labels        map[string]string
+	ResourceType  string
+	ResourceId    string
+	Selector      string
+	ResourceTypes []string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("add %s...", labelsArg),
+		Short: "Adds labels to IaaS resources",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Adds labels to IaaS resources, keeping their other labels. Existing labels with the same keys are overwritten.",
+			"The resource is either given with --resource TYPE:ID, or all resources whose labels match --selector are labeled. Key pairs are referenced by their name.",
+			fmt.Sprintf("Supported resource types are %q.", labels.Types),
+		),
+		Args: args.OneOrMoreArgs(labelsArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Add the label "env=prod" to the server with ID "xxx"`,
+				"$ stackit label add env=prod --resource server:xxx"),
+			examples.NewExample(
+				`Add the labels "env=prod" and "team=web" to the key pair with name "my-key"`,
+				"$ stackit label add env=prod team=web --resource key-pair:my-key"),
+			examples.NewExample(
+				`Add the label "owner=web-team" to all servers and volumes with the label "team=web"`,
+				"$ stackit label add owner=web-team --selector team=web --resource-type server,volume"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			resources, err := selectResources(ctx, apiClient, model)
+			if err != nil {
+				return err
+			}
+			updates := planUpdates(resources, model.Labels)
+			if len(updates) == 0 {
+				params.Printer.Info("The labels are already set on all %d selected resources\n", len(resources))
+				return nil
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to add labels to %d resources?", len(updates))
+				if model.ResourceId != "" {
+					prompt = fmt.Sprintf("Are you sure you want to add labels to %s %q?", model.ResourceType, model.ResourceId)
+				}
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			for i := range updates {
+				err = labels.Update(ctx, apiClient, model.ProjectId, &updates[i])
+				if err != nil {
+					return fmt.Errorf("add labels (%d of %d resources updated): %w", i, len(updates), err)
+				}
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, updates)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(resourceFlag, "", "Resource to label, in the format TYPE:ID")
+	cmd.Flags().String(selectorFlag, "", `Label selector of the resources to label, e.g. "env=prod,team!=web"`)
+	cmd.Flags().Var(flags.EnumSliceFlag(false, nil, labels.Types...), resourceTypeFlag, "Resource types to select with --selector, defaults to all resource types")
+
+	cmd.MarkFlagsOneRequired(resourceFlag, selectorFlag)
+	cmd.MarkFlagsMutuallyExclusive(resourceFlag, selectorFlag)
+	cmd.MarkFlagsMutuallyExclusive(resourceFlag, resourceTypeFlag)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	newLabels, err := labels.ParseLabels(inputArgs)
+	if err != nil {
+		return nil, &errors.ArgValidationError{
+			Arg:     labelsArg,
+			Details: err.Error(),
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Labels:          newLabels,
+		Selector:        flags.FlagToStringValue(p, cmd, selectorFlag),
+		ResourceTypes:   flags.FlagToStringSliceValue(p, cmd, resourceTypeFlag),
+	}
+
+	if resource := flags.FlagToStringValue(p, cmd, resourceFlag); resource != "" {
+		model.ResourceType, model.ResourceId, err = labels.ParseRef(resource)
+		if err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    resourceFlag,
+				Details: err.Error(),
+			}
+		}
+	} else {
+		if _, err := labels.ParseSelector(model.Selector); err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    selectorFlag,
+				Details: err.Error(),
+			}
+		}
+		if len(model.ResourceTypes) == 0 {
+			model.ResourceTypes = labels.Types
+		}
+	}
+
+	// key pairs belong to the user, all other resources to a project
+	if globalFlags.ProjectId == "" && model.ResourceType != labels.TypeKeyPair {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func selectResources(ctx context.Context, apiClient *iaas.APIClient, model *inputModel) ([]labels.Resource, error) {
+	if model.ResourceId != "" {
+		resource, err := labels.Get(ctx, apiClient, model.ProjectId, model.ResourceType, model.ResourceId)
+		if err != nil {
+			return nil, err
+		}
+		return []labels.Resource{*resource}, nil
+	}
+
+	selector, err := labels.ParseSelector(model.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return labels.Find(ctx, apiClient, model.ProjectId, model.ResourceTypes, selector)
+}
+
+// planUpdates returns the resources with the labels added, skipping resources which already have them
+func planUpdates(resources []labels.Resource, add map[string]string) []labels.Resource {
+	updates := []labels.Resource{}
+	for _, resource := range resources {
+		newLabels, changed := labels.Add(resource.Labels, add)
+		if !changed {
+			continue
+		}
+		resource.Labels = newLabels
+		updates = append(updates, resource)
+	}
+	return updates
+}
+
+func outputResult(p *print.Printer, outputFormat string, resources []labels.Resource) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal labeled resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resources, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal labeled resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("TYPE", "ID", "NAME", "LABELS")
+		for _, resource := range resources {
+			table.AddRow(resource.Type, resource.Id, resource.Name, labels.Format(resource.Labels))
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		p.Outputf("Added labels to %d resources\n", len(resources))
+		return nil
+	}
+}
@@ -0,0 +1,324 @@
+package add
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/labels"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testServerId = uuid.NewString()
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		"env=prod",
+		"team=web",
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag: testProjectId,
+		resourceFlag:  "server:" + testServerId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Labels:       map[string]string{"env": "prod", "team": "web"},
+		ResourceType: labels.TypeServer,
+		ResourceId:   testServerId,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no labels",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "label without value",
+			argValues:   []string{"env"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "no resource and selector",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, resourceFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid resource",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[resourceFlag] = "server:invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "key pair without project id",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+				flagValues[resourceFlag] = "key-pair:my-key"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ProjectId = ""
+				model.ResourceType = labels.TypeKeyPair
+				model.ResourceId = "my-key"
+			}),
+		},
+		{
+			description: "selector",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, resourceFlag)
+				flagValues[selectorFlag] = "env!=dev"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ResourceType = ""
+				model.ResourceId = ""
+				model.Selector = "env!=dev"
+				model.ResourceTypes = labels.Types
+			}),
+		},
+		{
+			description: "selector with resource types",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, resourceFlag)
+				flagValues[selectorFlag] = "env!=dev"
+				flagValues[resourceTypeFlag] = "server,volume"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ResourceType = ""
+				model.ResourceId = ""
+				model.Selector = "env!=dev"
+				model.ResourceTypes = []string{labels.TypeServer, labels.TypeVolume}
+			}),
+		},
+		{
+			description: "invalid selector",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, resourceFlag)
+				flagValues[selectorFlag] = "=dev"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid resource type",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, resourceFlag)
+				flagValues[selectorFlag] = "env!=dev"
+				flagValues[resourceTypeFlag] = "load-balancer"
+			}),
+			isValid: false,
+		},
+		{
+			description: "resource and selector",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[selectorFlag] = "env!=dev"
+			}),
+			isValid: false,
+		},
+		{
+			description: "resource and resource type",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[resourceTypeFlag] = "server"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err = cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			err = cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flag groups: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPlanUpdates(t *testing.T) {
+	resources := []labels.Resource{
+		{Type: labels.TypeServer, Id: testServerId, Labels: map[string]string{"env": "dev"}},
+		{Type: labels.TypeKeyPair, Id: "my-key", Name: "my-key", Labels: map[string]string{"env": "prod", "team": "web"}},
+		{Type: labels.TypeVolume, Id: "volume-id"},
+	}
+	expected := []labels.Resource{
+		{Type: labels.TypeServer, Id: testServerId, Labels: map[string]string{"env": "prod", "team": "web"}},
+		{Type: labels.TypeVolume, Id: "volume-id", Labels: map[string]string{"env": "prod", "team": "web"}},
+	}
+
+	updates := planUpdates(resources, map[string]string{"env": "prod", "team": "web"})
+	diff := cmp.Diff(updates, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if resources[0].Labels["env"] != "dev" {
+		t.Fatalf("selected resources were modified")
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		resources    []labels.Resource
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "base",
+			args: args{
+				resources: []labels.Resource{
+					{Type: labels.TypeServer, Id: testServerId, Name: "web", Labels: map[string]string{"env": "prod"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "json",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				resources: []labels.Resource{
+					{Type: labels.TypeServer, Id: testServerId, Name: "web", Labels: map[string]string{"env": "prod"}},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.resources); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
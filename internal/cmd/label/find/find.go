@@ -0,0 +1,163 @@
+package find
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/labels"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	selectorArg = "SELECTOR"
+
+	resourceTypeFlag = "resource-type"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Selector      string
+	ResourceTypes []string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("find %s", selectorArg),
+		Short: "Finds IaaS resources by their labels",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Finds the IaaS resources of a project whose labels match the selector, searching all resource types at once.",
+			`The selector is a comma separated list of requirements, which must all match: "key=value", "key!=value", "key" for resources with the label and "!key" for resources without it.`,
+			fmt.Sprintf("Supported resource types are %q.", labels.Types),
+		),
+		Args: args.SingleArg(selectorArg, validateSelector),
+		Example: examples.Build(
+			examples.NewExample(
+				`Find all resources with the label "env=prod"`,
+				"$ stackit label find env=prod"),
+			examples.NewExample(
+				`Find all servers and volumes with the label "team" which are not labeled "env=prod"`,
+				`$ stackit label find "team,env!=prod" --resource-type server,volume`),
+			examples.NewExample(
+				`Find all resources with the label "env=prod" in JSON format`,
+				"$ stackit label find env=prod --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			selector, err := labels.ParseSelector(model.Selector)
+			if err != nil {
+				return err
+			}
+			resources, err := labels.Find(ctx, apiClient, model.ProjectId, model.ResourceTypes, selector)
+			if err != nil {
+				return err
+			}
+
+			if len(resources) == 0 {
+				params.Printer.Info("No resources found matching %q\n", model.Selector)
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, resources)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.EnumSliceFlag(false, nil, labels.Types...), resourceTypeFlag, "Resource types to search, defaults to all resource types")
+}
+
+func validateSelector(value string) error {
+	_, err := labels.ParseSelector(value)
+	return err
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	selector := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	resourceTypes := flags.FlagToStringSliceValue(p, cmd, resourceTypeFlag)
+	if len(resourceTypes) == 0 {
+		resourceTypes = labels.Types
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Selector:        selector,
+		ResourceTypes:   resourceTypes,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func outputResult(p *print.Printer, outputFormat string, resources []labels.Resource) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resources, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("TYPE", "ID", "NAME", "LABELS")
+		for _, resource := range resources {
+			table.AddRow(resource.Type, resource.Id, resource.Name, labels.Format(resource.Labels))
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package label
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/label/add"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/label/find"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/label/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/label/remove"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "Manages the labels of IaaS resources",
+		Long:  "Manages the labels of IaaS resources, such as servers, volumes and networks, across all resource types.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(add.NewCmd(params))
+	cmd.AddCommand(remove.NewCmd(params))
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(find.NewCmd(params))
+}
@@ -0,0 +1,164 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/labels"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	resourceFlag = "resource"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ResourceType string
+	ResourceId   string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the labels of an IaaS resource",
+		Long: fmt.Sprintf("%s\n%s",
+			"Lists the labels of an IaaS resource, given with --resource TYPE:ID. Key pairs are referenced by their name.",
+			fmt.Sprintf("Supported resource types are %q.", labels.Types),
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List the labels of the server with ID "xxx"`,
+				"$ stackit label list --resource server:xxx"),
+			examples.NewExample(
+				`List the labels of the key pair with name "my-key" in JSON format`,
+				"$ stackit label list --resource key-pair:my-key --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			resource, err := labels.Get(ctx, apiClient, model.ProjectId, model.ResourceType, model.ResourceId)
+			if err != nil {
+				return err
+			}
+
+			if len(resource.Labels) == 0 && model.OutputFormat != print.JSONOutputFormat && model.OutputFormat != print.YAMLOutputFormat {
+				params.Printer.Info("No labels found for %s %q\n", model.ResourceType, model.ResourceId)
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, resource)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(resourceFlag, "", "Resource to list the labels of, in the format TYPE:ID")
+
+	err := flags.MarkFlagsRequired(cmd, resourceFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	resourceType, resourceId, err := labels.ParseRef(flags.FlagToStringValue(p, cmd, resourceFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    resourceFlag,
+			Details: err.Error(),
+		}
+	}
+
+	// key pairs belong to the user, all other resources to a project
+	if globalFlags.ProjectId == "" && resourceType != labels.TypeKeyPair {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ResourceType:    resourceType,
+		ResourceId:      resourceId,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func outputResult(p *print.Printer, outputFormat string, resource *labels.Resource) error {
+	if resource == nil {
+		return fmt.Errorf("resource is empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resource, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal labels: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resource, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal labels: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		keys := make([]string, 0, len(resource.Labels))
+		for key := range resource.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		table := tables.NewTable()
+		table.SetHeader("KEY", "VALUE")
+		for _, key := range keys {
+			table.AddRow(key, resource.Labels[key])
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
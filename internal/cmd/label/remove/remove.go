@@ -0,0 +1,240 @@
+package remove
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/labels"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	keysArg = "KEY"
+
+	resourceFlag     = "resource"
+	selectorFlag     = "selector"
+	resourceTypeFlag = "resource-type"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Keys          []string
+	ResourceType  string
+	ResourceId    string
+	Selector      string
+	ResourceTypes []string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("remove %s...", keysArg),
+		Short: "Removes labels from IaaS resources",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Removes the labels with the given keys from IaaS resources, keeping their other labels.",
+			"The resource is either given with --resource TYPE:ID, or the labels are removed from all resources whose labels match --selector. Key pairs are referenced by their name.",
+			fmt.Sprintf("Supported resource types are %q.", labels.Types),
+		),
+		Args: args.OneOrMoreArgs(keysArg, validateKey),
+		Example: examples.Build(
+			examples.NewExample(
+				`Remove the label with key "env" from the server with ID "xxx"`,
+				"$ stackit label remove env --resource server:xxx"),
+			examples.NewExample(
+				`Remove the labels with keys "env" and "team" from the key pair with name "my-key"`,
+				"$ stackit label remove env team --resource key-pair:my-key"),
+			examples.NewExample(
+				`Remove the label with key "temporary" from all resources with the label "env=dev"`,
+				"$ stackit label remove temporary --selector env=dev"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			resources, err := selectResources(ctx, apiClient, model)
+			if err != nil {
+				return err
+			}
+			updates := planUpdates(resources, model.Keys)
+			if len(updates) == 0 {
+				params.Printer.Info("None of the %d selected resources has the labels\n", len(resources))
+				return nil
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to remove labels from %d resources?", len(updates))
+				if model.ResourceId != "" {
+					prompt = fmt.Sprintf("Are you sure you want to remove labels from %s %q?", model.ResourceType, model.ResourceId)
+				}
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			for i := range updates {
+				err = labels.Update(ctx, apiClient, model.ProjectId, &updates[i])
+				if err != nil {
+					return fmt.Errorf("remove labels (%d of %d resources updated): %w", i, len(updates), err)
+				}
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, updates)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(resourceFlag, "", "Resource to remove the labels from, in the format TYPE:ID")
+	cmd.Flags().String(selectorFlag, "", `Label selector of the resources to label, e.g. "env=prod,team!=web"`)
+	cmd.Flags().Var(flags.EnumSliceFlag(false, nil, labels.Types...), resourceTypeFlag, "Resource types to select with --selector, defaults to all resource types")
+
+	cmd.MarkFlagsOneRequired(resourceFlag, selectorFlag)
+	cmd.MarkFlagsMutuallyExclusive(resourceFlag, selectorFlag)
+	cmd.MarkFlagsMutuallyExclusive(resourceFlag, resourceTypeFlag)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Keys:            inputArgs,
+		Selector:        flags.FlagToStringValue(p, cmd, selectorFlag),
+		ResourceTypes:   flags.FlagToStringSliceValue(p, cmd, resourceTypeFlag),
+	}
+
+	if resource := flags.FlagToStringValue(p, cmd, resourceFlag); resource != "" {
+		var err error
+		model.ResourceType, model.ResourceId, err = labels.ParseRef(resource)
+		if err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    resourceFlag,
+				Details: err.Error(),
+			}
+		}
+	} else {
+		if _, err := labels.ParseSelector(model.Selector); err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    selectorFlag,
+				Details: err.Error(),
+			}
+		}
+		if len(model.ResourceTypes) == 0 {
+			model.ResourceTypes = labels.Types
+		}
+	}
+
+	// key pairs belong to the user, all other resources to a project
+	if globalFlags.ProjectId == "" && model.ResourceType != labels.TypeKeyPair {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func validateKey(key string) error {
+	if strings.Contains(key, "=") {
+		return fmt.Errorf("%q is not a label key, the value must not be given", key)
+	}
+	return nil
+}
+
+func selectResources(ctx context.Context, apiClient *iaas.APIClient, model *inputModel) ([]labels.Resource, error) {
+	if model.ResourceId != "" {
+		resource, err := labels.Get(ctx, apiClient, model.ProjectId, model.ResourceType, model.ResourceId)
+		if err != nil {
+			return nil, err
+		}
+		return []labels.Resource{*resource}, nil
+	}
+
+	selector, err := labels.ParseSelector(model.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return labels.Find(ctx, apiClient, model.ProjectId, model.ResourceTypes, selector)
+}
+
+// planUpdates returns the resources with the labels removed, skipping resources which don't have them
+func planUpdates(resources []labels.Resource, keys []string) []labels.Resource {
+	updates := []labels.Resource{}
+	for _, resource := range resources {
+		newLabels, changed := labels.Remove(resource.Labels, keys)
+		if !changed {
+			continue
+		}
+		resource.Labels = newLabels
+		updates = append(updates, resource)
+	}
+	return updates
+}
+
+func outputResult(p *print.Printer, outputFormat string, resources []labels.Resource) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal labeled resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resources, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal labeled resources: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("TYPE", "ID", "NAME", "LABELS")
+		for _, resource := range resources {
+			table.AddRow(resource.Type, resource.Id, resource.Name, labels.Format(resource.Labels))
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		p.Outputf("Removed labels from %d resources\n", len(resources))
+		return nil
+	}
+}
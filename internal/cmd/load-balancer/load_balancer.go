@@ -8,6 +8,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/list"
 	observabilitycredentials "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/observability-credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/quota"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/status"
 	targetpool "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/target-pool"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/update"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
@@ -39,6 +40,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(quota.NewCmd(params))
 	cmd.AddCommand(observabilitycredentials.NewCmd(params))
+	cmd.AddCommand(status.NewCmd(params))
 	cmd.AddCommand(targetpool.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
 }
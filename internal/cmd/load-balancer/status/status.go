@@ -0,0 +1,349 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+const (
+	loadBalancerNameArg = "LOAD_BALANCER_NAME"
+
+	watchFlag    = "watch"
+	intervalFlag = "interval"
+
+	defaultInterval = "10s"
+	minInterval     = 2 * time.Second
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	LoadBalancerName string
+	Watch            bool
+	Interval         time.Duration
+}
+
+type loadBalancerStatus struct {
+	Name           string              `json:"name"`
+	Status         string              `json:"status"`
+	Errors         []loadBalancerError `json:"errors"`
+	Targets        []targetStatus      `json:"targets"`
+	MetricsPushUrl string              `json:"metricsPushUrl,omitempty"`
+}
+
+type loadBalancerError struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// targetStatus is a target of a target pool, with the listeners forwarding traffic to the pool
+// and the health check configuration of the pool
+type targetStatus struct {
+	Listeners         []string `json:"listeners"`
+	TargetPool        string   `json:"targetPool"`
+	HealthCheckConfig string   `json:"healthCheckConfig"`
+	DisplayName       string   `json:"displayName"`
+	Ip                string   `json:"ip"`
+	Port              string   `json:"port"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("status %s", loadBalancerNameArg),
+		Short: "Shows the status of a Load Balancer",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Shows the status of a Load Balancer, its errors and the targets each listener forwards traffic to.",
+			"The HEALTH CHECK CONFIG column shows the health check configured for the target pool, not the health of the targets, which is not reported by the Load Balancer API. If metrics are configured in the observability options of the Load Balancer, the URL they are pushed to is shown.",
+			"With --watch, the status is refreshed in an interval and shown again whenever it changes, until the command is interrupted.",
+		),
+		Args: args.SingleArg(loadBalancerNameArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Show the status of the load balancer with name "my-load-balancer"`,
+				"$ stackit load-balancer status my-load-balancer"),
+			examples.NewExample(
+				`Watch the status of the load balancer with name "my-load-balancer" during a deployment`,
+				"$ stackit load-balancer status my-load-balancer --watch"),
+			examples.NewExample(
+				`Show the status of the load balancer with name "my-load-balancer" in JSON format`,
+				"$ stackit load-balancer status my-load-balancer --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			if model.Watch {
+				return watchStatus(ctx, params.Printer, model, apiClient)
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("read load balancer: %w", err)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, false, buildStatus(resp))
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(watchFlag, false, "Refresh the status in an interval until the command is interrupted")
+	cmd.Flags().String(intervalFlag, defaultInterval, `Refresh interval in watch mode, e.g. "10s" or "1m"`)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	loadBalancerName := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	interval, err := time.ParseDuration(flags.FlagWithDefaultToStringValue(p, cmd, intervalFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: `must be a duration, e.g. "10s" or "1m"`,
+		}
+	}
+	if interval < minInterval {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: fmt.Sprintf("must be at least %s", minInterval),
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel:  globalFlags,
+		LoadBalancerName: loadBalancerName,
+		Watch:            flags.FlagToBoolValue(p, cmd, watchFlag),
+		Interval:         interval,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *loadbalancer.APIClient) loadbalancer.ApiGetLoadBalancerRequest {
+	req := apiClient.GetLoadBalancer(ctx, model.ProjectId, model.Region, model.LoadBalancerName)
+	return req
+}
+
+// watchStatus shows the status whenever it changes, until the command is interrupted
+func watchStatus(ctx context.Context, p *print.Printer, model *inputModel, apiClient *loadbalancer.APIClient) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(model.Interval)
+	defer ticker.Stop()
+
+	p.Info("Refreshing the status of load balancer %q every %s, press Ctrl+C to stop\n", model.LoadBalancerName, model.Interval)
+	var last *loadBalancerStatus
+	for {
+		resp, err := buildRequest(ctx, model, apiClient).Execute()
+		switch {
+		case err != nil && ctx.Err() == nil:
+			// Errors are only reported, so that a temporary failure doesn't stop watching
+			p.Warn("read load balancer: %v\n", err)
+		case err == nil:
+			status := buildStatus(resp)
+			if last == nil || !reflect.DeepEqual(status, last) {
+				p.Outputf("Status at %s\n", time.Now().Format(time.TimeOnly))
+				err = outputResult(p, model.OutputFormat, true, status)
+				if err != nil {
+					return err
+				}
+				last = status
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func buildStatus(loadBalancer *loadbalancer.LoadBalancer) *loadBalancerStatus {
+	if loadBalancer == nil {
+		return nil
+	}
+
+	status := &loadBalancerStatus{
+		Name:    utils.PtrString(loadBalancer.Name),
+		Status:  utils.PtrString(loadBalancer.Status),
+		Errors:  []loadBalancerError{},
+		Targets: []targetStatus{},
+	}
+	for _, e := range utils.PtrValue(loadBalancer.Errors) {
+		status.Errors = append(status.Errors, loadBalancerError{
+			Type:        utils.PtrString(e.Type),
+			Description: utils.PtrString(e.Description),
+		})
+	}
+	if loadBalancer.Options != nil && loadBalancer.Options.Observability != nil && loadBalancer.Options.Observability.Metrics != nil {
+		status.MetricsPushUrl = utils.PtrString(loadBalancer.Options.Observability.Metrics.PushUrl)
+	}
+
+	listenersByPool := map[string][]string{}
+	for _, listener := range utils.PtrValue(loadBalancer.Listeners) {
+		pool := utils.PtrString(listener.TargetPool)
+		name := utils.PtrStringDefault(listener.DisplayName, utils.PtrString(listener.Name))
+		description := fmt.Sprintf("%s (%s %s)", name, utils.PtrString(listener.Protocol), utils.PtrString(listener.Port))
+		listenersByPool[pool] = append(listenersByPool[pool], description)
+	}
+
+	for _, pool := range utils.PtrValue(loadBalancer.TargetPools) {
+		name := utils.PtrString(pool.Name)
+		listeners := listenersByPool[name]
+		if listeners == nil {
+			listeners = []string{}
+		}
+		for _, target := range utils.PtrValue(pool.Targets) {
+			status.Targets = append(status.Targets, targetStatus{
+				Listeners:         listeners,
+				TargetPool:        name,
+				HealthCheckConfig: describeHealthCheckConfig(pool.ActiveHealthCheck),
+				DisplayName:       utils.PtrString(target.DisplayName),
+				Ip:                utils.PtrString(target.Ip),
+				Port:              utils.PtrString(pool.TargetPort),
+			})
+		}
+	}
+	return status
+}
+
+func describeHealthCheckConfig(check *loadbalancer.ActiveHealthCheck) string {
+	if check == nil {
+		return "default"
+	}
+	parts := []string{}
+	if check.Interval != nil {
+		parts = append(parts, fmt.Sprintf("every %s", *check.Interval))
+	}
+	if check.UnhealthyThreshold != nil {
+		parts = append(parts, fmt.Sprintf("unhealthy after %d failures", *check.UnhealthyThreshold))
+	}
+	if check.HealthyThreshold != nil {
+		parts = append(parts, fmt.Sprintf("healthy after %d successes", *check.HealthyThreshold))
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// outputResult shows the status. In watch mode, the tables are printed without the pager,
+// which would block the refreshes until it is closed.
+func outputResult(p *print.Printer, outputFormat string, watch bool, status *loadBalancerStatus) error {
+	if status == nil {
+		return fmt.Errorf("loadbalancer response is empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal load balancer status: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(status, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal load balancer status: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		content := []tables.Table{}
+
+		table := tables.NewTable()
+		table.SetTitle("Load Balancer")
+		table.AddRow("NAME", status.Name)
+		table.AddSeparator()
+		table.AddRow("STATE", status.Status)
+		if status.MetricsPushUrl != "" {
+			table.AddSeparator()
+			table.AddRow("METRICS PUSH URL", status.MetricsPushUrl)
+		}
+		content = append(content, table)
+
+		if len(status.Errors) > 0 {
+			table = tables.NewTable()
+			table.SetTitle("Errors")
+			table.SetHeader("TYPE", "DESCRIPTION")
+			for _, e := range status.Errors {
+				table.AddRow(e.Type, e.Description)
+				table.AddSeparator()
+			}
+			content = append(content, table)
+		}
+
+		table = tables.NewTable()
+		table.SetTitle("Targets")
+		table.SetHeader("LISTENERS", "TARGET POOL", "HEALTH CHECK CONFIG", "TARGET", "IP", "PORT")
+		for _, target := range status.Targets {
+			table.AddRow(strings.Join(target.Listeners, "\n"), target.TargetPool, target.HealthCheckConfig, target.DisplayName, target.Ip, target.Port)
+			table.AddSeparator()
+		}
+		content = append(content, table)
+
+		if watch {
+			renderedTables := ""
+			for i := range content {
+				renderedTables += content[i].Render()
+			}
+			p.Outputln(renderedTables)
+			return nil
+		}
+		err := tables.DisplayTables(p, content)
+		if err != nil {
+			return fmt.Errorf("display output: %w", err)
+		}
+		return nil
+	}
+}
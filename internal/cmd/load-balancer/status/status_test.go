@@ -0,0 +1,354 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+const (
+	testRegion           = "eu02"
+	testloadBalancerName = "loadBalancer"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &loadbalancer.APIClient{}
+var testProjectId = uuid.NewString()
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testloadBalancerName,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		LoadBalancerName: testloadBalancerName,
+		Interval:         10 * time.Second,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureLoadBalancer() *loadbalancer.LoadBalancer {
+	return &loadbalancer.LoadBalancer{
+		Name:   utils.Ptr(testloadBalancerName),
+		Status: utils.Ptr("STATUS_ERROR"),
+		Errors: &[]loadbalancer.LoadBalancerError{
+			{
+				Type:        utils.Ptr("TYPE_FIP_NOT_CONFIGURED"),
+				Description: utils.Ptr("public IP not found"),
+			},
+		},
+		Listeners: &[]loadbalancer.Listener{
+			{
+				DisplayName: utils.Ptr("http"),
+				Port:        utils.Ptr(int64(80)),
+				Protocol:    utils.Ptr("PROTOCOL_TCP"),
+				TargetPool:  utils.Ptr("web"),
+			},
+			{
+				Name:       utils.Ptr("tcp-443"),
+				Port:       utils.Ptr(int64(443)),
+				Protocol:   utils.Ptr("PROTOCOL_TCP"),
+				TargetPool: utils.Ptr("web"),
+			},
+		},
+		TargetPools: &[]loadbalancer.TargetPool{
+			{
+				Name:       utils.Ptr("web"),
+				TargetPort: utils.Ptr(int64(8080)),
+				ActiveHealthCheck: &loadbalancer.ActiveHealthCheck{
+					Interval:           utils.Ptr("5s"),
+					UnhealthyThreshold: utils.Ptr(int64(3)),
+				},
+				Targets: &[]loadbalancer.Target{
+					{DisplayName: utils.Ptr("web-1"), Ip: utils.Ptr("10.0.0.1")},
+				},
+			},
+			{
+				Name:       utils.Ptr("unused"),
+				TargetPort: utils.Ptr(int64(9090)),
+				Targets: &[]loadbalancer.Target{
+					{DisplayName: utils.Ptr("other"), Ip: utils.Ptr("10.0.0.2")},
+				},
+			},
+		},
+		Options: &loadbalancer.LoadBalancerOptions{
+			Observability: &loadbalancer.LoadbalancerOptionObservability{
+				Metrics: &loadbalancer.LoadbalancerOptionMetrics{
+					PushUrl: utils.Ptr("https://push.example.com"),
+				},
+			},
+		},
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "watch",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[watchFlag] = "true"
+				flagValues[intervalFlag] = "1m"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Watch = true
+				model.Interval = time.Minute
+			}),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "soon"
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval too short",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "100ms"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	request := buildRequest(testCtx, fixtureInputModel(), testClient)
+	expectedRequest := testClient.GetLoadBalancer(testCtx, testProjectId, testRegion, testloadBalancerName)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	expected := &loadBalancerStatus{
+		Name:   testloadBalancerName,
+		Status: "STATUS_ERROR",
+		Errors: []loadBalancerError{
+			{Type: "TYPE_FIP_NOT_CONFIGURED", Description: "public IP not found"},
+		},
+		Targets: []targetStatus{
+			{
+				Listeners:         []string{"http (PROTOCOL_TCP 80)", "tcp-443 (PROTOCOL_TCP 443)"},
+				TargetPool:        "web",
+				HealthCheckConfig: "every 5s, unhealthy after 3 failures",
+				DisplayName:       "web-1",
+				Ip:                "10.0.0.1",
+				Port:              "8080",
+			},
+			{
+				Listeners:         []string{},
+				TargetPool:        "unused",
+				HealthCheckConfig: "default",
+				DisplayName:       "other",
+				Ip:                "10.0.0.2",
+				Port:              "9090",
+			},
+		},
+		MetricsPushUrl: "https://push.example.com",
+	}
+
+	diff := cmp.Diff(buildStatus(fixtureLoadBalancer()), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if buildStatus(nil) != nil {
+		t.Fatalf("expected nil status for nil load balancer")
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		watch        bool
+		status       *loadBalancerStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "empty status",
+			args: args{
+				status: &loadBalancerStatus{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "status",
+			args: args{
+				status: buildStatus(fixtureLoadBalancer()),
+			},
+			wantErr: false,
+		},
+		{
+			name: "status in watch mode",
+			args: args{
+				watch:  true,
+				status: buildStatus(fixtureLoadBalancer()),
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				status:       buildStatus(fixtureLoadBalancer()),
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				status:       buildStatus(fixtureLoadBalancer()),
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.watch, tt.args.status); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
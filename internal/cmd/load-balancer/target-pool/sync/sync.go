@@ -0,0 +1,444 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	iaasClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/client"
+	lbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+const (
+	lbNameFlag              = "lb-name"
+	targetPoolNameFlag      = "target-pool-name"
+	serverLabelSelectorFlag = "server-label-selector"
+	networkIdFlag           = "network-id"
+	dryRunFlag              = "dry-run"
+	watchFlag               = "watch"
+	intervalFlag            = "interval"
+
+	defaultInterval = "30s"
+	minInterval     = 10 * time.Second
+
+	actionAdd    = "add"
+	actionRemove = "remove"
+
+	serverStatusActive = "ACTIVE"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	LBName              string
+	TargetPoolName      string
+	ServerLabelSelector string
+	NetworkId           string
+	DryRun              bool
+	Watch               bool
+	Interval            time.Duration
+}
+
+// targetChange is a target to add to or remove from the target pool
+type targetChange struct {
+	Action      string `json:"action"`
+	Ip          string `json:"ip"`
+	DisplayName string `json:"displayName"`
+}
+
+// syncPlan holds the target pool and the targets it should have
+type syncPlan struct {
+	targetPool *loadbalancer.TargetPool
+	targets    []loadbalancer.Target
+	changes    []targetChange
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Syncs the targets of a target pool with the servers matching a label selector",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Syncs the targets of a target pool with the servers matching a label selector.",
+			"The IP addresses of the NICs of all active servers in the given network become the targets of the target pool, named after their server. Targets of servers which no longer match are removed. All changes are applied in a single update of the target pool.",
+			"With --watch, the sync is repeated in an interval until the command is interrupted, so it can keep the target pool of autoscaled servers up to date.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Sync target pool "my-target-pool" of load balancer "my-load-balancer" with the servers with label "role=web" in the network with ID "xxx"`,
+				"$ stackit load-balancer target-pool sync --lb-name my-load-balancer --target-pool-name my-target-pool --server-label-selector role=web --network-id xxx"),
+			examples.NewExample(
+				`Show the changes needed to sync target pool "my-target-pool" of load balancer "my-load-balancer", without applying them`,
+				"$ stackit load-balancer target-pool sync --lb-name my-load-balancer --target-pool-name my-target-pool --server-label-selector role=web --network-id xxx --dry-run"),
+			examples.NewExample(
+				`Keep target pool "my-target-pool" of load balancer "my-load-balancer" in sync, checking every minute`,
+				"$ stackit load-balancer target-pool sync --lb-name my-load-balancer --target-pool-name my-target-pool --server-label-selector role=web --network-id xxx --watch --interval 1m"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API clients
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+			iaasApiClient, err := iaasClient.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			if model.Watch {
+				return watchTargetPool(ctx, params.Printer, model, iaasApiClient, apiClient)
+			}
+
+			plan, err := planSync(ctx, params.Printer, model, iaasApiClient, apiClient)
+			if err != nil {
+				return err
+			}
+			if len(plan.changes) == 0 {
+				params.Printer.Info("Target pool %q of load balancer %q is already in sync\n", model.TargetPoolName, model.LBName)
+				return nil
+			}
+			if model.DryRun {
+				return outputResult(params.Printer, model, plan.changes)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to %s in target pool %q of load balancer %q?", describeChanges(plan.changes), model.TargetPoolName, model.LBName)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			s := spinner.New(params.Printer)
+			s.Start("Updating target pool")
+			err = applySync(ctx, model, apiClient, plan)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			return outputResult(params.Printer, model, plan.changes)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(lbNameFlag, "", "Load balancer name")
+	cmd.Flags().String(targetPoolNameFlag, "", "Target pool name")
+	cmd.Flags().String(serverLabelSelectorFlag, "", `Label selector of the servers, e.g. "role=web"`)
+	cmd.Flags().Var(flags.UUIDFlag(), networkIdFlag, "ID of the network of the server NICs to use as targets")
+	cmd.Flags().Bool(dryRunFlag, false, "Only show the changes, without applying them")
+	cmd.Flags().Bool(watchFlag, false, "Repeat the sync in an interval until the command is interrupted")
+	cmd.Flags().String(intervalFlag, defaultInterval, `Interval of the sync in watch mode, e.g. "30s" or "5m"`)
+
+	cmd.MarkFlagsMutuallyExclusive(dryRunFlag, watchFlag)
+	err := flags.MarkFlagsRequired(cmd, lbNameFlag, targetPoolNameFlag, serverLabelSelectorFlag, networkIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	interval, err := time.ParseDuration(flags.FlagWithDefaultToStringValue(p, cmd, intervalFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: `must be a duration, e.g. "30s" or "5m"`,
+		}
+	}
+	if interval < minInterval {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: fmt.Sprintf("must be at least %s", minInterval),
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel:     globalFlags,
+		LBName:              flags.FlagToStringValue(p, cmd, lbNameFlag),
+		TargetPoolName:      flags.FlagToStringValue(p, cmd, targetPoolNameFlag),
+		ServerLabelSelector: flags.FlagToStringValue(p, cmd, serverLabelSelectorFlag),
+		NetworkId:           flags.FlagToStringValue(p, cmd, networkIdFlag),
+		DryRun:              flags.FlagToBoolValue(p, cmd, dryRunFlag),
+		Watch:               flags.FlagToBoolValue(p, cmd, watchFlag),
+		Interval:            interval,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient lbUtils.LoadBalancerClient, plan *syncPlan) (loadbalancer.ApiUpdateTargetPoolRequest, error) {
+	req := apiClient.UpdateTargetPool(ctx, model.ProjectId, model.Region, model.LBName, model.TargetPoolName)
+
+	targetPool := *plan.targetPool
+	targets := slices.Clone(plan.targets)
+	targetPool.Targets = &targets
+
+	payload := lbUtils.ToPayloadTargetPool(&targetPool)
+	if payload == nil {
+		return req, fmt.Errorf("nil payload")
+	}
+	return req.UpdateTargetPoolPayload(*payload), nil
+}
+
+func watchTargetPool(ctx context.Context, p *print.Printer, model *inputModel, iaasApiClient *iaas.APIClient, apiClient lbUtils.LoadBalancerClient) error {
+	if !model.AssumeYes {
+		prompt := fmt.Sprintf("Are you sure you want to keep target pool %q of load balancer %q in sync with the servers matching %q?", model.TargetPoolName, model.LBName, model.ServerLabelSelector)
+		err := p.PromptForConfirmation(prompt)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(model.Interval)
+	defer ticker.Stop()
+
+	p.Info("Syncing target pool %q of load balancer %q every %s, press Ctrl+C to stop\n", model.TargetPoolName, model.LBName, model.Interval)
+	for {
+		// Errors are only reported, so that a temporary failure doesn't stop the sync
+		plan, err := planSync(ctx, p, model, iaasApiClient, apiClient)
+		if err == nil && len(plan.changes) > 0 {
+			err = applySync(ctx, model, apiClient, plan)
+			if err == nil {
+				err = outputResult(p, model, plan.changes)
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			p.Warn("sync target pool: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func planSync(ctx context.Context, p *print.Printer, model *inputModel, iaasApiClient *iaas.APIClient, apiClient lbUtils.LoadBalancerClient) (*syncPlan, error) {
+	resp, err := iaasApiClient.ListServers(ctx, model.ProjectId).LabelSelector(model.ServerLabelSelector).Details(true).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list servers: %w", err)
+	}
+	desired, skipped := targetsFromServers(utils.PtrValue(resp.Items), model.NetworkId)
+	for _, name := range skipped {
+		p.Warn("server %q has no NIC in network %q\n", name, model.NetworkId)
+	}
+	// An empty target pool would stop all traffic, which is more likely caused by a wrong label selector than intended
+	if len(desired) == 0 {
+		return nil, fmt.Errorf("no active servers matching %q have a NIC in network %q, not removing all targets", model.ServerLabelSelector, model.NetworkId)
+	}
+
+	targetPool, err := lbUtils.GetLoadBalancerTargetPool(ctx, apiClient, model.ProjectId, model.Region, model.LBName, model.TargetPoolName)
+	if err != nil {
+		return nil, fmt.Errorf("get load balancer target pool: %w", err)
+	}
+
+	targets, changes := planTargets(utils.PtrValue(targetPool.Targets), desired)
+	return &syncPlan{
+		targetPool: targetPool,
+		targets:    targets,
+		changes:    changes,
+	}, nil
+}
+
+func applySync(ctx context.Context, model *inputModel, apiClient lbUtils.LoadBalancerClient, plan *syncPlan) error {
+	req, err := buildRequest(ctx, model, apiClient, plan)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	_, err = req.Execute()
+	if err != nil {
+		return fmt.Errorf("update target pool: %w", err)
+	}
+	return nil
+}
+
+// targetsFromServers returns a target for each IP address of the active servers in the network, sorted by IP address,
+// and the names of the active servers without a NIC in the network
+func targetsFromServers(servers []iaas.Server, networkId string) (targets []loadbalancer.Target, skipped []string) {
+	targets = []loadbalancer.Target{}
+	skipped = []string{}
+	for i := range servers {
+		server := &servers[i]
+		if utils.PtrString(server.Status) != serverStatusActive {
+			continue
+		}
+		found := false
+		for _, nic := range utils.PtrValue(server.Nics) {
+			if utils.PtrString(nic.NetworkId) != networkId {
+				continue
+			}
+			ip := utils.PtrString(nic.Ipv4)
+			if ip == "" {
+				ip = utils.PtrString(nic.Ipv6)
+			}
+			if ip == "" {
+				continue
+			}
+			found = true
+			targets = append(targets, loadbalancer.Target{
+				DisplayName: utils.Ptr(utils.PtrString(server.Name)),
+				Ip:          utils.Ptr(ip),
+			})
+		}
+		if !found {
+			skipped = append(skipped, utils.PtrString(server.Name))
+		}
+	}
+	slices.SortFunc(targets, func(a, b loadbalancer.Target) int {
+		return compareIps(utils.PtrString(a.Ip), utils.PtrString(b.Ip))
+	})
+	return targets, skipped
+}
+
+// planTargets returns the targets of the target pool after the sync and the changes to the current targets.
+// Current targets which are still desired are kept as they are, new targets are added after them.
+func planTargets(current, desired []loadbalancer.Target) (targets []loadbalancer.Target, changes []targetChange) {
+	desiredIps := map[string]bool{}
+	for _, target := range desired {
+		desiredIps[utils.PtrString(target.Ip)] = true
+	}
+
+	targets = []loadbalancer.Target{}
+	changes = []targetChange{}
+	currentIps := map[string]bool{}
+	for _, target := range current {
+		ip := utils.PtrString(target.Ip)
+		currentIps[ip] = true
+		if desiredIps[ip] {
+			targets = append(targets, target)
+			continue
+		}
+		changes = append(changes, targetChange{
+			Action:      actionRemove,
+			Ip:          ip,
+			DisplayName: utils.PtrString(target.DisplayName),
+		})
+	}
+	for _, target := range desired {
+		ip := utils.PtrString(target.Ip)
+		if currentIps[ip] {
+			continue
+		}
+		currentIps[ip] = true
+		targets = append(targets, target)
+		changes = append(changes, targetChange{
+			Action:      actionAdd,
+			Ip:          ip,
+			DisplayName: utils.PtrString(target.DisplayName),
+		})
+	}
+	return targets, changes
+}
+
+func compareIps(a, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return addrA.Compare(addrB)
+}
+
+func describeChanges(changes []targetChange) string {
+	add, remove := 0, 0
+	for i := range changes {
+		if changes[i].Action == actionAdd {
+			add++
+		} else {
+			remove++
+		}
+	}
+	switch {
+	case add > 0 && remove > 0:
+		return fmt.Sprintf("add %d and remove %d targets", add, remove)
+	case add > 0:
+		return fmt.Sprintf("add %d targets", add)
+	default:
+		return fmt.Sprintf("remove %d targets", remove)
+	}
+}
+
+func outputResult(p *print.Printer, model *inputModel, changes []targetChange) error {
+	if model == nil {
+		return fmt.Errorf("input model is empty")
+	}
+
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal target pool changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(changes, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal target pool changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ACTION", "IP", "NAME")
+		for _, change := range changes {
+			table.AddRow(change.Action, change.Ip, change.DisplayName)
+		}
+		p.Outputln(table.Render())
+
+		if model.DryRun {
+			p.Outputf("Dry run, no changes were applied to target pool %q of load balancer %q\n", model.TargetPoolName, model.LBName)
+		} else {
+			p.Outputf("Applied %d target changes to target pool %q of load balancer %q\n", len(changes), model.TargetPoolName, model.LBName)
+		}
+		return nil
+	}
+}
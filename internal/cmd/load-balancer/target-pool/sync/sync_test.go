@@ -0,0 +1,441 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx       = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient    = &loadbalancer.APIClient{}
+	testProjectId = uuid.NewString()
+	testNetworkId = uuid.NewString()
+)
+
+const (
+	testRegion         = "eu02"
+	testLBName         = "my-load-balancer"
+	testTargetPoolName = "target-pool-1"
+	testLabelSelector  = "role=web"
+)
+
+type loadBalancerClientMocked struct{}
+
+func (m *loadBalancerClientMocked) GetCredentialsExecute(_ context.Context, _, _, _ string) (*loadbalancer.GetCredentialsResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *loadBalancerClientMocked) GetLoadBalancerExecute(_ context.Context, _, _, _ string) (*loadbalancer.LoadBalancer, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *loadBalancerClientMocked) UpdateTargetPool(ctx context.Context, projectId, region, loadBalancerName, targetPoolName string) loadbalancer.ApiUpdateTargetPoolRequest {
+	return testClient.UpdateTargetPool(ctx, projectId, region, loadBalancerName, targetPoolName)
+}
+
+func (m *loadBalancerClientMocked) ListLoadBalancersExecute(_ context.Context, _, _ string) (*loadbalancer.ListLoadBalancersResponse, error) {
+	return nil, nil
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		lbNameFlag:                testLBName,
+		targetPoolNameFlag:        testTargetPoolName,
+		serverLabelSelectorFlag:   testLabelSelector,
+		networkIdFlag:             testNetworkId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		LBName:              testLBName,
+		TargetPoolName:      testTargetPoolName,
+		ServerLabelSelector: testLabelSelector,
+		NetworkId:           testNetworkId,
+		Interval:            30 * time.Second,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureTarget(name, ip string) loadbalancer.Target {
+	return loadbalancer.Target{
+		DisplayName: utils.Ptr(name),
+		Ip:          utils.Ptr(ip),
+	}
+}
+
+func fixtureServer(name, status string, nics ...iaas.ServerNetwork) iaas.Server {
+	return iaas.Server{
+		Name:   utils.Ptr(name),
+		Status: utils.Ptr(status),
+		Nics:   &nics,
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "watch with interval",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[watchFlag] = "true"
+				flagValues[intervalFlag] = "5m"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Watch = true
+				model.Interval = 5 * time.Minute
+			}),
+		},
+		{
+			description: "dry run",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[dryRunFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.DryRun = true
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "label selector missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, serverLabelSelectorFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "network id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[networkIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "often"
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval too short",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "1s"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetsFromServers(t *testing.T) {
+	otherNetworkId := uuid.NewString()
+	servers := []iaas.Server{
+		fixtureServer("web-2", serverStatusActive, iaas.ServerNetwork{NetworkId: utils.Ptr(testNetworkId), Ipv4: utils.Ptr("10.0.0.10")}),
+		fixtureServer("web-1", serverStatusActive,
+			iaas.ServerNetwork{NetworkId: utils.Ptr(otherNetworkId), Ipv4: utils.Ptr("192.168.0.5")},
+			iaas.ServerNetwork{NetworkId: utils.Ptr(testNetworkId), Ipv4: utils.Ptr("10.0.0.9")},
+		),
+		fixtureServer("web-3", serverStatusActive, iaas.ServerNetwork{NetworkId: utils.Ptr(otherNetworkId), Ipv4: utils.Ptr("192.168.0.6")}),
+		fixtureServer("web-4", "CREATING", iaas.ServerNetwork{NetworkId: utils.Ptr(testNetworkId), Ipv4: utils.Ptr("10.0.0.11")}),
+		fixtureServer("web-5", serverStatusActive, iaas.ServerNetwork{NetworkId: utils.Ptr(testNetworkId), Ipv6: utils.Ptr("2001:db8::5")}),
+	}
+
+	targets, skipped := targetsFromServers(servers, testNetworkId)
+	expectedTargets := []loadbalancer.Target{
+		fixtureTarget("web-1", "10.0.0.9"),
+		fixtureTarget("web-2", "10.0.0.10"),
+		fixtureTarget("web-5", "2001:db8::5"),
+	}
+	diff := cmp.Diff(targets, expectedTargets)
+	if diff != "" {
+		t.Fatalf("Targets do not match: %s", diff)
+	}
+	diff = cmp.Diff(skipped, []string{"web-3"})
+	if diff != "" {
+		t.Fatalf("Skipped servers do not match: %s", diff)
+	}
+}
+
+func TestPlanTargets(t *testing.T) {
+	tests := []struct {
+		description     string
+		current         []loadbalancer.Target
+		desired         []loadbalancer.Target
+		expectedTargets []loadbalancer.Target
+		expectedChanges []targetChange
+	}{
+		{
+			description:     "in sync",
+			current:         []loadbalancer.Target{fixtureTarget("manual", "10.0.0.1")},
+			desired:         []loadbalancer.Target{fixtureTarget("web-1", "10.0.0.1")},
+			expectedTargets: []loadbalancer.Target{fixtureTarget("manual", "10.0.0.1")},
+			expectedChanges: []targetChange{},
+		},
+		{
+			description: "add and remove",
+			current: []loadbalancer.Target{
+				fixtureTarget("web-1", "10.0.0.1"),
+				fixtureTarget("web-2", "10.0.0.2"),
+			},
+			desired: []loadbalancer.Target{
+				fixtureTarget("web-2", "10.0.0.2"),
+				fixtureTarget("web-3", "10.0.0.3"),
+			},
+			expectedTargets: []loadbalancer.Target{
+				fixtureTarget("web-2", "10.0.0.2"),
+				fixtureTarget("web-3", "10.0.0.3"),
+			},
+			expectedChanges: []targetChange{
+				{Action: actionRemove, Ip: "10.0.0.1", DisplayName: "web-1"},
+				{Action: actionAdd, Ip: "10.0.0.3", DisplayName: "web-3"},
+			},
+		},
+		{
+			description: "empty target pool and duplicate IP",
+			current:     nil,
+			desired: []loadbalancer.Target{
+				fixtureTarget("web-1", "10.0.0.1"),
+				fixtureTarget("web-1", "10.0.0.1"),
+			},
+			expectedTargets: []loadbalancer.Target{fixtureTarget("web-1", "10.0.0.1")},
+			expectedChanges: []targetChange{
+				{Action: actionAdd, Ip: "10.0.0.1", DisplayName: "web-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			targets, changes := planTargets(tt.current, tt.desired)
+			diff := cmp.Diff(targets, tt.expectedTargets)
+			if diff != "" {
+				t.Fatalf("Targets do not match: %s", diff)
+			}
+			diff = cmp.Diff(changes, tt.expectedChanges)
+			if diff != "" {
+				t.Fatalf("Changes do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	targetPool := &loadbalancer.TargetPool{
+		Name:       utils.Ptr(testTargetPoolName),
+		TargetPort: utils.Ptr(int64(80)),
+		ActiveHealthCheck: &loadbalancer.ActiveHealthCheck{
+			UnhealthyThreshold: utils.Ptr(int64(3)),
+		},
+		Targets: &[]loadbalancer.Target{fixtureTarget("web-1", "10.0.0.1")},
+	}
+	plan := &syncPlan{
+		targetPool: targetPool,
+		targets:    []loadbalancer.Target{fixtureTarget("web-2", "10.0.0.2")},
+	}
+
+	request, err := buildRequest(testCtx, fixtureInputModel(), &loadBalancerClientMocked{}, plan)
+	if err != nil {
+		t.Fatalf("error building request: %v", err)
+	}
+
+	expectedRequest := testClient.UpdateTargetPool(testCtx, testProjectId, testRegion, testLBName, testTargetPoolName).
+		UpdateTargetPoolPayload(loadbalancer.UpdateTargetPoolPayload{
+			Name:       utils.Ptr(testTargetPoolName),
+			TargetPort: utils.Ptr(int64(80)),
+			ActiveHealthCheck: &loadbalancer.ActiveHealthCheck{
+				UnhealthyThreshold: utils.Ptr(int64(3)),
+			},
+			Targets: &[]loadbalancer.Target{fixtureTarget("web-2", "10.0.0.2")},
+		})
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if len(*targetPool.Targets) != 1 || *(*targetPool.Targets)[0].Ip != "10.0.0.1" {
+		t.Fatalf("target pool of the plan was modified")
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	add := targetChange{Action: actionAdd}
+	remove := targetChange{Action: actionRemove}
+	tests := []struct {
+		changes  []targetChange
+		expected string
+	}{
+		{[]targetChange{add, add}, "add 2 targets"},
+		{[]targetChange{remove}, "remove 1 targets"},
+		{[]targetChange{add, remove, remove}, "add 1 and remove 2 targets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := describeChanges(tt.changes); got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testChanges := []targetChange{
+		{Action: actionRemove, Ip: "10.0.0.1", DisplayName: "web-1"},
+		{Action: actionAdd, Ip: "10.0.0.3", DisplayName: "web-3"},
+	}
+
+	type args struct {
+		model   *inputModel
+		changes []targetChange
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "changes",
+			args: args{
+				model:   fixtureInputModel(),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dry run",
+			args: args{
+				model:   fixtureInputModel(func(model *inputModel) { model.DryRun = true }),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				model:   fixtureInputModel(func(model *inputModel) { model.OutputFormat = print.JSONOutputFormat }),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				model:   fixtureInputModel(func(model *inputModel) { model.OutputFormat = print.YAMLOutputFormat }),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, tt.args.changes); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	addtarget "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/target-pool/add-target"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/target-pool/describe"
 	removetarget "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/target-pool/remove-target"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer/target-pool/sync"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -27,4 +28,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(addtarget.NewCmd(params))
 	cmd.AddCommand(removetarget.NewCmd(params))
 	cmd.AddCommand(describe.NewCmd(params))
+	cmd.AddCommand(sync.NewCmd(params))
 }
@@ -38,12 +38,16 @@ const (
 	planIdFlag               = "plan-id"
 	planNameFlag             = "plan-name"
 	versionFlag              = "version"
+	cpuFlag                  = "cpu"
+	ramFlag                  = "ram"
 )
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 	PlanName string
 	Version  string
+	CPU      *int64
+	RAM      *int64
 
 	InstanceName         *string
 	EnableMonitoring     *bool
@@ -66,6 +70,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create a MariaDB instance with name "my-instance" and specify plan by name and version`,
 				"$ stackit mariadb instance create --name my-instance --plan-name stackit-mariadb-1.2.10-replica --version 10.6"),
+			examples.NewExample(
+				`Create a MariaDB instance with name "my-instance" and use the smallest plan of version 10.11 with at least 2 CPUs and 4 GB RAM`,
+				"$ stackit mariadb instance create --name my-instance --cpu 2 --ram 4 --version 10.11"),
 			examples.NewExample(
 				`Create a MariaDB instance with name "my-instance" and specify plan by ID`,
 				"$ stackit mariadb instance create --name my-instance --plan-id xxx"),
@@ -145,6 +152,8 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), planIdFlag, "Plan ID")
 	cmd.Flags().String(planNameFlag, "", "Plan name")
 	cmd.Flags().String(versionFlag, "", "Instance MariaDB version")
+	cmd.Flags().Int64(cpuFlag, 0, "Minimum number of CPUs of the plan. Can be used together with --ram and --version instead of --plan-name")
+	cmd.Flags().Int64(ramFlag, 0, "Minimum amount of RAM (in GB) of the plan. Can be used together with --cpu and --version instead of --plan-name")
 
 	err := flags.MarkFlagsRequired(cmd, instanceNameFlag)
 	cobra.CheckErr(err)
@@ -159,14 +168,22 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	planId := flags.FlagToStringPointer(p, cmd, planIdFlag)
 	planName := flags.FlagToStringValue(p, cmd, planNameFlag)
 	version := flags.FlagToStringValue(p, cmd, versionFlag)
+	cpu := flags.FlagToInt64Pointer(p, cmd, cpuFlag)
+	ram := flags.FlagToInt64Pointer(p, cmd, ramFlag)
+	hasResources := cpu != nil || ram != nil
 
-	if planId == nil && (planName == "" || version == "") {
-		return nil, &cliErr.DSAInputPlanError{
+	if planId == nil && ((planName == "" && !hasResources) || version == "") {
+		return nil, &cliErr.DSACreateInputPlanError{
+			Cmd: cmd,
+		}
+	}
+	if planId != nil && (planName != "" || version != "" || hasResources) {
+		return nil, &cliErr.DSACreateInputPlanError{
 			Cmd: cmd,
 		}
 	}
-	if planId != nil && (planName != "" || version != "") {
-		return nil, &cliErr.DSAInputPlanError{
+	if planName != "" && hasResources {
+		return nil, &cliErr.DSACreateInputPlanError{
 			Cmd: cmd,
 		}
 	}
@@ -184,6 +201,8 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		PlanId:               planId,
 		PlanName:             planName,
 		Version:              version,
+		CPU:                  cpu,
+		RAM:                  ram,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -215,7 +234,11 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient mariaDBClien
 	}
 
 	if model.PlanId == nil {
-		planId, err = mariadbUtils.LoadPlanId(model.PlanName, model.Version, offerings)
+		if model.PlanName != "" {
+			planId, err = mariadbUtils.LoadPlanId(model.PlanName, model.Version, offerings)
+		} else {
+			planId, err = mariadbUtils.LoadPlanIdFromResources(model.CPU, model.RAM, model.Version, offerings)
+		}
 		if err != nil {
 			var dsaInvalidPlanError *cliErr.DSAInvalidPlanError
 			if !errors.As(err, &dsaInvalidPlanError) {
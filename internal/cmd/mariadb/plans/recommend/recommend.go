@@ -0,0 +1,115 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/recommend"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mariadbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	*recommend.Input
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Recommends MariaDB service plans matching the given resources",
+		Long: fmt.Sprintf("%s\n%s",
+			"Recommends MariaDB service plans which have at least the given resources.",
+			"The plans are sorted by their size, the smallest matching plan is listed first.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Recommend MariaDB service plans with at least 2 CPUs and 4 GB RAM`,
+				"$ stackit mariadb plans recommend --cpu 2 --ram 4"),
+			examples.NewExample(
+				`Recommend MariaDB service plans of version 10.11 with at least 4 CPUs, 16 GB RAM and 100 GB storage`,
+				"$ stackit mariadb plans recommend --cpu 4 --ram 16 --storage 100 --version 10.11"),
+			examples.NewExample(
+				`Get the best matching MariaDB service plan in JSON format`,
+				"$ stackit mariadb plans recommend --cpu 2 --ram 4 --limit 1 --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("get MariaDB service plans: %w", err)
+			}
+
+			plans := recommend.RecommendPlans(mariadbUtils.GetPlanCandidates(utils.PtrString(model.Version), resp), model.Input)
+			if len(plans) == 0 {
+				params.Printer.Info("No plans found matching the given resources\n")
+				return nil
+			}
+
+			return recommend.OutputPlans(params.Printer, model.OutputFormat, plans)
+		},
+	}
+
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	recommend.ConfigureFlags(cmd, "Minimum storage size (in GB)", "MariaDB version. If not set, plans of all versions are considered")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	input, err := recommend.ParseInput(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Input:           input,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *mariadb.APIClient) mariadb.ApiListOfferingsRequest {
+	req := apiClient.ListOfferings(ctx, model.ProjectId)
+	return req
+}
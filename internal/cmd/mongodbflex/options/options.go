@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/options/recommend"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 
 	"github.com/goccy/go-yaml"
@@ -87,9 +88,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		},
 	}
 	configureFlags(cmd)
+	addSubcommands(cmd, params)
 	return cmd
 }
 
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(recommend.NewCmd(params))
+}
+
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(flavorsFlag, false, "Lists supported flavors")
 	cmd.Flags().Bool(versionsFlag, false, "Lists supported versions")
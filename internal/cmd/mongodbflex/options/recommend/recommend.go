@@ -0,0 +1,165 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/recommend"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	*recommend.Input
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Recommends MongoDB Flex flavors matching the given resources",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Recommends MongoDB Flex flavors which have at least the given resources.",
+			"The flavors are sorted by their size, the smallest matching flavor is listed first.",
+			"If a storage size is given, only flavors supporting it are listed. If a version is given, it is checked to be available.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Recommend MongoDB Flex flavors with at least 2 CPUs and 4 GB RAM`,
+				"$ stackit mongodbflex options recommend --cpu 2 --ram 4"),
+			examples.NewExample(
+				`Recommend MongoDB Flex flavors for version 7.0 with at least 4 CPUs, 16 GB RAM and support for 100 GB storage`,
+				"$ stackit mongodbflex options recommend --cpu 4 --ram 16 --storage 100 --version 7.0"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			flavors, err := getRecommendedFlavors(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+			if len(flavors) == 0 {
+				params.Printer.Info("No flavors found matching the given resources\n")
+				return nil
+			}
+
+			return recommend.OutputFlavors(params.Printer, model.OutputFormat, flavors)
+		},
+	}
+
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	recommend.ConfigureFlags(cmd, "Storage size (in GB) which must be supported by the flavor", "MongoDB version which must be available")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	input, err := recommend.ParseInput(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Input:           input,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+type mongoDBFlexOptionsClient interface {
+	ListFlavorsExecute(ctx context.Context, projectId string) (*mongodbflex.ListFlavorsResponse, error)
+	ListVersionsExecute(ctx context.Context, projectId string) (*mongodbflex.ListVersionsResponse, error)
+	ListStoragesExecute(ctx context.Context, projectId, flavorId string) (*mongodbflex.ListStoragesResponse, error)
+}
+
+// flavorClient converts the MongoDB Flex options to recommendation candidates
+type flavorClient struct {
+	apiClient mongoDBFlexOptionsClient
+	projectId string
+}
+
+func (c *flavorClient) ListVersions(ctx context.Context) ([]string, error) {
+	versions, err := c.apiClient.ListVersionsExecute(ctx, c.projectId)
+	if err != nil {
+		return nil, fmt.Errorf("get MongoDB Flex versions: %w", err)
+	}
+	return utils.PtrValue(versions.Versions), nil
+}
+
+func (c *flavorClient) ListFlavors(ctx context.Context) ([]recommend.Candidate, error) {
+	flavors, err := c.apiClient.ListFlavorsExecute(ctx, c.projectId)
+	if err != nil {
+		return nil, fmt.Errorf("get MongoDB Flex flavors: %w", err)
+	}
+	candidates := []recommend.Candidate{}
+	for _, flavor := range utils.PtrValue(flavors.Flavors) {
+		if flavor.Id == nil {
+			continue
+		}
+		candidates = append(candidates, recommend.Candidate{
+			Id:          *flavor.Id,
+			Description: utils.PtrString(flavor.Description),
+			CPU:         utils.PtrValue(flavor.Cpu),
+			RAM:         utils.PtrValue(flavor.Memory),
+		})
+	}
+	return candidates, nil
+}
+
+func (c *flavorClient) GetStorageRange(ctx context.Context, flavorId string) (*recommend.StorageRange, error) {
+	storages, err := c.apiClient.ListStoragesExecute(ctx, c.projectId, flavorId)
+	if err != nil {
+		return nil, fmt.Errorf("get MongoDB Flex storages: %w", err)
+	}
+	if storages.StorageRange == nil {
+		return nil, nil
+	}
+	return &recommend.StorageRange{
+		Min: utils.PtrValue(storages.StorageRange.Min),
+		Max: utils.PtrValue(storages.StorageRange.Max),
+	}, nil
+}
+
+func getRecommendedFlavors(ctx context.Context, model *inputModel, apiClient mongoDBFlexOptionsClient) ([]recommend.Candidate, error) {
+	return recommend.RecommendFlavors(ctx, model.Input, &flavorClient{
+		apiClient: apiClient,
+		projectId: model.ProjectId,
+	})
+}
@@ -0,0 +1,307 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/recommend"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testProjectId = uuid.NewString()
+var testRegion = "eu01"
+
+type mongoDBFlexClientMocked struct {
+	listFlavorsFails  bool
+	listVersionsFails bool
+	listStoragesFails bool
+
+	// Maximum storage size per flavor ID
+	maxStorages map[string]int64
+}
+
+func (c *mongoDBFlexClientMocked) ListFlavorsExecute(_ context.Context, _ string) (*mongodbflex.ListFlavorsResponse, error) {
+	if c.listFlavorsFails {
+		return nil, fmt.Errorf("list flavors failed")
+	}
+	return &mongodbflex.ListFlavorsResponse{
+		Flavors: &[]mongodbflex.HandlersInfraFlavor{
+			{Id: utils.Ptr("large"), Cpu: utils.Ptr(int64(8)), Memory: utils.Ptr(int64(32))},
+			{Id: utils.Ptr("small"), Cpu: utils.Ptr(int64(2)), Memory: utils.Ptr(int64(4))},
+			{Id: utils.Ptr("medium"), Cpu: utils.Ptr(int64(4)), Memory: utils.Ptr(int64(16))},
+			{Cpu: utils.Ptr(int64(4)), Memory: utils.Ptr(int64(16))},
+		},
+	}, nil
+}
+
+func (c *mongoDBFlexClientMocked) ListVersionsExecute(_ context.Context, _ string) (*mongodbflex.ListVersionsResponse, error) {
+	if c.listVersionsFails {
+		return nil, fmt.Errorf("list versions failed")
+	}
+	return &mongodbflex.ListVersionsResponse{
+		Versions: &[]string{"6.0", "7.0"},
+	}, nil
+}
+
+func (c *mongoDBFlexClientMocked) ListStoragesExecute(_ context.Context, _, flavorId string) (*mongodbflex.ListStoragesResponse, error) {
+	if c.listStoragesFails {
+		return nil, fmt.Errorf("list storages failed")
+	}
+	return &mongodbflex.ListStoragesResponse{
+		StorageRange: &mongodbflex.StorageRange{
+			Min: utils.Ptr(int64(5)),
+			Max: utils.Ptr(c.maxStorages[flavorId]),
+		},
+	}, nil
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag:          testProjectId,
+		globalflags.RegionFlag: testRegion,
+		recommend.CPUFlag:      "4",
+		recommend.RAMFlag:      "16",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Input: &recommend.Input{
+			CPU: utils.Ptr(int64(4)),
+			RAM: utils.Ptr(int64(16)),
+		},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[recommend.StorageFlag] = "100"
+				flagValues[recommend.VersionFlag] = "7.0"
+				flagValues[recommend.LimitFlag] = "1"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Storage = utils.Ptr(int64(100))
+				model.Version = utils.Ptr("7.0")
+				model.Limit = utils.Ptr(int64(1))
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[projectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "ram negative",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[recommend.RAMFlag] = "-4"
+			}),
+			isValid: false,
+		},
+		{
+			description: "limit invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[recommend.LimitFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetRecommendedFlavors(t *testing.T) {
+	tests := []struct {
+		description       string
+		model             *inputModel
+		listFlavorsFails  bool
+		listVersionsFails bool
+		listStoragesFails bool
+		isValid           bool
+		expectedIds       []string
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			isValid:     true,
+			expectedIds: []string{"medium", "large"},
+		},
+		{
+			description: "limit",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Limit = utils.Ptr(int64(1))
+			}),
+			isValid:     true,
+			expectedIds: []string{"medium"},
+		},
+		{
+			description: "storage",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Storage = utils.Ptr(int64(200))
+			}),
+			isValid:     true,
+			expectedIds: []string{"large"},
+		},
+		{
+			description: "available version",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Version = utils.Ptr("7.0")
+			}),
+			isValid:     true,
+			expectedIds: []string{"medium", "large"},
+		},
+		{
+			description: "unavailable version",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Version = utils.Ptr("4.0")
+			}),
+			isValid: false,
+		},
+		{
+			description:      "list flavors fails",
+			model:            fixtureInputModel(),
+			listFlavorsFails: true,
+			isValid:          false,
+		},
+		{
+			description: "list versions fails",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Version = utils.Ptr("7.0")
+			}),
+			listVersionsFails: true,
+			isValid:           false,
+		},
+		{
+			description: "list storages fails",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Storage = utils.Ptr(int64(20))
+			}),
+			listStoragesFails: true,
+			isValid:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mongoDBFlexClientMocked{
+				listFlavorsFails:  tt.listFlavorsFails,
+				listVersionsFails: tt.listVersionsFails,
+				listStoragesFails: tt.listStoragesFails,
+				maxStorages: map[string]int64{
+					"small":  100,
+					"medium": 100,
+					"large":  500,
+				},
+			}
+
+			flavors, err := getRecommendedFlavors(testCtx, tt.model, client)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error getting recommended flavors: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			ids := []string{}
+			for _, flavor := range flavors {
+				ids = append(ids, flavor.Id)
+			}
+			diff := cmp.Diff(ids, tt.expectedIds)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
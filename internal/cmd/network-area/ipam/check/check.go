@@ -0,0 +1,158 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ipam"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+)
+
+const (
+	organizationIdFlag = "organization-id"
+	networkAreaIdFlag  = "network-area-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	OrganizationId string
+	NetworkAreaId  string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Checks the address allocations of a STACKIT Network Area (SNA)",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Checks the address allocations of a STACKIT Network Area (SNA), based on the network ranges and static routes of the area and the networks of all projects attached to it.",
+			"Networks with overlapping prefixes, IPv4 networks outside of the network ranges and static routes whose next hop is not within any network are reported.",
+			"The command fails if any issue is found.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Check the STACKIT Network Area with ID "xxx" in organization with ID "yyy"`,
+				"$ stackit network-area ipam check --network-area-id xxx --organization-id yyy"),
+			examples.NewExample(
+				`Check the STACKIT Network Area with ID "xxx" in organization with ID "yyy" and show the issues in JSON format`,
+				"$ stackit network-area ipam check --network-area-id xxx --organization-id yyy --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			s := spinner.New(params.Printer)
+			s.Start("Loading networks")
+			area, err := ipam.LoadArea(ctx, apiClient, model.OrganizationId, model.NetworkAreaId)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			issues := ipam.Check(area)
+			err = outputResult(params.Printer, model.OutputFormat, model.NetworkAreaId, issues)
+			if err != nil {
+				return err
+			}
+			if len(issues) > 0 {
+				return fmt.Errorf("found %d issues in STACKIT Network Area %q", len(issues), model.NetworkAreaId)
+			}
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), organizationIdFlag, "Organization ID")
+	cmd.Flags().Var(flags.UUIDFlag(), networkAreaIdFlag, "STACKIT Network Area ID")
+
+	err := flags.MarkFlagsRequired(cmd, organizationIdFlag, networkAreaIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		OrganizationId:  flags.FlagToStringValue(p, cmd, organizationIdFlag),
+		NetworkAreaId:   flags.FlagToStringValue(p, cmd, networkAreaIdFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func outputResult(p *print.Printer, outputFormat, networkAreaId string, issues []ipam.Issue) error {
+	if issues == nil {
+		return fmt.Errorf("issues are empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal network area issues: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(issues, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal network area issues: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		if len(issues) == 0 {
+			p.Outputf("No issues found in STACKIT Network Area %q\n", networkAreaId)
+			return nil
+		}
+
+		table := tables.NewTable()
+		table.SetHeader("TYPE", "RESOURCE", "PREFIX", "DETAILS")
+		for _, issue := range issues {
+			table.AddRow(issue.Type, issue.Resource, issue.Prefix, issue.Details)
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+		return nil
+	}
+}
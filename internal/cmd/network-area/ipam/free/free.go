@@ -0,0 +1,214 @@
+package free
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ipam"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+)
+
+const (
+	organizationIdFlag = "organization-id"
+	networkAreaIdFlag  = "network-area-id"
+	sizeFlag           = "size"
+	limitFlag          = "limit"
+
+	defaultLimit = 10
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	OrganizationId string
+	NetworkAreaId  string
+	Size           *int
+	Limit          int64
+}
+
+type freeResult struct {
+	Free       []netip.Prefix `json:"free"`
+	Candidates []netip.Prefix `json:"candidates"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "free",
+		Short: "Lists the unallocated prefixes of a STACKIT Network Area (SNA)",
+		Long: fmt.Sprintf("%s\n%s",
+			"Lists the unallocated prefixes of a STACKIT Network Area (SNA), computed from the network ranges of the area and the networks of all projects attached to it.",
+			"If a size is given, the first prefixes of that size which are free are listed instead, e.g. to pick the prefix of a new network.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List the unallocated prefixes of the STACKIT Network Area with ID "xxx" in organization with ID "yyy"`,
+				"$ stackit network-area ipam free --network-area-id xxx --organization-id yyy"),
+			examples.NewExample(
+				`List the first 3 free /24 prefixes of the STACKIT Network Area with ID "xxx" in organization with ID "yyy"`,
+				"$ stackit network-area ipam free --network-area-id xxx --organization-id yyy --size /24 --limit 3"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			s := spinner.New(params.Printer)
+			s.Start("Loading networks")
+			area, err := ipam.LoadArea(ctx, apiClient, model.OrganizationId, model.NetworkAreaId)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			result := computeFree(model, area)
+			return outputResult(params.Printer, model, result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), organizationIdFlag, "Organization ID")
+	cmd.Flags().Var(flags.UUIDFlag(), networkAreaIdFlag, "STACKIT Network Area ID")
+	cmd.Flags().String(sizeFlag, "", `Prefix length of the prefixes to list, e.g. "/24"`)
+	cmd.Flags().Int64(limitFlag, defaultLimit, "Maximum number of prefixes of the given size to list")
+
+	err := flags.MarkFlagsRequired(cmd, organizationIdFlag, networkAreaIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	var size *int
+	sizeValue := flags.FlagToStringValue(p, cmd, sizeFlag)
+	if sizeValue != "" {
+		bits, err := strconv.Atoi(strings.TrimPrefix(sizeValue, "/"))
+		if err != nil || bits < 1 || bits > 32 {
+			return nil, &errors.FlagValidationError{
+				Flag:    sizeFlag,
+				Details: `must be a prefix length between /1 and /32, e.g. "/24"`,
+			}
+		}
+		size = &bits
+	}
+
+	limit := flags.FlagWithDefaultToInt64Value(p, cmd, limitFlag)
+	if limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		OrganizationId:  flags.FlagToStringValue(p, cmd, organizationIdFlag),
+		NetworkAreaId:   flags.FlagToStringValue(p, cmd, networkAreaIdFlag),
+		Size:            size,
+		Limit:           limit,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func computeFree(model *inputModel, area *ipam.Area) *freeResult {
+	result := &freeResult{
+		Free:       ipam.Free(area.Ranges, area.Allocated()),
+		Candidates: []netip.Prefix{},
+	}
+	if model.Size != nil {
+		result.Candidates = ipam.Candidates(result.Free, *model.Size, int(model.Limit))
+	}
+	return result
+}
+
+func outputResult(p *print.Printer, model *inputModel, result *freeResult) error {
+	if model == nil || result == nil {
+		return fmt.Errorf("free prefixes are empty")
+	}
+
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal free prefixes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal free prefixes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		prefixes := result.Free
+		if model.Size != nil {
+			prefixes = result.Candidates
+		}
+		if len(prefixes) == 0 {
+			if model.Size != nil {
+				p.Info("No free /%d prefix found in STACKIT Network Area %q\n", *model.Size, model.NetworkAreaId)
+			} else {
+				p.Info("No unallocated prefixes found in STACKIT Network Area %q\n", model.NetworkAreaId)
+			}
+			return nil
+		}
+
+		table := tables.NewTable()
+		table.SetHeader("PREFIX", "FIRST ADDRESS", "LAST ADDRESS")
+		for _, prefix := range prefixes {
+			table.AddRow(prefix, prefix.Addr(), lastAddr(prefix))
+		}
+		p.Outputln(table.Render())
+		return nil
+	}
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(addr)*8; i++ {
+		addr[i/8] |= 0x80 >> (i % 8)
+	}
+	last, _ := netip.AddrFromSlice(addr)
+	return last
+}
@@ -0,0 +1,284 @@
+package free
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ipam"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+var testOrganizationId = uuid.NewString()
+var testNetworkAreaId = uuid.NewString()
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		organizationIdFlag: testOrganizationId,
+		networkAreaIdFlag:  testNetworkAreaId,
+		sizeFlag:           "/24",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		OrganizationId: testOrganizationId,
+		NetworkAreaId:  testNetworkAreaId,
+		Size:           utils.Ptr(24),
+		Limit:          defaultLimit,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "size without slash and limit",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sizeFlag] = "26"
+				flagValues[limitFlag] = "3"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Size = utils.Ptr(26)
+				model.Limit = 3
+			}),
+		},
+		{
+			description: "no size",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, sizeFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Size = nil
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "organization id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, organizationIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "network area id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[networkAreaIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "size invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sizeFlag] = "/33"
+			}),
+			isValid: false,
+		},
+		{
+			description: "size not a number",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sizeFlag] = "large"
+			}),
+			isValid: false,
+		},
+		{
+			description: "limit invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[limitFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeFree(t *testing.T) {
+	area := &ipam.Area{
+		Ranges: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/22")},
+		Networks: []ipam.Network{
+			{NetworkId: "net", Prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.1.0/24")}},
+		},
+	}
+	expected := &freeResult{
+		Free: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("10.0.2.0/23")},
+		Candidates: []netip.Prefix{
+			netip.MustParsePrefix("10.0.0.0/24"),
+			netip.MustParsePrefix("10.0.2.0/24"),
+		},
+	}
+
+	result := computeFree(fixtureInputModel(func(model *inputModel) { model.Limit = 2 }), area)
+	diff := cmp.Diff(result, expected, cmpopts.EquateComparable(netip.Prefix{}))
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestLastAddr(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{"10.0.0.0/24", "10.0.0.255"},
+		{"10.0.0.0/23", "10.0.1.255"},
+		{"10.0.0.0/8", "10.255.255.255"},
+		{"10.0.0.1/32", "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			last := lastAddr(netip.MustParsePrefix(tt.prefix))
+			if last.String() != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, last)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testResult := &freeResult{
+		Free:       []netip.Prefix{netip.MustParsePrefix("10.0.0.0/23")},
+		Candidates: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("10.0.1.0/24")},
+	}
+
+	type args struct {
+		model  *inputModel
+		result *freeResult
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "empty result",
+			args: args{
+				model:  fixtureInputModel(),
+				result: &freeResult{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "candidates",
+			args: args{
+				model:  fixtureInputModel(),
+				result: testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "free prefixes",
+			args: args{
+				model:  fixtureInputModel(func(model *inputModel) { model.Size = nil }),
+				result: testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				model:  fixtureInputModel(func(model *inputModel) { model.OutputFormat = print.JSONOutputFormat }),
+				result: testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				model:  fixtureInputModel(func(model *inputModel) { model.OutputFormat = print.YAMLOutputFormat }),
+				result: testResult,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, tt.args.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
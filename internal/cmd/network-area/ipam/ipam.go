@@ -0,0 +1,28 @@
+package ipam
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/ipam/check"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/ipam/free"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ipam",
+		Short: "Provides functionality for IP address management in STACKIT Network Areas",
+		Long:  "Provides functionality for IP address management in STACKIT Network Areas, based on the network ranges of the area and the networks of all attached projects.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(check.NewCmd(params))
+	cmd.AddCommand(free.NewCmd(params))
+}
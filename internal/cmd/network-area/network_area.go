@@ -4,6 +4,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/ipam"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/list"
 	networkrange "github.com/stackitcloud/stackit-cli/internal/cmd/network-area/network-range"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network-area/route"
@@ -31,6 +32,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(create.NewCmd(params))
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(describe.NewCmd(params))
+	cmd.AddCommand(ipam.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(networkrange.NewCmd(params))
 	cmd.AddCommand(route.NewCmd(params))
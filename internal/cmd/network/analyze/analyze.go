@@ -0,0 +1,285 @@
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/reachability"
+	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	fromFlag = "from"
+	toFlag   = "to"
+	portFlag = "port"
+
+	serverEndpointType = "server"
+	ipEndpointType     = "ip"
+)
+
+type endpointRef struct {
+	Type  string
+	Value string
+}
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	From    endpointRef
+	To      endpointRef
+	Traffic reachability.Traffic
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyzes whether security groups allow traffic between two endpoints",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Analyzes whether the security groups allow traffic between two endpoints, and which rule allows it.",
+			"The egress rules of the source NICs and the ingress rules of the destination NICs are evaluated, including IP ranges and remote security groups. Endpoints are given as \"server:<SERVER_ID>\" or \"ip:<IP_ADDRESS>\" for addresses outside of STACKIT.",
+			"The traffic is given as \"<PORT>/<PROTOCOL>\", e.g. \"5432/tcp\", or as protocol without port, e.g. \"icmp\". ICMP traffic is evaluated as echo request.",
+			"Only the initial traffic is evaluated, answers are allowed by stateful security groups. Routing between networks is not evaluated.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Analyze whether the server with ID "xxx" can connect to port 5432 of the server with ID "yyy"`,
+				"$ stackit network analyze --from server:xxx --to server:yyy --port 5432/tcp"),
+			examples.NewExample(
+				`Analyze whether the server with ID "xxx" can be pinged from the IP address 203.0.113.10`,
+				"$ stackit network analyze --from ip:203.0.113.10 --to server:xxx --port icmp"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			from, err := loadEndpoint(ctx, params.Printer, apiClient, model.ProjectId, model.From)
+			if err != nil {
+				return err
+			}
+			to, err := loadEndpoint(ctx, params.Printer, apiClient, model.ProjectId, model.To)
+			if err != nil {
+				return err
+			}
+			securityGroups, err := loadSecurityGroups(ctx, apiClient, model.ProjectId, from, to)
+			if err != nil {
+				return err
+			}
+
+			result := reachability.Analyze(*from, *to, model.Traffic, securityGroups)
+			return outputResult(params.Printer, model.OutputFormat, &result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(fromFlag, "", `Source of the traffic, as "server:<SERVER_ID>" or "ip:<IP_ADDRESS>"`)
+	cmd.Flags().String(toFlag, "", `Destination of the traffic, as "server:<SERVER_ID>" or "ip:<IP_ADDRESS>"`)
+	cmd.Flags().String(portFlag, "", `Traffic to analyze, as "<PORT>/<PROTOCOL>" (e.g. "5432/tcp") or "<PROTOCOL>" (e.g. "icmp")`)
+
+	err := flags.MarkFlagsRequired(cmd, fromFlag, toFlag, portFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	from, err := parseEndpointRef(flags.FlagToStringValue(p, cmd, fromFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    fromFlag,
+			Details: err.Error(),
+		}
+	}
+	to, err := parseEndpointRef(flags.FlagToStringValue(p, cmd, toFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    toFlag,
+			Details: err.Error(),
+		}
+	}
+	if from.Type == ipEndpointType && to.Type == ipEndpointType {
+		return nil, &errors.FlagValidationError{
+			Flag:    toFlag,
+			Details: "at least one endpoint must be a server",
+		}
+	}
+	traffic, err := reachability.ParseTraffic(flags.FlagToStringValue(p, cmd, portFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    portFlag,
+			Details: err.Error(),
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		From:            from,
+		To:              to,
+		Traffic:         traffic,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func parseEndpointRef(value string) (endpointRef, error) {
+	endpointType, id, found := strings.Cut(value, ":")
+	if !found || id == "" {
+		return endpointRef{}, fmt.Errorf(`must have the format "server:<SERVER_ID>" or "ip:<IP_ADDRESS>"`)
+	}
+	switch endpointType {
+	case serverEndpointType:
+		err := utils.ValidateUUID(id)
+		if err != nil {
+			return endpointRef{}, err
+		}
+	case ipEndpointType:
+		_, err := netip.ParseAddr(id)
+		if err != nil {
+			return endpointRef{}, fmt.Errorf("invalid IP address %q", id)
+		}
+	default:
+		return endpointRef{}, fmt.Errorf("endpoint type %q is not supported, must be %q or %q", endpointType, serverEndpointType, ipEndpointType)
+	}
+	return endpointRef{Type: endpointType, Value: id}, nil
+}
+
+func loadEndpoint(ctx context.Context, p *print.Printer, apiClient *iaas.APIClient, projectId string, ref endpointRef) (*reachability.Endpoint, error) {
+	if ref.Type == ipEndpointType {
+		return &reachability.Endpoint{
+			Label:      ref.Value,
+			Interfaces: []reachability.Interface{{Ip: netip.MustParseAddr(ref.Value), External: true}},
+		}, nil
+	}
+
+	label, err := iaasUtils.GetServerName(ctx, apiClient, projectId, ref.Value)
+	if err != nil {
+		p.Debug(print.ErrorLevel, "get server name: %v", err)
+		label = ref.Value
+	}
+	resp, err := apiClient.ListServerNicsExecute(ctx, projectId, ref.Value)
+	if err != nil {
+		return nil, fmt.Errorf("list NICs of server %q: %w", label, err)
+	}
+	interfaces := reachability.InterfacesFromNICs(utils.PtrValue(resp.Items))
+	if len(interfaces) == 0 {
+		return nil, fmt.Errorf("server %q has no NICs with IP addresses", label)
+	}
+	return &reachability.Endpoint{
+		Label:      label,
+		Interfaces: interfaces,
+	}, nil
+}
+
+func loadSecurityGroups(ctx context.Context, apiClient *iaas.APIClient, projectId string, endpoints ...*reachability.Endpoint) (map[string]iaas.SecurityGroup, error) {
+	securityGroups := map[string]iaas.SecurityGroup{}
+	for _, endpoint := range endpoints {
+		for _, iface := range endpoint.Interfaces {
+			for _, id := range iface.SecurityGroupIds {
+				if _, ok := securityGroups[id]; ok {
+					continue
+				}
+				securityGroup, err := apiClient.GetSecurityGroupExecute(ctx, projectId, id)
+				if err != nil {
+					return nil, fmt.Errorf("get security group %q: %w", id, err)
+				}
+				securityGroups[id] = *securityGroup
+			}
+		}
+	}
+	return securityGroups, nil
+}
+
+func outputResult(p *print.Printer, outputFormat string, result *reachability.Result) error {
+	if result == nil {
+		return fmt.Errorf("analysis result is empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal analysis result: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal analysis result: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("FROM", "TO", "EGRESS", "INGRESS", "RESULT")
+		for i := range result.Paths {
+			path := result.Paths[i]
+			verdict := "blocked"
+			if path.Allowed {
+				verdict = "allowed"
+			}
+			if path.Details != "" {
+				verdict = fmt.Sprintf("%s (%s)", verdict, path.Details)
+			}
+			table.AddRow(path.FromIp, path.ToIp, path.Egress.Reason, path.Ingress.Reason, verdict)
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		switch {
+		case len(result.Paths) == 0:
+			p.Outputf("%q and %q have no IP addresses of the same IP version\n", result.From, result.To)
+		case result.Allowed:
+			p.Outputf("Traffic %s from %q to %q is allowed by the security groups\n", result.Traffic, result.From, result.To)
+		default:
+			p.Outputf("Traffic %s from %q to %q is blocked by the security groups\n", result.Traffic, result.From, result.To)
+		}
+		return nil
+	}
+}
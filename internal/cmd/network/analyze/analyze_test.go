@@ -0,0 +1,337 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/reachability"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testFromServerId = uuid.NewString()
+var testToServerId = uuid.NewString()
+
+func fixtureTraffic(t *testing.T, value string) reachability.Traffic {
+	t.Helper()
+	traffic, err := reachability.ParseTraffic(value)
+	if err != nil {
+		t.Fatalf("parse traffic: %v", err)
+	}
+	return traffic
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag: testProjectId,
+		fromFlag:      "server:" + testFromServerId,
+		toFlag:        "server:" + testToServerId,
+		portFlag:      "5432/tcp",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(t *testing.T, mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		From:    endpointRef{Type: serverEndpointType, Value: testFromServerId},
+		To:      endpointRef{Type: serverEndpointType, Value: testToServerId},
+		Traffic: fixtureTraffic(t, "5432/tcp"),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(t),
+		},
+		{
+			description: "ip source and icmp",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fromFlag] = "ip:203.0.113.10"
+				flagValues[portFlag] = "icmp"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(t, func(model *inputModel) {
+				model.From = endpointRef{Type: ipEndpointType, Value: "203.0.113.10"}
+				model.Traffic = fixtureTraffic(t, "icmp")
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "from missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, fromFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "from without type",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fromFlag] = testFromServerId
+			}),
+			isValid: false,
+		},
+		{
+			description: "unsupported endpoint type",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fromFlag] = "nic:" + testFromServerId
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid server id",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toFlag] = "server:invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid ip",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toFlag] = "ip:10.0.0"
+			}),
+			isValid: false,
+		},
+		{
+			description: "only ip endpoints",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fromFlag] = "ip:10.0.0.1"
+				flagValues[toFlag] = "ip:10.0.0.2"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid port",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[portFlag] = "tcp"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel, cmp.AllowUnexported(reachability.Traffic{}))
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	securityGroupId := uuid.NewString()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/projects/%s/servers/%s", testProjectId, testFromServerId):
+			_, _ = fmt.Fprint(w, `{"id": "from", "name": "web"}`)
+		case fmt.Sprintf("/v1/projects/%s/servers/%s/nics", testProjectId, testFromServerId):
+			_, _ = fmt.Fprintf(w, `{"items": [{"id": "nic", "networkId": "net", "ipv4": "10.0.0.5", "nicSecurity": true, "securityGroups": [%q]}]}`, securityGroupId)
+		case fmt.Sprintf("/v1/projects/%s/security-groups/%s", testProjectId, securityGroupId):
+			_, _ = fmt.Fprintf(w, `{"id": %q, "name": "web", "rules": [{"id": "egress", "direction": "egress", "ethertype": "IPv4"}]}`, securityGroupId)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+	ctx := context.Background()
+	p := print.NewPrinter()
+
+	from, err := loadEndpoint(ctx, p, apiClient, testProjectId, endpointRef{Type: serverEndpointType, Value: testFromServerId})
+	if err != nil {
+		t.Fatalf("failed to load endpoint: %v", err)
+	}
+	expectedFrom := &reachability.Endpoint{
+		Label: "web",
+		Interfaces: []reachability.Interface{
+			{NicId: "nic", NetworkId: "net", Ip: netip.MustParseAddr("10.0.0.5"), SecurityGroupIds: []string{securityGroupId}, NicSecurity: true},
+		},
+	}
+	diff := cmp.Diff(from, expectedFrom, cmpopts.EquateComparable(netip.Addr{}))
+	if diff != "" {
+		t.Fatalf("Endpoint does not match: %s", diff)
+	}
+
+	to, err := loadEndpoint(ctx, p, apiClient, testProjectId, endpointRef{Type: ipEndpointType, Value: "203.0.113.10"})
+	if err != nil {
+		t.Fatalf("failed to load endpoint: %v", err)
+	}
+	if !to.Interfaces[0].External {
+		t.Fatalf("expected IP endpoint to be external")
+	}
+
+	securityGroups, err := loadSecurityGroups(ctx, apiClient, testProjectId, from, from, to)
+	if err != nil {
+		t.Fatalf("failed to load security groups: %v", err)
+	}
+	if len(securityGroups) != 1 {
+		t.Fatalf("expected 1 security group, got %d", len(securityGroups))
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+
+	_, err = loadEndpoint(ctx, p, apiClient, testProjectId, endpointRef{Type: serverEndpointType, Value: testToServerId})
+	if err == nil {
+		t.Fatalf("expected error for server without NICs")
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testResult := &reachability.Result{
+		From:    "web",
+		To:      "db",
+		Traffic: fixtureTraffic(t, "5432/tcp"),
+		Paths: []reachability.Path{
+			{
+				FromIp:  netip.MustParseAddr("10.0.0.5"),
+				ToIp:    netip.MustParseAddr("10.0.1.5"),
+				Egress:  reachability.Verdict{Allowed: true, Reason: "allowed"},
+				Ingress: reachability.Verdict{Reason: "no rule"},
+				Details: "different networks",
+			},
+		},
+	}
+
+	type args struct {
+		outputFormat string
+		result       *reachability.Result
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "empty result",
+			args: args{
+				result: &reachability.Result{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "result",
+			args: args{
+				result: testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				result:       testResult,
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				result:       testResult,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
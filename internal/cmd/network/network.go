@@ -1,6 +1,7 @@
 package network
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/network/analyze"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/network/describe"
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(analyze.NewCmd(params))
 }
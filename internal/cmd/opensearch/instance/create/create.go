@@ -39,12 +39,16 @@ const (
 	planIdFlag               = "plan-id"
 	planNameFlag             = "plan-name"
 	versionFlag              = "version"
+	cpuFlag                  = "cpu"
+	ramFlag                  = "ram"
 )
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 	PlanName string
 	Version  string
+	CPU      *int64
+	RAM      *int64
 
 	InstanceName         *string
 	EnableMonitoring     *bool
@@ -68,6 +72,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create an OpenSearch instance with name "my-instance" and specify plan by name and version`,
 				"$ stackit opensearch instance create --name my-instance --plan-name stackit-opensearch-1.2.10-replica --version 2"),
+			examples.NewExample(
+				`Create an OpenSearch instance with name "my-instance" and use the smallest plan of version 2 with at least 2 CPUs and 4 GB RAM`,
+				"$ stackit opensearch instance create --name my-instance --cpu 2 --ram 4 --version 2"),
 			examples.NewExample(
 				`Create an OpenSearch instance with name "my-instance" and specify plan by ID`,
 				"$ stackit opensearch instance create --name my-instance --plan-id xxx"),
@@ -148,6 +155,8 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), planIdFlag, "Plan ID")
 	cmd.Flags().String(planNameFlag, "", "Plan name")
 	cmd.Flags().String(versionFlag, "", "Instance OpenSearch version")
+	cmd.Flags().Int64(cpuFlag, 0, "Minimum number of CPUs of the plan. Can be used together with --ram and --version instead of --plan-name")
+	cmd.Flags().Int64(ramFlag, 0, "Minimum amount of RAM (in GB) of the plan. Can be used together with --cpu and --version instead of --plan-name")
 
 	err := flags.MarkFlagsRequired(cmd, instanceNameFlag)
 	cobra.CheckErr(err)
@@ -162,14 +171,22 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	planId := flags.FlagToStringPointer(p, cmd, planIdFlag)
 	planName := flags.FlagToStringValue(p, cmd, planNameFlag)
 	version := flags.FlagToStringValue(p, cmd, versionFlag)
+	cpu := flags.FlagToInt64Pointer(p, cmd, cpuFlag)
+	ram := flags.FlagToInt64Pointer(p, cmd, ramFlag)
+	hasResources := cpu != nil || ram != nil
 
-	if planId == nil && (planName == "" || version == "") {
-		return nil, &cliErr.DSAInputPlanError{
+	if planId == nil && ((planName == "" && !hasResources) || version == "") {
+		return nil, &cliErr.DSACreateInputPlanError{
+			Cmd: cmd,
+		}
+	}
+	if planId != nil && (planName != "" || version != "" || hasResources) {
+		return nil, &cliErr.DSACreateInputPlanError{
 			Cmd: cmd,
 		}
 	}
-	if planId != nil && (planName != "" || version != "") {
-		return nil, &cliErr.DSAInputPlanError{
+	if planName != "" && hasResources {
+		return nil, &cliErr.DSACreateInputPlanError{
 			Cmd: cmd,
 		}
 	}
@@ -188,6 +205,8 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		PlanId:               planId,
 		PlanName:             planName,
 		Version:              version,
+		CPU:                  cpu,
+		RAM:                  ram,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -219,7 +238,11 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient openSearchCl
 	}
 
 	if model.PlanId == nil {
-		planId, err = opensearchUtils.LoadPlanId(model.PlanName, model.Version, offerings)
+		if model.PlanName != "" {
+			planId, err = opensearchUtils.LoadPlanId(model.PlanName, model.Version, offerings)
+		} else {
+			planId, err = opensearchUtils.LoadPlanIdFromResources(model.CPU, model.RAM, model.Version, offerings)
+		}
 		if err != nil {
 			var dsaInvalidPlanError *cliErr.DSAInvalidPlanError
 			if !errors.As(err, &dsaInvalidPlanError) {
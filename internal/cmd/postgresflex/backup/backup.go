@@ -4,6 +4,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/backup/describe"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/backup/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/backup/restore"
 	updateschedule "github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/backup/update-schedule"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -27,4 +28,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(updateschedule.NewCmd(params))
+	cmd.AddCommand(restore.NewCmd(params))
 }
@@ -0,0 +1,206 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	postgresflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+)
+
+const (
+	backupIdArg = "BACKUP_ID"
+
+	instanceIdFlag   = "instance-id"
+	storageClassFlag = "storage-class"
+	storageSizeFlag  = "storage-size"
+
+	recoveryDateFormat = "2006-01-02T15:04:05-07:00"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	InstanceId   string
+	BackupId     string
+	StorageClass *string
+	StorageSize  *int64
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("restore %s", backupIdArg),
+		Short: "Restores a backup of a PostgreSQL Flex instance to a new instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Restores a backup of a PostgreSQL Flex instance to a new instance, by cloning the instance from the point in time at which the backup finished, like \"stackit postgresflex instance clone --recovery-timestamp\" does.",
+			"The new instance will be an independent instance with the same settings as the original instance unless the flags are specified.",
+			"The API doesn't support restoring a backup in place or into another existing instance, so the original instance is left unchanged.",
+		),
+		Example: examples.Build(
+			examples.NewExample(
+				`Restore the backup with ID "xxx" of the PostgreSQL Flex instance with ID "yyy" to a new instance`,
+				"$ stackit postgresflex backup restore xxx --instance-id yyy"),
+			examples.NewExample(
+				`Restore the backup with ID "xxx" of the PostgreSQL Flex instance with ID "yyy" to a new instance with a different storage class and size`,
+				"$ stackit postgresflex backup restore xxx --instance-id yyy --storage-class premium-perf6-stackit --storage-size 20"),
+		),
+		Args: args.SingleArg(backupIdArg, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := postgresflexUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.Region, model.InstanceId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			backup, err := apiClient.GetBackupExecute(ctx, model.ProjectId, model.Region, model.InstanceId, model.BackupId)
+			if err != nil {
+				return fmt.Errorf("get backup of PostgreSQL Flex instance: %w", err)
+			}
+			recoveryTimestamp, err := getRecoveryTimestamp(backup.Item)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to restore backup %q of instance %q to a new instance?", model.BackupId, instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req, err := buildRequest(ctx, model, apiClient, recoveryTimestamp)
+			if err != nil {
+				return err
+			}
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("restore backup of PostgreSQL Flex instance: %w", err)
+			}
+			instanceId := utils.PtrString(resp.InstanceId)
+
+			// Wait for async operation, if async mode not enabled
+			if !model.Async {
+				err = postgresflexUtils.WaitForClonedInstance(ctx, params.Printer, apiClient, model.ProjectId, model.Region, instanceId)
+				if err != nil {
+					return err
+				}
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, model.Async, model.BackupId, instanceLabel, resp)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance the backup belongs to")
+	cmd.Flags().String(storageClassFlag, "", "Storage class of the new instance. If not specified, storage class from the existing instance will be used.")
+	cmd.Flags().Int64(storageSizeFlag, 0, "Storage size of the new instance (in GB). If not specified, storage size from the existing instance will be used.")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	backupId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		BackupId:        backupId,
+		StorageClass:    flags.FlagToStringPointer(p, cmd, storageClassFlag),
+		StorageSize:     flags.FlagToInt64Pointer(p, cmd, storageSizeFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// getRecoveryTimestamp returns the point in time at which the backup finished, in the format expected by the clone API
+func getRecoveryTimestamp(backup *postgresflex.Backup) (string, error) {
+	if backup == nil || backup.EndTime == nil || *backup.EndTime == "" {
+		return "", fmt.Errorf("backup end time not defined, the backup may not be finished yet")
+	}
+	endTime, err := time.Parse(time.RFC3339, *backup.EndTime)
+	if err != nil {
+		return "", fmt.Errorf("parse backup end time: %w", err)
+	}
+	return endTime.Format(recoveryDateFormat), nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient postgresflexUtils.PostgresFlexCloneClient, recoveryTimestamp string) (postgresflex.ApiCloneInstanceRequest, error) {
+	return postgresflexUtils.BuildCloneInstanceRequest(ctx, apiClient, model.ProjectId, model.Region, model.InstanceId, model.StorageClass, model.StorageSize, recoveryTimestamp)
+}
+
+func outputResult(p *print.Printer, outputFormat string, async bool, backupId, instanceLabel string, resp *postgresflex.CloneInstanceResponse) error {
+	if resp == nil {
+		return fmt.Errorf("response not set")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal PostgreSQL Flex backup restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resp, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal PostgreSQL Flex backup restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		operationState := "Restored"
+		if async {
+			operationState = "Triggered restore of"
+		}
+		p.Outputf("%s backup %q of instance %q. New Instance ID: %s\n", operationState, backupId, instanceLabel, utils.PtrString(resp.InstanceId))
+		return nil
+	}
+}
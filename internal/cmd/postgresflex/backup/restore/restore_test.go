@@ -0,0 +1,440 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &postgresflex.APIClient{}
+
+type postgresFlexClientMocked struct {
+	listStoragesFails bool
+	listStoragesResp  *postgresflex.ListStoragesResponse
+	getInstanceFails  bool
+	getInstanceResp   *postgresflex.InstanceResponse
+}
+
+func (c *postgresFlexClientMocked) CloneInstance(ctx context.Context, projectId, region, instanceId string) postgresflex.ApiCloneInstanceRequest {
+	return testClient.CloneInstance(ctx, projectId, region, instanceId)
+}
+
+func (c *postgresFlexClientMocked) GetInstanceExecute(_ context.Context, _, _, _ string) (*postgresflex.InstanceResponse, error) {
+	if c.getInstanceFails {
+		return nil, fmt.Errorf("get instance failed")
+	}
+	return c.getInstanceResp, nil
+}
+
+func (c *postgresFlexClientMocked) ListStoragesExecute(_ context.Context, _, _, _ string) (*postgresflex.ListStoragesResponse, error) {
+	if c.listStoragesFails {
+		return nil, fmt.Errorf("list storages failed")
+	}
+	return c.listStoragesResp, nil
+}
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testBackupId = "backup-id"
+var testRecoveryTimestamp = "2024-03-08T09:28:00+00:00"
+var testFlavorId = uuid.NewString()
+var testStorageClass = "premium-perf4-stackit"
+var testStorageSize = int64(10)
+var testRegion = "eu01"
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testBackupId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		instanceIdFlag:            testInstanceId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		BackupId:   testBackupId,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureRequest(mods ...func(request *postgresflex.ApiCloneInstanceRequest)) postgresflex.ApiCloneInstanceRequest {
+	request := testClient.CloneInstance(testCtx, testProjectId, testRegion, testInstanceId)
+	request = request.CloneInstancePayload(postgresflex.CloneInstancePayload{
+		Timestamp: utils.Ptr(testRecoveryTimestamp),
+	})
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func fixtureInstanceResponse() *postgresflex.InstanceResponse {
+	return &postgresflex.InstanceResponse{
+		Item: &postgresflex.Instance{
+			Flavor: &postgresflex.Flavor{
+				Id: utils.Ptr(testFlavorId),
+			},
+			Storage: &postgresflex.Storage{
+				Class: utils.Ptr(testStorageClass),
+				Size:  utils.Ptr(testStorageSize),
+			},
+		},
+	}
+}
+
+func fixtureStoragesResponse() *postgresflex.ListStoragesResponse {
+	return &postgresflex.ListStoragesResponse{
+		StorageClasses: &[]string{"class", testStorageClass},
+		StorageRange: &postgresflex.StorageRange{
+			Min: utils.Ptr(int64(10)),
+			Max: utils.Ptr(int64(100)),
+		},
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "with storage",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[storageClassFlag] = "class"
+				flagValues[storageSizeFlag] = "20"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("class")
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "no flag values",
+			argValues:   fixtureArgValues(),
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, instanceIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetRecoveryTimestamp(t *testing.T) {
+	tests := []struct {
+		description string
+		backup      *postgresflex.Backup
+		isValid     bool
+		expected    string
+	}{
+		{
+			description: "base",
+			backup:      &postgresflex.Backup{EndTime: utils.Ptr("2024-03-08T09:28:00Z")},
+			isValid:     true,
+			expected:    testRecoveryTimestamp,
+		},
+		{
+			description: "with offset",
+			backup:      &postgresflex.Backup{EndTime: utils.Ptr("2024-03-08T10:28:00+01:00")},
+			isValid:     true,
+			expected:    "2024-03-08T10:28:00+01:00",
+		},
+		{
+			description: "nil backup",
+			backup:      nil,
+			isValid:     false,
+		},
+		{
+			description: "end time not set",
+			backup:      &postgresflex.Backup{},
+			isValid:     false,
+		},
+		{
+			description: "end time invalid",
+			backup:      &postgresflex.Backup{EndTime: utils.Ptr("yesterday")},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			timestamp, err := getRecoveryTimestamp(tt.backup)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error getting recovery timestamp: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if timestamp != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, timestamp)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description       string
+		model             *inputModel
+		expectedRequest   postgresflex.ApiCloneInstanceRequest
+		getInstanceFails  bool
+		listStoragesFails bool
+		isValid           bool
+	}{
+		{
+			description:     "base",
+			model:           fixtureInputModel(),
+			isValid:         true,
+			expectedRequest: fixtureRequest(),
+		},
+		{
+			description: "specify storage class and size",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("class")
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+			isValid: true,
+			expectedRequest: fixtureRequest().CloneInstancePayload(postgresflex.CloneInstancePayload{
+				Class:     utils.Ptr("class"),
+				Size:      utils.Ptr(int64(20)),
+				Timestamp: utils.Ptr(testRecoveryTimestamp),
+			}),
+		},
+		{
+			description: "get instance fails",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("class")
+			}),
+			getInstanceFails: true,
+			isValid:          false,
+		},
+		{
+			description: "list storages fails",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+			listStoragesFails: true,
+			isValid:           false,
+		},
+		{
+			description: "invalid storage class",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("non-existing-class")
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid storage size",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageSize = utils.Ptr(int64(9))
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &postgresFlexClientMocked{
+				getInstanceFails:  tt.getInstanceFails,
+				getInstanceResp:   fixtureInstanceResponse(),
+				listStoragesFails: tt.listStoragesFails,
+				listStoragesResp:  fixtureStoragesResponse(),
+			}
+			request, err := buildRequest(testCtx, tt.model, client, testRecoveryTimestamp)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat  string
+		async         bool
+		backupId      string
+		instanceLabel string
+		resp          *postgresflex.CloneInstanceResponse
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "standard",
+			args: args{
+				backupId:      testBackupId,
+				instanceLabel: "foo",
+				resp:          &postgresflex.CloneInstanceResponse{InstanceId: utils.Ptr("id")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "async",
+			args: args{
+				async: true,
+				resp:  &postgresflex.CloneInstanceResponse{},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.async, tt.args.backupId, tt.args.instanceLabel, tt.args.resp); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,12 +15,10 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
 	postgresflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/utils"
-	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
-	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex/wait"
 )
 
 const (
@@ -100,13 +98,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 			// Wait for async operation, if async mode not enabled
 			if !model.Async {
-				s := spinner.New(params.Printer)
-				s.Start("Cloning instance")
-				_, err = wait.CreateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.Region, instanceId).WaitWithContext(ctx)
+				err = postgresflexUtils.WaitForClonedInstance(ctx, params.Printer, apiClient, model.ProjectId, model.Region, instanceId)
 				if err != nil {
-					return fmt.Errorf("wait for PostgreSQL Flex instance cloning: %w", err)
+					return err
 				}
-				s.Stop()
 			}
 
 			return outputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, instanceId, resp)
@@ -162,48 +157,8 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 	return &model, nil
 }
 
-type PostgreSQLFlexClient interface {
-	CloneInstance(ctx context.Context, projectId, region, instanceId string) postgresflex.ApiCloneInstanceRequest
-	GetInstanceExecute(ctx context.Context, projectId, region, instanceId string) (*postgresflex.InstanceResponse, error)
-	ListStoragesExecute(ctx context.Context, projectId, region, flavorId string) (*postgresflex.ListStoragesResponse, error)
-}
-
-func buildRequest(ctx context.Context, model *inputModel, apiClient PostgreSQLFlexClient) (postgresflex.ApiCloneInstanceRequest, error) {
-	req := apiClient.CloneInstance(ctx, model.ProjectId, model.Region, model.InstanceId)
-
-	var storages *postgresflex.ListStoragesResponse
-	if model.StorageClass != nil || model.StorageSize != nil {
-		currentInstance, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.Region, model.InstanceId)
-		if err != nil {
-			return req, fmt.Errorf("get PostgreSQL Flex instance: %w", err)
-		}
-		validationFlavorId := currentInstance.Item.Flavor.Id
-		currentInstanceStorageClass := currentInstance.Item.Storage.Class
-		currentInstanceStorageSize := currentInstance.Item.Storage.Size
-
-		storages, err = apiClient.ListStoragesExecute(ctx, model.ProjectId, model.Region, *validationFlavorId)
-		if err != nil {
-			return req, fmt.Errorf("get PostgreSQL Flex storages: %w", err)
-		}
-
-		if model.StorageClass == nil {
-			err = postgresflexUtils.ValidateStorage(currentInstanceStorageClass, model.StorageSize, storages, *validationFlavorId)
-		} else if model.StorageSize == nil {
-			err = postgresflexUtils.ValidateStorage(model.StorageClass, currentInstanceStorageSize, storages, *validationFlavorId)
-		} else {
-			err = postgresflexUtils.ValidateStorage(model.StorageClass, model.StorageSize, storages, *validationFlavorId)
-		}
-		if err != nil {
-			return req, err
-		}
-	}
-
-	req = req.CloneInstancePayload(postgresflex.CloneInstancePayload{
-		Class:     model.StorageClass,
-		Size:      model.StorageSize,
-		Timestamp: model.RecoveryDate,
-	})
-	return req, nil
+func buildRequest(ctx context.Context, model *inputModel, apiClient postgresflexUtils.PostgresFlexCloneClient) (postgresflex.ApiCloneInstanceRequest, error) {
+	return postgresflexUtils.BuildCloneInstanceRequest(ctx, apiClient, model.ProjectId, model.Region, model.InstanceId, model.StorageClass, model.StorageSize, utils.PtrString(model.RecoveryDate))
 }
 
 func outputResult(p *print.Printer, outputFormat string, async bool, instanceLabel, instanceId string, resp *postgresflex.CloneInstanceResponse) error {
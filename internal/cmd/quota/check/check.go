@@ -0,0 +1,189 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	warnFlag = "warn"
+
+	warnDefault = 80
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Warn int64
+}
+
+type checkResult struct {
+	quota.Quota
+	Percent  float64 `json:"percent"`
+	Exceeded bool    `json:"exceeded"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Checks the quota usage against a threshold",
+		Long: fmt.Sprintf("%s\n%s",
+			"Checks the usage of the project quotas against a threshold in percent of the limit.",
+			"The command fails if the usage of any quota is at or above the threshold, so that it can be used in CI pipelines.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Check that all quotas are used less than 80%`,
+				`$ stackit quota check`,
+			),
+			examples.NewExample(
+				`Check that all quotas are used less than 90%, in JSON format`,
+				`$ stackit quota check --warn 90 --output-format json`,
+			),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			request := buildRequest(ctx, model, apiClient)
+			response, err := request.Execute()
+			if err != nil {
+				return fmt.Errorf("list quotas: %w", err)
+			}
+
+			results := checkQuotas(quota.FromAPI(response.Quotas), model.Warn)
+			err = outputResult(params.Printer, model.OutputFormat, results)
+			if err != nil {
+				return err
+			}
+
+			exceeded := 0
+			for _, result := range results {
+				if result.Exceeded {
+					exceeded++
+				}
+			}
+			if exceeded > 0 {
+				return fmt.Errorf("%d quotas are used at or above the threshold of %d%%", exceeded, model.Warn)
+			}
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(warnFlag, warnDefault, "Threshold in percent of the limit, at or above which the usage of a quota fails the check")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	warn := flags.FlagWithDefaultToInt64Value(p, cmd, warnFlag)
+	if warn < 1 || warn > 100 {
+		return nil, &errors.FlagValidationError{
+			Flag:    warnFlag,
+			Details: "must be between 1 and 100",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Warn:            warn,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiListQuotasRequest {
+	return apiClient.ListQuotas(ctx, model.ProjectId)
+}
+
+func checkQuotas(quotas []quota.Quota, warn int64) []checkResult {
+	results := make([]checkResult, 0, len(quotas))
+	for _, q := range quotas {
+		percent := q.Percent()
+		results = append(results, checkResult{
+			Quota:    q,
+			Percent:  percent,
+			Exceeded: percent >= float64(warn),
+		})
+	}
+	return results
+}
+
+func outputResult(p *print.Printer, outputFormat string, results []checkResult) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal quota check: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(results, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal quota check: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("NAME", "LIMIT", "CURRENT USAGE", "PERCENT", "STATUS")
+		for _, result := range results {
+			status := "OK"
+			if result.Exceeded {
+				status = "WARN"
+			}
+			table.AddRow(result.Description, result.Limit, result.Usage, fmt.Sprintf("%3.1f%%", result.Percent), status)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
 )
@@ -133,41 +134,8 @@ func outputResult(p *print.Printer, outputFormat string, quotas *iaas.QuotaList)
 	default:
 		table := tables.NewTable()
 		table.SetHeader("NAME", "LIMIT", "CURRENT USAGE", "PERCENT")
-		if val := quotas.BackupGigabytes; val != nil {
-			table.AddRow("Total size in GiB of backups [GiB]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Backups; val != nil {
-			table.AddRow("Number of backups [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Gigabytes; val != nil {
-			table.AddRow("Total size in GiB of volumes and snapshots [GiB]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Networks; val != nil {
-			table.AddRow("Number of networks [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Nics; val != nil {
-			table.AddRow("Number of network interfaces (nics) [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.PublicIps; val != nil {
-			table.AddRow("Number of public IP addresses [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Ram; val != nil {
-			table.AddRow("Amount of server RAM in MiB [MiB]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.SecurityGroupRules; val != nil {
-			table.AddRow("Number of security group rules [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.SecurityGroups; val != nil {
-			table.AddRow("Number of security groups [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Snapshots; val != nil {
-			table.AddRow("Number of snapshots [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Vcpu; val != nil {
-			table.AddRow("Number of server cores (vcpu) [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
-		}
-		if val := quotas.Volumes; val != nil {
-			table.AddRow("Number of volumes [Count]", conv(val.Limit), conv(val.Usage), percentage(val))
+		for _, q := range quota.AllFromAPI(quotas) {
+			table.AddRow(q.Description, conv(q.Limit), conv(q.Usage), percentage(q))
 		}
 		err := table.Display(p)
 		if err != nil {
@@ -185,14 +153,10 @@ func conv(n *int64) string {
 	return "n/a"
 }
 
-func percentage(val interface {
-	GetLimitOk() (int64, bool)
-	GetUsageOk() (int64, bool)
-}) string {
-	a, aOk := val.GetLimitOk()
-	b, bOk := val.GetUsageOk()
-	if aOk && bOk {
-		return fmt.Sprintf("%3.1f%%", 100.0/float64(a)*float64(b))
+func percentage(q quota.ReportedQuota) string {
+	if q.Limit == nil || q.Usage == nil {
+		return "n/a"
 	}
-	return "n/a"
+	usage := quota.Quota{Limit: *q.Limit, Usage: *q.Usage}
+	return fmt.Sprintf("%3.1f%%", usage.Percent())
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -186,6 +188,16 @@ func Test_outputResult(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "quotas without limit or usage",
+			args: args{
+				quotas: &iaas.QuotaList{
+					Networks: &iaas.QuotaListNetworks{Limit: utils.Ptr(int64(10))},
+					Vcpu:     &iaas.QuotaListVcpu{},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	p := print.NewPrinter()
 	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
@@ -197,3 +209,35 @@ func Test_outputResult(t *testing.T) {
 		})
 	}
 }
+
+func TestPercentage(t *testing.T) {
+	tests := []struct {
+		description string
+		quota       quota.ReportedQuota
+		expected    string
+	}{
+		{
+			description: "base",
+			quota:       quota.ReportedQuota{Limit: utils.Ptr(int64(10)), Usage: utils.Ptr(int64(3))},
+			expected:    "30.0%",
+		},
+		{
+			description: "usage missing",
+			quota:       quota.ReportedQuota{Limit: utils.Ptr(int64(10))},
+			expected:    "n/a",
+		},
+		{
+			description: "limit missing",
+			quota:       quota.ReportedQuota{Usage: utils.Ptr(int64(3))},
+			expected:    "n/a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if result := percentage(tt.quota); result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
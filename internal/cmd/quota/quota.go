@@ -2,6 +2,7 @@ package quota
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/quota/check"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/quota/list"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 
@@ -25,5 +26,6 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(
 		list.NewCmd(params),
+		check.NewCmd(params),
 	)
 }
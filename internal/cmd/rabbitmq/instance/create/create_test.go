@@ -228,6 +228,47 @@ func TestParseInput(t *testing.T) {
 			}),
 			isValid: false,
 		},
+		{
+			description: "with cpu, ram and version",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, planIdFlag)
+				flagValues[cpuFlag] = "2"
+				flagValues[ramFlag] = "4"
+				flagValues[versionFlag] = "3.13"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.PlanId = nil
+				model.CPU = utils.Ptr(int64(2))
+				model.RAM = utils.Ptr(int64(4))
+				model.Version = "3.13"
+			}),
+		},
+		{
+			description: "invalid with cpu only",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, planIdFlag)
+				flagValues[cpuFlag] = "2"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid with plan name and cpu",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, planIdFlag)
+				flagValues[planNameFlag] = "plan-name"
+				flagValues[cpuFlag] = "2"
+				flagValues[versionFlag] = "3.13"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid with plan ID and ram",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[ramFlag] = "4"
+			}),
+			isValid: false,
+		},
 		{
 			description:  "repeated acl flags",
 			flagValues:   fixtureFlagValues(),
@@ -401,6 +442,64 @@ func TestBuildRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "use cpu, ram and version",
+			model: fixtureInputModel(
+				func(model *inputModel) {
+					model.PlanId = nil
+					model.CPU = utils.Ptr(int64(2))
+					model.RAM = utils.Ptr(int64(4))
+					model.Version = "example-version"
+				},
+			),
+			expectedRequest: fixtureRequest(),
+			getOfferingsResp: &rabbitmq.ListOfferingsResponse{
+				Offerings: &[]rabbitmq.Offering{
+					{
+						Version: utils.Ptr("example-version"),
+						Plans: &[]rabbitmq.Plan{
+							{
+								Name: utils.Ptr("stackit-rabbitmq-1.2.10-single"),
+								Id:   utils.Ptr("other-plan-id"),
+							},
+							{
+								Name: utils.Ptr("stackit-rabbitmq-4.16.80-single"),
+								Id:   utils.Ptr("other-plan-id"),
+							},
+							{
+								Name: utils.Ptr("stackit-rabbitmq-2.4.20-single"),
+								Id:   utils.Ptr(testPlanId),
+							},
+						},
+					},
+				},
+			},
+			isValid: true,
+		},
+		{
+			description: "no plan with cpu and ram",
+			model: fixtureInputModel(
+				func(model *inputModel) {
+					model.PlanId = nil
+					model.CPU = utils.Ptr(int64(8))
+					model.Version = "example-version"
+				},
+			),
+			getOfferingsResp: &rabbitmq.ListOfferingsResponse{
+				Offerings: &[]rabbitmq.Offering{
+					{
+						Version: utils.Ptr("example-version"),
+						Plans: &[]rabbitmq.Plan{
+							{
+								Name: utils.Ptr("stackit-rabbitmq-2.4.20-single"),
+								Id:   utils.Ptr(testPlanId),
+							},
+						},
+					},
+				},
+			},
+			isValid: false,
+		},
 		{
 			description: "get offering fails",
 			model: fixtureInputModel(
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	goerrors "errors"
 	"fmt"
 	"os"
 	"strings"
@@ -12,11 +13,14 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta"
 	configCmd "github.com/stackitcloud/stackit-cli/internal/cmd/config"
+	copyCmd "github.com/stackitcloud/stackit-cli/internal/cmd/copy"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/curl"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/db"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/git"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/image"
 	keypair "github.com/stackitcloud/stackit-cli/internal/cmd/key-pair"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/label"
 	loadbalancer "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/logme"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb"
@@ -157,14 +161,17 @@ func configureFlags(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("configure global flags: %w", err)
 	}
+	cmd.MarkFlagsMutuallyExclusive(globalflags.RecordFlag, globalflags.ReplayFlag)
 	return nil
 }
 
 func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(auth.NewCmd(params))
 	cmd.AddCommand(configCmd.NewCmd(params))
+	cmd.AddCommand(copyCmd.NewCmd(params))
 	cmd.AddCommand(beta.NewCmd(params))
 	cmd.AddCommand(curl.NewCmd(params))
+	cmd.AddCommand(db.NewCmd(params))
 	cmd.AddCommand(dns.NewCmd(params))
 	cmd.AddCommand(loadbalancer.NewCmd(params))
 	cmd.AddCommand(logme.NewCmd(params))
@@ -190,6 +197,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(securitygroup.NewCmd(params))
 	cmd.AddCommand(keypair.NewCmd(params))
 	cmd.AddCommand(image.NewCmd(params))
+	cmd.AddCommand(label.NewCmd(params))
 	cmd.AddCommand(quota.NewCmd(params))
 	cmd.AddCommand(affinityGroups.NewCmd(params))
 	cmd.AddCommand(git.NewCmd(params))
@@ -213,6 +221,11 @@ func Execute(version, date string) {
 	p.Verbosity = print.InfoLevel
 
 	err := cmd.Execute()
+	var exitCodeErr *errors.ExitCodeError
+	if goerrors.As(err, &exitCodeErr) {
+		p.Debug(print.ErrorLevel, "execute command: %v", err)
+		os.Exit(exitCodeErr.Code)
+	}
 	if err != nil {
 		err := beautifyUnknownAndMissingCommandsError(cmd, err)
 		p.Debug(print.ErrorLevel, "execute command: %v", err)
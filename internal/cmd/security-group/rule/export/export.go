@@ -0,0 +1,135 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	securityGroupIdFlag = "security-group-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	SecurityGroupId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the rules of a security group to a file",
+		Long: fmt.Sprintf("%s\n%s",
+			"Exports the rules of a security group in YAML format, or in JSON format with --output-format json.",
+			`The exported rules can be synced back to a security group with "stackit security-group rule sync".`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Export the rules of the security group with ID "xxx" to the file "rules.yaml"`,
+				"$ stackit security-group rule export --security-group-id xxx > rules.yaml"),
+			examples.NewExample(
+				`Export the rules of the security group with ID "xxx" in JSON format`,
+				"$ stackit security-group rule export --security-group-id xxx --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("list security group rules: %w", err)
+			}
+
+			rules := []rulefile.Rule{}
+			if resp.Items != nil {
+				for i := range *resp.Items {
+					rules = append(rules, rulefile.FromSecurityGroupRule(&(*resp.Items)[i]))
+				}
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, rules)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), securityGroupIdFlag, `The security group ID`)
+
+	err := flags.MarkFlagsRequired(cmd, securityGroupIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		SecurityGroupId: flags.FlagToStringValue(p, cmd, securityGroupIdFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiListSecurityGroupRulesRequest {
+	return apiClient.ListSecurityGroupRules(ctx, model.ProjectId, model.SecurityGroupId)
+}
+
+func outputResult(p *print.Printer, outputFormat string, rules []rulefile.Rule) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(rulefile.File{Rules: rules}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal security group rules: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		details, err := rulefile.Format(rules)
+		if err != nil {
+			return fmt.Errorf("marshal security group rules: %w", err)
+		}
+		p.Outputf("%s", details)
+
+		return nil
+	}
+}
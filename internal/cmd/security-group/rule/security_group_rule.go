@@ -5,7 +5,9 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/export"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/security-group/rule/sync"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -29,4 +31,6 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(export.NewCmd(params))
+	cmd.AddCommand(sync.NewCmd(params))
 }
@@ -0,0 +1,291 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	securityGroupIdFlag = "security-group-id"
+	fileFlag            = "file"
+	dryRunFlag          = "dry-run"
+
+	actionCreate = "create"
+	actionDelete = "delete"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	SecurityGroupId string
+	FilePath        string
+	DryRun          bool
+}
+
+// ruleChange is a rule to create or an existing rule to delete
+type ruleChange struct {
+	Action string        `json:"action"`
+	Id     string        `json:"id,omitempty"`
+	Rule   rulefile.Rule `json:"rule"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Syncs the rules of a security group with a file",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Syncs the rules of a security group with the rules defined in a YAML or JSON file.",
+			"Rules are compared by what they match (direction, ether type, protocol, port range, ICMP parameters, IP range and remote security group), not by ID or description.",
+			"Rules of the file which don't exist in the security group are created and rules of the security group which are not in the file are deleted. New rules are created before old ones are deleted.",
+			`The file has the format of "stackit security-group rule export": {"rules": [{"direction": "ingress", "protocol": "tcp", "portRange": {"min": 22, "max": 22}, "ipRange": "10.0.0.0/8"}]}. The protocol can be given by name or number.`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Sync the rules of the security group with ID "xxx" with the file "rules.yaml"`,
+				"$ stackit security-group rule sync --security-group-id xxx --file rules.yaml"),
+			examples.NewExample(
+				`Show the changes needed to sync the security group with ID "xxx" with the file "rules.yaml", without applying them`,
+				"$ stackit security-group rule sync --security-group-id xxx --file rules.yaml --dry-run"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			content, err := os.ReadFile(model.FilePath)
+			if err != nil {
+				return fmt.Errorf("read rules file %q: %w", model.FilePath, err)
+			}
+			desired, err := rulefile.Parse(content)
+			if err != nil {
+				return fmt.Errorf("parse rules file %q: %w", model.FilePath, err)
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			securityGroupLabel, err := iaasUtils.GetSecurityGroupName(ctx, apiClient, model.ProjectId, model.SecurityGroupId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get security group name: %v", err)
+				securityGroupLabel = model.SecurityGroupId
+			}
+
+			resp, err := apiClient.ListSecurityGroupRulesExecute(ctx, model.ProjectId, model.SecurityGroupId)
+			if err != nil {
+				return fmt.Errorf("list security group rules: %w", err)
+			}
+
+			changes := planChanges(desired, utils.PtrValue(resp.Items))
+			if len(changes) == 0 {
+				params.Printer.Info("Rules of security group %q are already in sync\n", securityGroupLabel)
+				return nil
+			}
+			if model.DryRun {
+				return outputResult(params.Printer, model, securityGroupLabel, changes)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to %s in security group %q?", describeChanges(changes), securityGroupLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			s := spinner.New(params.Printer)
+			s.Start("Syncing security group rules")
+			err = applyChanges(ctx, apiClient, model, changes)
+			if err != nil {
+				s.StopWithError()
+				return err
+			}
+			s.Stop()
+
+			return outputResult(params.Printer, model, securityGroupLabel, changes)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), securityGroupIdFlag, `The security group ID`)
+	cmd.Flags().StringP(fileFlag, "f", "", "Path of the YAML or JSON file with the desired rules")
+	cmd.Flags().Bool(dryRunFlag, false, "Only show the changes, without applying them")
+
+	err := flags.MarkFlagsRequired(cmd, securityGroupIdFlag, fileFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		SecurityGroupId: flags.FlagToStringValue(p, cmd, securityGroupIdFlag),
+		FilePath:        flags.FlagToStringValue(p, cmd, fileFlag),
+		DryRun:          flags.FlagToBoolValue(p, cmd, dryRunFlag),
+	}
+
+	if model.FilePath == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    fileFlag,
+			Details: "must not be empty",
+		}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func planChanges(desired []rulefile.Rule, existing []iaas.SecurityGroupRule) []ruleChange {
+	toCreate, toDelete := rulefile.Plan(desired, existing)
+
+	changes := []ruleChange{}
+	for i := range toCreate {
+		changes = append(changes, ruleChange{
+			Action: actionCreate,
+			Rule:   toCreate[i],
+		})
+	}
+	for i := range toDelete {
+		changes = append(changes, ruleChange{
+			Action: actionDelete,
+			Id:     utils.PtrString(toDelete[i].Id),
+			Rule:   rulefile.FromSecurityGroupRule(&toDelete[i]),
+		})
+	}
+	return changes
+}
+
+// applyChanges creates and deletes the rules in the order of the changes, so that new rules exist before old ones are deleted
+func applyChanges(ctx context.Context, apiClient *iaas.APIClient, model *inputModel, changes []ruleChange) error {
+	for i := range changes {
+		change := &changes[i]
+		switch change.Action {
+		case actionCreate:
+			resp, err := apiClient.CreateSecurityGroupRule(ctx, model.ProjectId, model.SecurityGroupId).
+				CreateSecurityGroupRulePayload(change.Rule.CreatePayload()).
+				Execute()
+			if err != nil {
+				return fmt.Errorf("create security group rule: %w", err)
+			}
+			change.Id = utils.PtrString(resp.Id)
+		case actionDelete:
+			err := apiClient.DeleteSecurityGroupRuleExecute(ctx, model.ProjectId, model.SecurityGroupId, change.Id)
+			if err != nil {
+				return fmt.Errorf("delete security group rule %q: %w", change.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func describeChanges(changes []ruleChange) string {
+	create, del := 0, 0
+	for i := range changes {
+		if changes[i].Action == actionCreate {
+			create++
+		} else {
+			del++
+		}
+	}
+	switch {
+	case create > 0 && del > 0:
+		return fmt.Sprintf("create %d and delete %d rules", create, del)
+	case create > 0:
+		return fmt.Sprintf("create %d rules", create)
+	default:
+		return fmt.Sprintf("delete %d rules", del)
+	}
+}
+
+func outputResult(p *print.Printer, model *inputModel, securityGroupLabel string, changes []ruleChange) error {
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal security group rule changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(changes, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal security group rule changes: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ACTION", "ID", "DIRECTION", "ETHER TYPE", "PROTOCOL", "PORT RANGE", "IP RANGE", "REMOTE SECURITY GROUP ID", "DESCRIPTION")
+		for i := range changes {
+			change := changes[i]
+			portRange := ""
+			if change.Rule.PortRange != nil {
+				portRange = fmt.Sprintf("%d-%d", change.Rule.PortRange.Min, change.Rule.PortRange.Max)
+			}
+			table.AddRow(
+				change.Action,
+				change.Id,
+				change.Rule.Direction,
+				change.Rule.Ethertype,
+				change.Rule.Protocol,
+				portRange,
+				change.Rule.IpRange,
+				change.Rule.RemoteSecurityGroupId,
+				change.Rule.Description,
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		if model.DryRun {
+			p.Outputf("Dry run, no changes were applied to security group %q\n", securityGroupLabel)
+		} else {
+			p.Outputf("Applied %d rule changes in security group %q\n", len(changes), securityGroupLabel)
+		}
+		return nil
+	}
+}
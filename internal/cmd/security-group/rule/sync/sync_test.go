@@ -0,0 +1,297 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+var testProjectId = uuid.NewString()
+var testSecurityGroupId = uuid.NewString()
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag:       testProjectId,
+		securityGroupIdFlag: testSecurityGroupId,
+		fileFlag:            "rules.yaml",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			Verbosity: globalflags.VerbosityDefault,
+			ProjectId: testProjectId,
+		},
+		SecurityGroupId: testSecurityGroupId,
+		FilePath:        "rules.yaml",
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "dry run",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[dryRunFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.DryRun = true
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "security group id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, securityGroupIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "security group id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[securityGroupIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "file missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, fileFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "file empty",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[fileFlag] = ""
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPlanChanges(t *testing.T) {
+	desired := []rulefile.Rule{
+		{Direction: rulefile.DirectionIngress, Protocol: "tcp", PortRange: &rulefile.PortRange{Min: 443, Max: 443}},
+	}
+	existing := []iaas.SecurityGroupRule{
+		{
+			Id:        utils.Ptr("old"),
+			Direction: utils.Ptr(rulefile.DirectionIngress),
+			Ethertype: utils.Ptr("IPv4"),
+			Protocol:  &iaas.Protocol{Name: utils.Ptr("tcp")},
+			PortRange: &iaas.PortRange{Min: utils.Ptr(int64(80)), Max: utils.Ptr(int64(80))},
+		},
+	}
+	expected := []ruleChange{
+		{Action: actionCreate, Rule: desired[0]},
+		{
+			Action: actionDelete,
+			Id:     "old",
+			Rule:   rulefile.Rule{Direction: rulefile.DirectionIngress, Ethertype: "IPv4", Protocol: "tcp", PortRange: &rulefile.PortRange{Min: 80, Max: 80}},
+		},
+	}
+
+	changes := planChanges(desired, existing)
+	diff := cmp.Diff(changes, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if description := describeChanges(changes); description != "create 1 and delete 1 rules" {
+		t.Fatalf("unexpected description %q", description)
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id": "new", "direction": "ingress"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+
+	oldRuleId := uuid.NewString()
+	changes := []ruleChange{
+		{Action: actionCreate, Rule: rulefile.Rule{Direction: rulefile.DirectionIngress}},
+		{Action: actionDelete, Id: oldRuleId, Rule: rulefile.Rule{Direction: rulefile.DirectionEgress}},
+	}
+	err = applyChanges(context.Background(), apiClient, fixtureInputModel(), changes)
+	if err != nil {
+		t.Fatalf("failed to apply changes: %v", err)
+	}
+
+	basePath := fmt.Sprintf("/v1/projects/%s/security-groups/%s/rules", testProjectId, testSecurityGroupId)
+	diff := cmp.Diff(requests, []string{
+		"POST " + basePath,
+		"DELETE " + basePath + "/" + oldRuleId,
+	})
+	if diff != "" {
+		t.Fatalf("Requests do not match: %s", diff)
+	}
+	if changes[0].Id != "new" {
+		t.Fatalf("expected ID of created rule to be set, got %q", changes[0].Id)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	testChanges := []ruleChange{
+		{Action: actionCreate, Rule: rulefile.Rule{Direction: rulefile.DirectionIngress, PortRange: &rulefile.PortRange{Min: 22, Max: 22}}},
+		{Action: actionDelete, Id: "old", Rule: rulefile.Rule{Direction: rulefile.DirectionEgress}},
+	}
+
+	type args struct {
+		model   *inputModel
+		changes []ruleChange
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				model: fixtureInputModel(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "changes",
+			args: args{
+				model:   fixtureInputModel(),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dry run",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.DryRun = true
+				}),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				model: fixtureInputModel(func(model *inputModel) {
+					model.OutputFormat = print.JSONOutputFormat
+				}),
+				changes: testChanges,
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.model, "sg", tt.args.changes); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/disable"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/enable"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/prune"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/restore"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/schedule"
 	volumebackup "github.com/stackitcloud/stackit-cli/internal/cmd/server/backup/volume-backup"
@@ -38,5 +39,6 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(create.NewCmd(params))
 	cmd.AddCommand(restore.NewCmd(params))
 	cmd.AddCommand(del.NewCmd(params))
+	cmd.AddCommand(prune.NewCmd(params))
 	cmd.AddCommand(volumebackup.NewCmd(params))
 }
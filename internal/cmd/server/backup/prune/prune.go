@@ -0,0 +1,263 @@
+package prune
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/retention"
+	serverbackupUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+const (
+	serverIdFlag    = "server-id"
+	keepDailyFlag   = "keep-daily"
+	keepWeeklyFlag  = "keep-weekly"
+	keepMonthlyFlag = "keep-monthly"
+	dryRunFlag      = "dry-run"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ServerIds []string
+	Policy    retention.Policy
+	DryRun    bool
+}
+
+// serverPlan holds the retention decisions for the backups of a server
+type serverPlan struct {
+	ServerId  string               `json:"serverId"`
+	Decisions []retention.Decision `json:"decisions"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Deletes server backups according to a retention policy",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Deletes server backups according to a grandfather-father-son retention policy.",
+			"For each of the last days, ISO weeks and months with backups, the newest backup of the period is kept, up to the given number of periods. All other completed backups are deleted.",
+			"Backups which are not completed are never deleted and don't count towards the retention policy.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Show which backups of server "xxx" would be deleted when keeping 7 daily, 4 weekly and 6 monthly backups`,
+				"$ stackit server backup prune --server-id xxx --keep-daily 7 --keep-weekly 4 --keep-monthly 6 --dry-run"),
+			examples.NewExample(
+				`Delete the backups of servers "xxx" and "yyy", keeping 7 daily and 4 weekly backups of each server`,
+				"$ stackit server backup prune --server-id xxx,yyy --keep-daily 7 --keep-weekly 4"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			plans, err := buildPlans(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+			err = outputResult(params.Printer, model.OutputFormat, plans)
+			if err != nil {
+				return err
+			}
+
+			count := countPruned(plans)
+			if count == 0 {
+				params.Printer.Info("No backups to delete\n")
+				return nil
+			}
+			if model.DryRun {
+				params.Printer.Info("Dry run: %d backups would be deleted\n", count)
+				return nil
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to delete %d server backups? (This cannot be undone)", count)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			failed := 0
+			for _, plan := range plans {
+				for _, decision := range plan.Decisions {
+					if decision.Keep {
+						continue
+					}
+					backupId := utils.PtrString(decision.Backup.Id)
+					err = apiClient.DeleteBackupExecute(ctx, model.ProjectId, plan.ServerId, model.Region, backupId)
+					if err != nil {
+						params.Printer.Warn("delete backup %q of server %q: %v\n", backupId, plan.ServerId, err)
+						failed++
+					}
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to delete %d of %d server backups", failed, count)
+			}
+
+			params.Printer.Info("Triggered deletion of %d server backups\n", count)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().VarP(flags.UUIDSliceFlag(), serverIdFlag, "s", "Server IDs, as comma separated UUID values")
+	cmd.Flags().Int64(keepDailyFlag, 0, "Number of days for which the newest backup is kept")
+	cmd.Flags().Int64(keepWeeklyFlag, 0, "Number of weeks for which the newest backup is kept")
+	cmd.Flags().Int64(keepMonthlyFlag, 0, "Number of months for which the newest backup is kept")
+	cmd.Flags().Bool(dryRunFlag, false, "Only show which backups would be deleted")
+
+	err := flags.MarkFlagsRequired(cmd, serverIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	policy := retention.Policy{}
+	for _, keep := range []struct {
+		flag  string
+		value *int
+	}{
+		{keepDailyFlag, &policy.KeepDaily},
+		{keepWeeklyFlag, &policy.KeepWeekly},
+		{keepMonthlyFlag, &policy.KeepMonthly},
+	} {
+		value := utils.PtrValue(flags.FlagToInt64Pointer(p, cmd, keep.flag))
+		if value < 0 {
+			return nil, &errors.FlagValidationError{
+				Flag:    keep.flag,
+				Details: "must not be negative",
+			}
+		}
+		*keep.value = int(value)
+	}
+	if policy.IsEmpty() {
+		return nil, fmt.Errorf("at least one of --%s, --%s or --%s must be greater than 0", keepDailyFlag, keepWeeklyFlag, keepMonthlyFlag)
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ServerIds:       flags.FlagToStringSliceValue(p, cmd, serverIdFlag),
+		Policy:          policy,
+		DryRun:          flags.FlagToBoolValue(p, cmd, dryRunFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildPlans(ctx context.Context, model *inputModel, apiClient serverbackupUtils.ServerBackupClient) ([]serverPlan, error) {
+	plans := []serverPlan{}
+	for _, serverId := range model.ServerIds {
+		resp, err := apiClient.ListBackupsExecute(ctx, model.ProjectId, serverId, model.Region)
+		if err != nil {
+			return nil, fmt.Errorf("list backups of server %q: %w", serverId, err)
+		}
+		decisions, err := retention.Apply(model.Policy, utils.PtrValue(resp.Items))
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, serverPlan{ServerId: serverId, Decisions: decisions})
+	}
+	return plans, nil
+}
+
+func countPruned(plans []serverPlan) int {
+	count := 0
+	for _, plan := range plans {
+		for _, decision := range plan.Decisions {
+			if !decision.Keep {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func outputResult(p *print.Printer, outputFormat string, plans []serverPlan) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(plans, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal server backup retention: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(plans, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal server backup retention: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("SERVER ID", "BACKUP ID", "NAME", "CREATED AT", "STATUS", "ACTION", "REASONS")
+		for _, plan := range plans {
+			for _, decision := range plan.Decisions {
+				action := "delete"
+				if decision.Keep {
+					action = "keep"
+				}
+				table.AddRow(
+					plan.ServerId,
+					utils.PtrString(decision.Backup.Id),
+					utils.PtrString(decision.Backup.Name),
+					utils.PtrString(decision.Backup.CreatedAt),
+					utils.PtrString(decision.Backup.Status),
+					action,
+					strings.Join(decision.Reasons, ", "),
+				)
+			}
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
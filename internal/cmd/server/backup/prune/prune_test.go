@@ -0,0 +1,288 @@
+package prune
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/retention"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testProjectId = uuid.NewString()
+var testServerId = uuid.NewString()
+var testServerId2 = uuid.NewString()
+var testRegion = "eu01"
+
+type serverBackupClientMocked struct {
+	listBackupsFails bool
+	backups          map[string][]serverbackup.Backup
+}
+
+func (m *serverBackupClientMocked) ListBackupSchedulesExecute(_ context.Context, _, _, _ string) (*serverbackup.GetBackupSchedulesResponse, error) {
+	return nil, nil
+}
+
+func (m *serverBackupClientMocked) ListBackupsExecute(_ context.Context, _, serverId, _ string) (*serverbackup.GetBackupsListResponse, error) {
+	if m.listBackupsFails {
+		return nil, fmt.Errorf("could not list backups")
+	}
+	backups := m.backups[serverId]
+	return &serverbackup.GetBackupsListResponse{Items: &backups}, nil
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		serverIdFlag:              testServerId,
+		keepDailyFlag:             "7",
+		keepWeeklyFlag:            "4",
+		keepMonthlyFlag:           "6",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ServerIds: []string{testServerId},
+		Policy:    retention.Policy{KeepDaily: 7, KeepWeekly: 4, KeepMonthly: 6},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureBackup(id, createdAt string) serverbackup.Backup {
+	return serverbackup.Backup{
+		Id:        utils.Ptr(id),
+		Name:      utils.Ptr(id),
+		CreatedAt: utils.Ptr(createdAt),
+		Status:    utils.Ptr(retention.BackupStatusCompleted),
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "several servers and dry run",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serverIdFlag] = fmt.Sprintf("%s,%s", testServerId, testServerId2)
+				flagValues[dryRunFlag] = "true"
+				delete(flagValues, keepWeeklyFlag)
+				delete(flagValues, keepMonthlyFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ServerIds = []string{testServerId, testServerId2}
+				model.DryRun = true
+				model.Policy = retention.Policy{KeepDaily: 7}
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "server id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, serverIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "server id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[serverIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no retention",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, keepDailyFlag)
+				delete(flagValues, keepWeeklyFlag)
+				delete(flagValues, keepMonthlyFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "negative retention",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[keepWeeklyFlag] = "-1"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildPlans(t *testing.T) {
+	client := &serverBackupClientMocked{
+		backups: map[string][]serverbackup.Backup{
+			testServerId: {
+				fixtureBackup("old", "2025-03-17T02:00:00Z"),
+				fixtureBackup("new", "2025-03-18T02:00:00Z"),
+			},
+			testServerId2: {},
+		},
+	}
+	model := fixtureInputModel(func(model *inputModel) {
+		model.ServerIds = []string{testServerId, testServerId2}
+		model.Policy = retention.Policy{KeepDaily: 1}
+	})
+
+	plans, err := buildPlans(testCtx, model, client)
+	if err != nil {
+		t.Fatalf("build plans: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("expected 2 plans, got %d", len(plans))
+	}
+	if plans[0].ServerId != testServerId || len(plans[0].Decisions) != 2 || len(plans[1].Decisions) != 0 {
+		t.Fatalf("unexpected plans: %+v", plans)
+	}
+	if utils.PtrString(plans[0].Decisions[1].Backup.Id) != "old" || plans[0].Decisions[1].Keep {
+		t.Fatalf("expected backup %q to be deleted: %+v", "old", plans[0].Decisions)
+	}
+	if countPruned(plans) != 1 {
+		t.Fatalf("expected 1 backup to be deleted, got %d", countPruned(plans))
+	}
+
+	client.listBackupsFails = true
+	_, err = buildPlans(testCtx, model, client)
+	if err == nil {
+		t.Fatalf("expected error when listing backups fails")
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	plans := []serverPlan{
+		{
+			ServerId: testServerId,
+			Decisions: []retention.Decision{
+				{Backup: fixtureBackup("new", "2025-03-18T02:00:00Z"), Keep: true, Reasons: []string{retention.ReasonDaily}},
+				{Backup: fixtureBackup("old", "2025-03-17T02:00:00Z"), Reasons: []string{}},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		outputFormat string
+		plans        []serverPlan
+		wantErr      bool
+	}{
+		{
+			name:    "empty",
+			wantErr: false,
+		},
+		{
+			name:    "plans",
+			plans:   plans,
+			wantErr: false,
+		},
+		{
+			name:         "json output",
+			outputFormat: print.JSONOutputFormat,
+			plans:        plans,
+			wantErr:      false,
+		},
+		{
+			name:         "yaml output",
+			outputFormat: print.YAMLOutputFormat,
+			plans:        plans,
+			wantErr:      false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.outputFormat, tt.plans); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
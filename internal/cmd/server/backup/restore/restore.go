@@ -11,10 +11,15 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	iaasClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/client"
+	serverbackupUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/serverbackup/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas/wait"
 	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
 )
 
@@ -23,8 +28,12 @@ const (
 	serverIdFlag                = "server-id"
 	startServerAfterRestoreFlag = "start-server-after-restore"
 	backupVolumeIdsFlag         = "volume-ids"
+	toNewVolumeFlag             = "to-new-volume"
+	targetServerIdFlag          = "target-server-id"
 
 	defaultStartServerAfterRestore = false
+
+	restoredFromBackupLabel = "restored-from-backup"
 )
 
 type inputModel struct {
@@ -33,14 +42,20 @@ type inputModel struct {
 	ServerId                string
 	StartServerAfterRestore bool
 	BackupVolumeIds         []string
+	ToNewVolume             bool
+	TargetServerId          string
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("restore %s", backupIdArg),
 		Short: "Restores a Server Backup.",
-		Long:  "Restores a Server Backup. Operation always is async.",
-		Args:  args.SingleArg(backupIdArg, utils.ValidateUUID),
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Restores a Server Backup. Operation always is async.",
+			"With --to-new-volume, the volume backups are restored to new volumes instead, which are attached to the server given by --target-server-id. The original volumes of the server are not changed.",
+			"The new volumes are created in the availability zone of the target server. This operation waits until the volumes are restored and attached.",
+		),
+		Args: args.SingleArg(backupIdArg, utils.ValidateUUID),
 		Example: examples.Build(
 			examples.NewExample(
 				`Restore a Server Backup with ID "xxx" for server "zzz"`,
@@ -48,6 +63,12 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Restore a Server Backup with ID "xxx" for server "zzz" and start the server afterwards`,
 				"$ stackit server backup restore xxx --server-id=zzz --start-server-after-restore"),
+			examples.NewExample(
+				`Restore all volume backups of the Server Backup with ID "xxx" for server "zzz" to new volumes and attach them to server "yyy"`,
+				"$ stackit server backup restore xxx --server-id=zzz --to-new-volume --target-server-id=yyy"),
+			examples.NewExample(
+				`Restore the volume backup with ID "vvv" of the Server Backup with ID "xxx" for server "zzz" to a new volume and attach it to server "yyy"`,
+				"$ stackit server backup restore xxx --server-id=zzz --to-new-volume --target-server-id=yyy --volume-ids=vvv"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -62,6 +83,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
+			if model.ToNewVolume {
+				return restoreToNewVolumes(ctx, params, model, apiClient)
+			}
+
 			if !model.AssumeYes {
 				prompt := fmt.Sprintf("Are you sure you want to restore server backup %q? (This cannot be undone)", model.BackupId)
 				err = params.Printer.PromptForConfirmation(prompt)
@@ -89,6 +114,10 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().VarP(flags.UUIDFlag(), serverIdFlag, "s", "Server ID")
 	cmd.Flags().VarP(flags.UUIDSliceFlag(), backupVolumeIdsFlag, "i", "Backup volume IDs, as comma separated UUID values.")
 	cmd.Flags().BoolP(startServerAfterRestoreFlag, "u", defaultStartServerAfterRestore, "Should the server start after the backup restoring.")
+	cmd.Flags().Bool(toNewVolumeFlag, false, "Restore the volume backups to new volumes and attach them to the target server, instead of restoring the server")
+	cmd.Flags().Var(flags.UUIDFlag(), targetServerIdFlag, "ID of the server to attach the new volumes to. Required with --to-new-volume")
+
+	cmd.MarkFlagsMutuallyExclusive(toNewVolumeFlag, startServerAfterRestoreFlag)
 
 	err := flags.MarkFlagsRequired(cmd, serverIdFlag)
 	cobra.CheckErr(err)
@@ -108,6 +137,21 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		ServerId:                flags.FlagToStringValue(p, cmd, serverIdFlag),
 		BackupVolumeIds:         flags.FlagToStringSliceValue(p, cmd, backupVolumeIdsFlag),
 		StartServerAfterRestore: flags.FlagToBoolValue(p, cmd, startServerAfterRestoreFlag),
+		ToNewVolume:             flags.FlagToBoolValue(p, cmd, toNewVolumeFlag),
+		TargetServerId:          flags.FlagToStringValue(p, cmd, targetServerIdFlag),
+	}
+
+	if model.ToNewVolume && model.TargetServerId == "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    targetServerIdFlag,
+			Details: fmt.Sprintf("must be set with --%s", toNewVolumeFlag),
+		}
+	}
+	if !model.ToNewVolume && model.TargetServerId != "" {
+		return nil, &errors.FlagValidationError{
+			Flag:    targetServerIdFlag,
+			Details: fmt.Sprintf("can only be set with --%s", toNewVolumeFlag),
+		}
 	}
 
 	if p.IsVerbosityDebug() {
@@ -134,3 +178,137 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *serverbacku
 	req = req.RestoreBackupPayload(payload)
 	return req
 }
+
+func restoreToNewVolumes(ctx context.Context, params *params.CmdParams, model *inputModel, apiClient *serverbackup.APIClient) error {
+	iaasApiClient, err := iaasClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return err
+	}
+
+	backup, err := apiClient.GetBackupExecute(ctx, model.ProjectId, model.ServerId, model.Region, model.BackupId)
+	if err != nil {
+		return fmt.Errorf("get Server Backup: %w", err)
+	}
+	volumeBackups, err := selectVolumeBackups(backup, model.BackupVolumeIds)
+	if err != nil {
+		return err
+	}
+	targetServer, err := iaasApiClient.GetServerExecute(ctx, model.ProjectId, model.TargetServerId)
+	if err != nil {
+		return fmt.Errorf("get target server: %w", err)
+	}
+	targetServerLabel := utils.PtrString(targetServer.Name)
+	if targetServerLabel == "" {
+		targetServerLabel = model.TargetServerId
+	}
+
+	if !model.AssumeYes {
+		prompt := fmt.Sprintf("Are you sure you want to restore %d volume backups of server backup %q to new volumes and attach them to server %q?", len(volumeBackups), model.BackupId, targetServerLabel)
+		err = params.Printer.PromptForConfirmation(prompt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range volumeBackups {
+		volumeBackupId := utils.PtrString(volumeBackups[i].Id)
+		s := spinner.New(params.Printer)
+		s.Start(fmt.Sprintf("Restoring volume backup %q", volumeBackupId))
+
+		req, err := buildCreateVolumeRequest(ctx, model, iaasApiClient, utils.PtrString(targetServer.AvailabilityZone), &volumeBackups[i])
+		if err != nil {
+			s.StopWithError()
+			return err
+		}
+		volume, err := req.Execute()
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("create volume: %w", err)
+		}
+		volumeId := utils.PtrString(volume.Id)
+		_, err = wait.CreateVolumeWaitHandler(ctx, iaasApiClient, model.ProjectId, volumeId).WaitWithContext(ctx)
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("wait for volume creation: %w", err)
+		}
+
+		err = buildRestoreVolumeBackupRequest(ctx, model, apiClient, volumeBackupId, volumeId).Execute()
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("restore Server Volume Backup: %w", err)
+		}
+		_, err = serverbackupUtils.RestoreVolumeBackupWaitHandler(ctx, apiClient, model.ProjectId, model.ServerId, model.Region, model.BackupId, volumeBackupId, volumeId).WaitWithContext(ctx)
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("wait for restoring of Server Volume Backup: %w", err)
+		}
+
+		_, err = iaasApiClient.AddVolumeToServer(ctx, model.ProjectId, model.TargetServerId, volumeId).Execute()
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("attach volume to server: %w", err)
+		}
+		_, err = wait.AddVolumeToServerWaitHandler(ctx, iaasApiClient, model.ProjectId, model.TargetServerId, volumeId).WaitWithContext(ctx)
+		if err != nil {
+			s.StopWithError()
+			return fmt.Errorf("wait for volume attachment: %w", err)
+		}
+		s.Stop()
+
+		params.Printer.Info("Restored volume backup %q to new volume %q and attached it to server %q\n", volumeBackupId, volumeId, targetServerLabel)
+	}
+	return nil
+}
+
+// selectVolumeBackups returns the volume backups of the backup with the given IDs, or all volume backups if no IDs are given
+func selectVolumeBackups(backup *serverbackup.Backup, volumeBackupIds []string) ([]serverbackup.BackupVolumeBackupsInner, error) {
+	volumeBackups := utils.PtrValue(backup.VolumeBackups)
+	if len(volumeBackupIds) == 0 {
+		if len(volumeBackups) == 0 {
+			return nil, fmt.Errorf("server backup %q has no volume backups", utils.PtrString(backup.Id))
+		}
+		return volumeBackups, nil
+	}
+
+	selected := []serverbackup.BackupVolumeBackupsInner{}
+	for _, id := range volumeBackupIds {
+		found := false
+		for _, volumeBackup := range volumeBackups {
+			if utils.PtrString(volumeBackup.Id) == id {
+				selected = append(selected, volumeBackup)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("volume backup %q not found in server backup %q", id, utils.PtrString(backup.Id))
+		}
+	}
+	return selected, nil
+}
+
+func buildCreateVolumeRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient, availabilityZone string, volumeBackup *serverbackup.BackupVolumeBackupsInner) (iaas.ApiCreateVolumeRequest, error) {
+	volumeBackupId := utils.PtrString(volumeBackup.Id)
+	if utils.PtrValue(volumeBackup.Size) <= 0 {
+		return iaas.ApiCreateVolumeRequest{}, fmt.Errorf("size of volume backup %q is unknown", volumeBackupId)
+	}
+
+	req := apiClient.CreateVolume(ctx, model.ProjectId)
+	payload := iaas.CreateVolumePayload{
+		AvailabilityZone: utils.Ptr(availabilityZone),
+		Name:             utils.Ptr(fmt.Sprintf("restore-%s", volumeBackupId)),
+		Size:             volumeBackup.Size,
+		Labels: utils.Ptr(map[string]interface{}{
+			restoredFromBackupLabel: model.BackupId,
+		}),
+	}
+	return req.CreateVolumePayload(payload), nil
+}
+
+func buildRestoreVolumeBackupRequest(ctx context.Context, model *inputModel, apiClient *serverbackup.APIClient, volumeBackupId, volumeId string) serverbackup.ApiRestoreVolumeBackupRequest {
+	req := apiClient.RestoreVolumeBackup(ctx, model.ProjectId, model.ServerId, model.Region, model.BackupId, volumeBackupId)
+	payload := serverbackup.RestoreVolumeBackupPayload{
+		RestoreVolumeId: utils.Ptr(volumeId),
+	}
+	return req.RestoreVolumeBackupPayload(payload)
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
 	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
 )
 
@@ -22,6 +24,10 @@ var testProjectId = uuid.NewString()
 var testServerId = uuid.NewString()
 var testBackupId = uuid.NewString()
 var testRegion = "eu01"
+var testIaasClient = &iaas.APIClient{}
+var testTargetServerId = uuid.NewString()
+var testVolumeBackupId = uuid.NewString()
+var testVolumeId = uuid.NewString()
 
 func fixtureArgValues(mods ...func(argValues []string)) []string {
 	argValues := []string{
@@ -128,6 +134,46 @@ func TestParseInput(t *testing.T) {
 			}),
 			isValid: false,
 		},
+		{
+			description: "to new volume",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toNewVolumeFlag] = "true"
+				flagValues[targetServerIdFlag] = testTargetServerId
+				flagValues[backupVolumeIdsFlag] = testVolumeBackupId
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ToNewVolume = true
+				model.TargetServerId = testTargetServerId
+				model.BackupVolumeIds = []string{testVolumeBackupId}
+			}),
+		},
+		{
+			description: "to new volume without target server",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toNewVolumeFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "target server without to new volume",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[targetServerIdFlag] = testTargetServerId
+			}),
+			isValid: false,
+		},
+		{
+			description: "target server id invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[toNewVolumeFlag] = "true"
+				flagValues[targetServerIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -211,3 +257,136 @@ func TestBuildRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectVolumeBackups(t *testing.T) {
+	otherVolumeBackupId := uuid.NewString()
+	backup := &serverbackup.Backup{
+		Id: utils.Ptr(testBackupId),
+		VolumeBackups: &[]serverbackup.BackupVolumeBackupsInner{
+			{Id: utils.Ptr(testVolumeBackupId)},
+			{Id: utils.Ptr(otherVolumeBackupId)},
+		},
+	}
+
+	tests := []struct {
+		description     string
+		backup          *serverbackup.Backup
+		volumeBackupIds []string
+		isValid         bool
+		expectedIds     []string
+	}{
+		{
+			description: "all volume backups",
+			backup:      backup,
+			isValid:     true,
+			expectedIds: []string{testVolumeBackupId, otherVolumeBackupId},
+		},
+		{
+			description:     "selected volume backups",
+			backup:          backup,
+			volumeBackupIds: []string{otherVolumeBackupId},
+			isValid:         true,
+			expectedIds:     []string{otherVolumeBackupId},
+		},
+		{
+			description:     "volume backup not found",
+			backup:          backup,
+			volumeBackupIds: []string{uuid.NewString()},
+			isValid:         false,
+		},
+		{
+			description: "no volume backups",
+			backup:      &serverbackup.Backup{Id: utils.Ptr(testBackupId)},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			volumeBackups, err := selectVolumeBackups(tt.backup, tt.volumeBackupIds)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("select volume backups: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			ids := []string{}
+			for _, volumeBackup := range volumeBackups {
+				ids = append(ids, utils.PtrString(volumeBackup.Id))
+			}
+			diff := cmp.Diff(ids, tt.expectedIds)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCreateVolumeRequest(t *testing.T) {
+	tests := []struct {
+		description     string
+		volumeBackup    *serverbackup.BackupVolumeBackupsInner
+		isValid         bool
+		expectedRequest iaas.ApiCreateVolumeRequest
+	}{
+		{
+			description:  "base",
+			volumeBackup: &serverbackup.BackupVolumeBackupsInner{Id: utils.Ptr(testVolumeBackupId), Size: utils.Ptr(int64(20))},
+			isValid:      true,
+			expectedRequest: testIaasClient.CreateVolume(testCtx, testProjectId).CreateVolumePayload(iaas.CreateVolumePayload{
+				AvailabilityZone: utils.Ptr("eu01-1"),
+				Name:             utils.Ptr("restore-" + testVolumeBackupId),
+				Size:             utils.Ptr(int64(20)),
+				Labels: utils.Ptr(map[string]interface{}{
+					restoredFromBackupLabel: testBackupId,
+				}),
+			}),
+		},
+		{
+			description:  "size unknown",
+			volumeBackup: &serverbackup.BackupVolumeBackupsInner{Id: utils.Ptr(testVolumeBackupId)},
+			isValid:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			request, err := buildCreateVolumeRequest(testCtx, fixtureInputModel(), testIaasClient, "eu01-1", tt.volumeBackup)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRestoreVolumeBackupRequest(t *testing.T) {
+	expectedRequest := testClient.RestoreVolumeBackup(testCtx, testProjectId, testServerId, testRegion, testBackupId, testVolumeBackupId).
+		RestoreVolumeBackupPayload(serverbackup.RestoreVolumeBackupPayload{RestoreVolumeId: utils.Ptr(testVolumeId)})
+
+	request := buildRestoreVolumeBackupRequest(testCtx, fixtureInputModel(), testClient, testVolumeBackupId, testVolumeId)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
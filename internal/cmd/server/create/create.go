@@ -15,6 +15,8 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/userdata"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
@@ -41,6 +43,8 @@ const (
 	securityGroupsFlag                = "security-groups"
 	serviceAccountEmailsFlag          = "service-account-emails"
 	userDataFlag                      = "user-data"
+	userDataVarFlag                   = "user-data-var"
+	userDataGzipFlag                  = "user-data-gzip"
 	volumesFlag                       = "volumes"
 )
 
@@ -62,7 +66,7 @@ type inputModel struct {
 	NetworkInterfaceIds           *[]string
 	SecurityGroups                *[]string
 	ServiceAccountMails           *[]string
-	UserData                      *string
+	UserData                      *[]byte
 	Volumes                       *[]string
 }
 
@@ -109,6 +113,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Create a server with user data (cloud-init)`,
 				`$ stackit server create --machine-type t1.1 --name server1 --boot-volume-source-id xxx --boot-volume-source-type image --boot-volume-size 64 --user-data @path/to/file.yaml")`,
 			),
+			examples.NewExample(
+				`Create a server with user data combined from a cloud-config and a script, rendering the variable "hostname" in them`,
+				`$ stackit server create --machine-type t1.1 --name server1 --image-id xxx --user-data @base.yaml --user-data @app.sh --user-data-var hostname=server1`,
+			),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -129,6 +137,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				projectLabel = model.ProjectId
 			}
 
+			// Check quotas before creating the server
+			machineType, err := apiClient.GetMachineType(ctx, model.ProjectId, *model.MachineType).Execute()
+			if err != nil {
+				params.Printer.Debug(print.WarningLevel, "get machine type for quota check: %v", err)
+			}
+			err = quota.Preflight(ctx, params.Printer, apiClient, model.ProjectId, requestedQuotas(model, machineType))
+			if err != nil {
+				return err
+			}
+
 			if !model.AssumeYes {
 				prompt := fmt.Sprintf("Are you sure you want to create a server for project %q?", projectLabel)
 				err = params.Printer.PromptForConfirmation(prompt)
@@ -180,7 +198,9 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSlice(networkInterfaceIdsFlag, []string{}, "List of network interface IDs for the initial networking setup for the server creation")
 	cmd.Flags().StringSlice(securityGroupsFlag, []string{}, "The initial security groups for the server creation")
 	cmd.Flags().StringSlice(serviceAccountEmailsFlag, []string{}, "List of the service account mails")
-	cmd.Flags().Var(flags.ReadFromFileFlag(), userDataFlag, "User data that is passed via cloud-init to the server")
+	cmd.Flags().StringArray(userDataFlag, []string{}, `User data that is passed via cloud-init to the server. Values starting with "@" are read from file. Can be repeated to combine several parts, e.g. a cloud-config and a script, into a multipart MIME document`)
+	cmd.Flags().StringToString(userDataVarFlag, nil, "Variables the user data is rendered with as Go template, e.g. '--user-data-var hostname=server1' replaces {{ .hostname }}")
+	cmd.Flags().Bool(userDataGzipFlag, false, "Compress the user data with gzip, for user data close to the size limit")
 	cmd.Flags().StringSlice(volumesFlag, []string{}, "The list of volumes attached to the server")
 
 	err := flags.MarkFlagsRequired(cmd, nameFlag, machineTypeFlag)
@@ -263,10 +283,15 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		NetworkInterfaceIds:           flags.FlagToStringSlicePointer(p, cmd, networkInterfaceIdsFlag),
 		SecurityGroups:                flags.FlagToStringSlicePointer(p, cmd, securityGroupsFlag),
 		ServiceAccountMails:           flags.FlagToStringSlicePointer(p, cmd, serviceAccountEmailsFlag),
-		UserData:                      flags.FlagToStringPointer(p, cmd, userDataFlag),
 		Volumes:                       flags.FlagToStringSlicePointer(p, cmd, volumesFlag),
 	}
 
+	userData, err := parseUserData(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+	model.UserData = userData
+
 	if p.IsVerbosityDebug() {
 		modelStr, err := print.BuildDebugStrFromInputModel(model)
 		if err != nil {
@@ -279,6 +304,36 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 	return &model, nil
 }
 
+// parseUserData builds the user data from the user data flags and prints the warnings of its validation.
+// Returns nil if no user data is set.
+func parseUserData(p *print.Printer, cmd *cobra.Command) (*[]byte, error) {
+	values := flags.FlagToStringArrayValue(p, cmd, userDataFlag)
+	vars := flags.FlagToStringToStringPointer(p, cmd, userDataVarFlag)
+	compress := flags.FlagToBoolValue(p, cmd, userDataGzipFlag)
+	if len(values) == 0 {
+		if vars != nil || compress {
+			return nil, &cliErr.FlagValidationError{
+				Flag:    userDataFlag,
+				Details: fmt.Sprintf("must be set when using --%s or --%s", userDataVarFlag, userDataGzipFlag),
+			}
+		}
+		return nil, nil
+	}
+
+	parts, err := userdata.ReadParts(values)
+	if err != nil {
+		return nil, err
+	}
+	userData, warnings, err := userdata.Build(parts, utils.PtrValue(vars), compress)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		p.Warn("%s\n", warning)
+	}
+	return &userData, nil
+}
+
 func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiCreateServerRequest {
 	req := apiClient.CreateServer(ctx, model.ProjectId)
 	var labelsMap *map[string]interface{}
@@ -290,11 +345,6 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APICli
 		}
 	}
 
-	var userData *[]byte
-	if model.UserData != nil {
-		userData = utils.Ptr([]byte(*model.UserData))
-	}
-
 	payload := iaas.CreateServerPayload{
 		Name:             model.Name,
 		MachineType:      model.MachineType,
@@ -305,7 +355,7 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APICli
 		KeypairName:         model.KeypairName,
 		SecurityGroups:      model.SecurityGroups,
 		ServiceAccountMails: model.ServiceAccountMails,
-		UserData:            userData,
+		UserData:            model.UserData,
 		Volumes:             model.Volumes,
 		Labels:              labelsMap,
 	}
@@ -340,6 +390,26 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APICli
 	return req.CreateServerPayload(payload)
 }
 
+// requestedQuotas returns the amounts of the project quotas the server uses.
+// The machine type is nil if it couldn't be read, then its cores and RAM aren't requested.
+func requestedQuotas(model *inputModel, machineType *iaas.MachineType) map[string]int64 {
+	requested := map[string]int64{}
+	if machineType != nil {
+		requested[quota.Vcpu] = utils.PtrValue(machineType.Vcpus)
+		requested[quota.Ram] = utils.PtrValue(machineType.Ram)
+	}
+	// a new boot volume is only created from an image, a volume source is attached as is
+	if model.BootVolumeSourceType != nil && *model.BootVolumeSourceType == "image" {
+		requested[quota.Volumes] = 1
+		requested[quota.Gigabytes] = utils.PtrValue(model.BootVolumeSize)
+	}
+	// a network interface is created in the network, given network interfaces already exist
+	if model.NetworkId != nil {
+		requested[quota.Nics] = 1
+	}
+	return requested
+}
+
 func outputResult(p *print.Printer, outputFormat, projectLabel string, server *iaas.Server) error {
 	if server == nil {
 		return fmt.Errorf("server response is empty")
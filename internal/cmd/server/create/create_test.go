@@ -7,6 +7,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
@@ -75,7 +76,7 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		NetworkId:                     utils.Ptr(testNetworkId),
 		SecurityGroups:                utils.Ptr([]string{"test-security-groups"}),
 		ServiceAccountMails:           utils.Ptr([]string{"test-service-account"}),
-		UserData:                      utils.Ptr("test-user-data"),
+		UserData:                      utils.Ptr([]byte("test-user-data")),
 		Volumes:                       utils.Ptr([]string{testVolumeId}),
 		Labels: utils.Ptr(map[string]string{
 			"key": "value",
@@ -195,6 +196,32 @@ func TestParseInput(t *testing.T) {
 				model.Volumes = nil
 			}),
 		},
+		{
+			description: "user data with variables",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[userDataFlag] = "#cloud-config\nhostname: {{ .hostname }}\n"
+				flagValues[userDataVarFlag] = "hostname=server1"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.UserData = utils.Ptr([]byte("#cloud-config\nhostname: server1\n"))
+			}),
+		},
+		{
+			description: "user data invalid cloud-config",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[userDataFlag] = "#cloud-config\npackages: nginx\n"
+			}),
+			isValid: false,
+		},
+		{
+			description: "user data variables without user data",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, userDataFlag)
+				flagValues[userDataVarFlag] = "hostname=server1"
+			}),
+			isValid: false,
+		},
 		{
 			description: "machine type missing",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
@@ -410,6 +437,47 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestRequestedQuotas(t *testing.T) {
+	tests := []struct {
+		description string
+		model       *inputModel
+		machineType *iaas.MachineType
+		expected    map[string]int64
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			machineType: &iaas.MachineType{Vcpus: utils.Ptr(int64(2)), Ram: utils.Ptr(int64(4096))},
+			expected:    map[string]int64{quota.Vcpu: 2, quota.Ram: 4096, quota.Nics: 1},
+		},
+		{
+			description: "boot volume from image",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.BootVolumeSourceType = utils.Ptr("image")
+				model.NetworkId = nil
+			}),
+			machineType: &iaas.MachineType{Vcpus: utils.Ptr(int64(2)), Ram: utils.Ptr(int64(4096))},
+			expected:    map[string]int64{quota.Vcpu: 2, quota.Ram: 4096, quota.Volumes: 1, quota.Gigabytes: 5},
+		},
+		{
+			description: "unknown machine type",
+			model:       fixtureInputModel(),
+			machineType: nil,
+			expected:    map[string]int64{quota.Nics: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			requested := requestedQuotas(tt.model, tt.machineType)
+			diff := cmp.Diff(requested, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestOutputResult(t *testing.T) {
 	type args struct {
 		outputFormat string
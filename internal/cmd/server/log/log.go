@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
@@ -26,13 +29,20 @@ const (
 	serverIdArg = "SERVER_ID"
 
 	lengthLimitFlag    = "length"
+	followFlag         = "follow"
+	intervalFlag       = "interval"
 	defaultLengthLimit = 2000 // lines
+
+	defaultInterval = "5s"
+	minInterval     = 2 * time.Second
 )
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 	ServerId string
 	Length   *int64
+	Follow   bool
+	Interval time.Duration
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -54,6 +64,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Get server console log for the server with ID "xxx" in JSON format`,
 				"$ stackit server log xxx --output-format json",
 			),
+			examples.NewExample(
+				`Follow the server console log for the server with ID "xxx", printing new lines as they appear`,
+				"$ stackit server log xxx --follow",
+			),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -76,6 +90,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				serverLabel = model.ServerId
 			}
 
+			if model.Follow {
+				return followLog(ctx, params.Printer, model, apiClient, serverLabel)
+			}
+
 			// Call API
 			req := buildRequest(ctx, model, apiClient)
 			resp, err := req.Execute()
@@ -101,6 +119,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64(lengthLimitFlag, defaultLengthLimit, "Maximum number of lines to list")
+	cmd.Flags().BoolP(followFlag, "f", false, "Print new log lines as they appear until the command is interrupted")
+	cmd.Flags().String(intervalFlag, defaultInterval, `Polling interval when following the log, e.g. "5s" or "1m"`)
 }
 
 func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
@@ -119,10 +139,33 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		}
 	}
 
+	interval, err := time.ParseDuration(flags.FlagWithDefaultToStringValue(p, cmd, intervalFlag))
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: `must be a duration, e.g. "5s" or "1m"`,
+		}
+	}
+	if interval < minInterval {
+		return nil, &errors.FlagValidationError{
+			Flag:    intervalFlag,
+			Details: fmt.Sprintf("must be at least %s", minInterval),
+		}
+	}
+
 	model := inputModel{
 		GlobalFlagModel: globalFlags,
 		ServerId:        serverId,
 		Length:          utils.Ptr(length),
+		Follow:          flags.FlagToBoolValue(p, cmd, followFlag),
+		Interval:        interval,
+	}
+
+	if model.Follow && model.OutputFormat != "" && model.OutputFormat != print.PrettyOutputFormat {
+		return nil, &errors.FlagValidationError{
+			Flag:    followFlag,
+			Details: fmt.Sprintf("can't be used with the %q output format", model.OutputFormat),
+		}
 	}
 
 	if p.IsVerbosityDebug() {
@@ -141,6 +184,72 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APICli
 	return apiClient.GetServerLog(ctx, model.ProjectId, model.ServerId)
 }
 
+// followLog prints the most recent lines of the log and then polls it, printing the lines which were added, until the command is interrupted
+func followLog(ctx context.Context, p *print.Printer, model *inputModel, apiClient *iaas.APIClient, serverLabel string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(model.Interval)
+	defer ticker.Stop()
+
+	p.Info("Following log for server %q, press Ctrl+C to stop\n", serverLabel)
+	var last []string
+	first := true
+	for {
+		resp, err := buildRequest(ctx, model, apiClient).Execute()
+		switch {
+		case err != nil && ctx.Err() == nil:
+			// Errors are only reported, so that a temporary failure doesn't stop following
+			p.Warn("server log: %v\n", err)
+		case err == nil:
+			current := completeLines(resp.GetOutput())
+			lines := newLines(last, current)
+			if first && len(lines) > int(*model.Length) {
+				lines = lines[len(lines)-int(*model.Length):]
+			}
+			for _, line := range lines {
+				p.Outputln(line)
+			}
+			last = current
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// completeLines splits the log into lines, leaving out the last line if it isn't terminated yet
+func completeLines(log string) []string {
+	end := strings.LastIndex(log, "\n")
+	if end < 0 {
+		return []string{}
+	}
+	return strings.Split(log[:end], "\n")
+}
+
+// newLines returns the lines of current which were not in previous.
+// The log may be truncated at the beginning once it grows too large, so the longest end of previous which current starts with is skipped.
+func newLines(previous, current []string) []string {
+	for overlap := min(len(previous), len(current)); overlap > 0; overlap-- {
+		if equalLines(previous[len(previous)-overlap:], current[:overlap]) {
+			return current[overlap:]
+		}
+	}
+	return current
+}
+
+func equalLines(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func outputResult(p *print.Printer, outputFormat, serverLabel, log string) error {
 	switch outputFormat {
 	case print.JSONOutputFormat:
@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
@@ -53,6 +54,7 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		},
 		ServerId: testServerId,
 		Length:   utils.Ptr(int64(3000)),
+		Interval: 5 * time.Second,
 	}
 	for _, mod := range mods {
 		mod(model)
@@ -138,6 +140,44 @@ func TestParseInput(t *testing.T) {
 				model.Length = utils.Ptr(int64(2000))
 			}),
 		},
+		{
+			description: "follow",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[followFlag] = "true"
+				flagValues[intervalFlag] = "10s"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Follow = true
+				model.Interval = 10 * time.Second
+			}),
+		},
+		{
+			description: "follow with json output",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[followFlag] = "true"
+				flagValues[globalflags.OutputFormatFlag] = print.JSONOutputFormat
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "five"
+			}),
+			isValid: false,
+		},
+		{
+			description: "interval too short",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[intervalFlag] = "1s"
+			}),
+			isValid: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -194,6 +234,101 @@ func TestParseInput(t *testing.T) {
 	}
 }
 
+func TestCompleteLines(t *testing.T) {
+	tests := []struct {
+		description   string
+		log           string
+		expectedLines []string
+	}{
+		{
+			description:   "empty",
+			log:           "",
+			expectedLines: []string{},
+		},
+		{
+			description:   "unterminated line",
+			log:           "booting",
+			expectedLines: []string{},
+		},
+		{
+			description:   "terminated lines",
+			log:           "line 1\nline 2\n",
+			expectedLines: []string{"line 1", "line 2"},
+		},
+		{
+			description:   "last line unterminated",
+			log:           "line 1\nline 2\nline",
+			expectedLines: []string{"line 1", "line 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			lines := completeLines(tt.log)
+			diff := cmp.Diff(lines, tt.expectedLines)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewLines(t *testing.T) {
+	tests := []struct {
+		description   string
+		previous      []string
+		current       []string
+		expectedLines []string
+	}{
+		{
+			description:   "first poll",
+			previous:      nil,
+			current:       []string{"a", "b"},
+			expectedLines: []string{"a", "b"},
+		},
+		{
+			description:   "no new lines",
+			previous:      []string{"a", "b"},
+			current:       []string{"a", "b"},
+			expectedLines: []string{},
+		},
+		{
+			description:   "appended lines",
+			previous:      []string{"a", "b"},
+			current:       []string{"a", "b", "c", "d"},
+			expectedLines: []string{"c", "d"},
+		},
+		{
+			description:   "truncated at the beginning",
+			previous:      []string{"a", "b", "c"},
+			current:       []string{"b", "c", "d"},
+			expectedLines: []string{"d"},
+		},
+		{
+			description:   "repeated lines",
+			previous:      []string{"x", "x"},
+			current:       []string{"x", "x", "x"},
+			expectedLines: []string{"x"},
+		},
+		{
+			description:   "no overlap",
+			previous:      []string{"a", "b"},
+			current:       []string{"c", "d"},
+			expectedLines: []string{"c", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			lines := newLines(tt.previous, tt.current)
+			diff := cmp.Diff(lines, tt.expectedLines)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestBuildRequest(t *testing.T) {
 	tests := []struct {
 		description     string
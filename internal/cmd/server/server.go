@@ -19,10 +19,13 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/rescue"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/resize"
 	serviceaccount "github.com/stackitcloud/stackit-cli/internal/cmd/server/service-account"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/server/ssh"
+	sshconfig "github.com/stackitcloud/stackit-cli/internal/cmd/server/ssh-config"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/start"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/stop"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/unrescue"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/update"
+	userdata "github.com/stackitcloud/stackit-cli/internal/cmd/server/user-data"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server/volume"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
@@ -66,4 +69,7 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(unrescue.NewCmd(params))
 	cmd.AddCommand(osUpdate.NewCmd(params))
 	cmd.AddCommand(machinetype.NewCmd(params))
+	cmd.AddCommand(ssh.NewCmd(params))
+	cmd.AddCommand(sshconfig.NewCmd(params))
+	cmd.AddCommand(userdata.NewCmd(params))
 }
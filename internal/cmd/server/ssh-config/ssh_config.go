@@ -0,0 +1,199 @@
+package sshconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasSsh "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ssh"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+const (
+	userFlag       = "user"
+	bastionFlag    = "bastion"
+	hostPrefixFlag = "host-prefix"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	User          string
+	Bastion       string
+	HostPrefix    string
+	IdentityFiles map[string]string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh-config",
+		Short: "Generates an ssh config for all servers of a project",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Generates an ssh config block for every server of a project, named like the server, which can be added to ~/.ssh/config.",
+			`Servers are reached like with "stackit server ssh": through their public IP if they have one, otherwise through the bastion server.`,
+			"Servers which can't be reached are skipped with a warning.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Generate an ssh config for all servers of the project with ID "xxx"`,
+				"$ stackit server ssh-config --project-id xxx"),
+			examples.NewExample(
+				`Add the servers to ~/.ssh/config, prefixed with "dev-", reaching servers without public IP through the server named "bastion"`,
+				"$ stackit server ssh-config --host-prefix dev- --bastion bastion >> ~/.ssh/config"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			resp, err := apiClient.ListServers(ctx, model.ProjectId).Details(true).Execute()
+			if err != nil {
+				return fmt.Errorf("list servers: %w", err)
+			}
+
+			hosts, err := buildHosts(params.Printer, model, utils.PtrValue(resp.Items))
+			if err != nil {
+				return err
+			}
+			return outputResult(params.Printer, model.OutputFormat, hosts)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(userFlag, "l", "", `User to log in as. If not set, the "ssh_user" config option is used`)
+	cmd.Flags().String(bastionFlag, "", `ID or name of the server to reach servers without public IP through. If not set, the "ssh_bastion_server" config option is used`)
+	cmd.Flags().String(hostPrefixFlag, "", "Prefix of the host names in the ssh config")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	identityFiles, err := iaasSsh.ParseIdentityFiles(viper.GetString(config.SSHIdentityFilesKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse config option %q: %w", config.SSHIdentityFilesKey, err)
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		User:            flags.FlagToStringValue(p, cmd, userFlag),
+		Bastion:         flags.FlagToStringValue(p, cmd, bastionFlag),
+		HostPrefix:      flags.FlagToStringValue(p, cmd, hostPrefixFlag),
+		IdentityFiles:   identityFiles,
+	}
+	if model.User == "" {
+		model.User = viper.GetString(config.SSHUserKey)
+	}
+	if model.Bastion == "" {
+		model.Bastion = viper.GetString(config.SSHBastionServerKey)
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildHosts(p *print.Printer, model *inputModel, servers []iaas.Server) ([]iaasSsh.Host, error) {
+	opts := &iaasSsh.Options{
+		User:          model.User,
+		IdentityFiles: model.IdentityFiles,
+		KeyDir:        iaasSsh.DefaultKeyDir,
+	}
+
+	var bastion *iaasSsh.Host
+	if model.Bastion != "" {
+		for i := range servers {
+			if utils.PtrString(servers[i].Id) != model.Bastion && utils.PtrString(servers[i].Name) != model.Bastion {
+				continue
+			}
+			if bastion != nil {
+				return nil, fmt.Errorf("found several servers named %q, use the ID of the bastion server instead", model.Bastion)
+			}
+			host, err := iaasSsh.NewHost(&servers[i], opts, nil)
+			if err != nil {
+				return nil, fmt.Errorf("bastion server: %w", err)
+			}
+			host.Alias = model.HostPrefix + host.Alias
+			bastion = host
+		}
+		if bastion == nil {
+			return nil, fmt.Errorf("bastion server %q not found", model.Bastion)
+		}
+	}
+
+	hosts := []iaasSsh.Host{}
+	for i := range servers {
+		if bastion != nil && utils.PtrString(servers[i].Id) == bastion.ServerId {
+			hosts = append(hosts, *bastion)
+			continue
+		}
+		host, err := iaasSsh.NewHost(&servers[i], opts, bastion)
+		if err != nil {
+			p.Warn("skipping server %q: %v\n", utils.PtrString(servers[i].Id), err)
+			continue
+		}
+		host.Alias = model.HostPrefix + host.Alias
+		hosts = append(hosts, *host)
+	}
+	return hosts, nil
+}
+
+func outputResult(p *print.Printer, outputFormat string, hosts []iaasSsh.Host) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(hosts, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal ssh hosts: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(hosts, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal ssh hosts: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		p.Outputf("%s", iaasSsh.Config(hosts))
+
+		return nil
+	}
+}
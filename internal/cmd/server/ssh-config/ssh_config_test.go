@@ -0,0 +1,279 @@
+package sshconfig
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	iaasSsh "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ssh"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var testProjectId = uuid.NewString()
+var testBastionId = uuid.NewString()
+var testServerId = uuid.NewString()
+var testPrivateServerId = uuid.NewString()
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		userFlag:                  "ubuntu",
+		bastionFlag:               "bastion",
+		hostPrefixFlag:            "dev-",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		User:          "ubuntu",
+		Bastion:       "bastion",
+		HostPrefix:    "dev-",
+		IdentityFiles: map[string]string{},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureServers() []iaas.Server {
+	return []iaas.Server{
+		{
+			Id:   utils.Ptr(testBastionId),
+			Name: utils.Ptr("bastion"),
+			Nics: &[]iaas.ServerNetwork{{Ipv4: utils.Ptr("10.0.0.2"), PublicIp: utils.Ptr("192.0.2.1")}},
+		},
+		{
+			Id:   utils.Ptr(testServerId),
+			Name: utils.Ptr("web"),
+			Nics: &[]iaas.ServerNetwork{{Ipv4: utils.Ptr("10.0.0.3"), PublicIp: utils.Ptr("192.0.2.2")}},
+		},
+		{
+			Id:   utils.Ptr(testPrivateServerId),
+			Name: utils.Ptr("db"),
+			Nics: &[]iaas.ServerNetwork{{Ipv4: utils.Ptr("10.0.0.4")}},
+		},
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[globalflags.ProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildHosts(t *testing.T) {
+	bastion := iaasSsh.Host{Alias: "dev-bastion", ServerId: testBastionId, HostName: "192.0.2.1", User: "ubuntu"}
+
+	tests := []struct {
+		description   string
+		model         *inputModel
+		servers       []iaas.Server
+		isValid       bool
+		expectedHosts []iaasSsh.Host
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			servers:     fixtureServers(),
+			isValid:     true,
+			expectedHosts: []iaasSsh.Host{
+				bastion,
+				{Alias: "dev-web", ServerId: testServerId, HostName: "192.0.2.2", User: "ubuntu"},
+				{Alias: "dev-db", ServerId: testPrivateServerId, HostName: "10.0.0.4", User: "ubuntu", ProxyJump: &bastion},
+			},
+		},
+		{
+			description: "bastion by id",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Bastion = testBastionId
+			}),
+			servers: fixtureServers(),
+			isValid: true,
+			expectedHosts: []iaasSsh.Host{
+				bastion,
+				{Alias: "dev-web", ServerId: testServerId, HostName: "192.0.2.2", User: "ubuntu"},
+				{Alias: "dev-db", ServerId: testPrivateServerId, HostName: "10.0.0.4", User: "ubuntu", ProxyJump: &bastion},
+			},
+		},
+		{
+			description: "no bastion",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Bastion = ""
+				model.HostPrefix = ""
+			}),
+			servers: fixtureServers(),
+			isValid: true,
+			expectedHosts: []iaasSsh.Host{
+				{Alias: "bastion", ServerId: testBastionId, HostName: "192.0.2.1", User: "ubuntu"},
+				{Alias: "web", ServerId: testServerId, HostName: "192.0.2.2", User: "ubuntu"},
+			},
+		},
+		{
+			description: "bastion not found",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Bastion = "other"
+			}),
+			servers: fixtureServers(),
+			isValid: false,
+		},
+		{
+			description: "no servers",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Bastion = ""
+			}),
+			servers:       []iaas.Server{},
+			isValid:       true,
+			expectedHosts: []iaasSsh.Host{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			hosts, err := buildHosts(p, tt.model, tt.servers)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("build hosts: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(hosts, tt.expectedHosts)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		hosts        []iaasSsh.Host
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "set hosts",
+			args: args{
+				hosts: []iaasSsh.Host{{Alias: "web", ServerId: testServerId, HostName: "192.0.2.2"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				hosts:        []iaasSsh.Host{{Alias: "web", ServerId: testServerId, HostName: "192.0.2.2"}},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.hosts); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package ssh
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasSsh "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/ssh"
+)
+
+const (
+	serverArg = "SERVER"
+
+	userFlag         = "user"
+	bastionFlag      = "bastion"
+	identityFileFlag = "identity-file"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Server        string
+	User          string
+	Bastion       string
+	IdentityFile  string
+	IdentityFiles map[string]string
+	SSHArgs       []string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("ssh %s [-- SSH_ARGS...]", serverArg),
+		Short: "Connects to a server with ssh",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Connects to a server with the local ssh client. The server is given by its ID or name.",
+			"The public IP of the server is used if it has one. Otherwise its private IP is reached through the bastion server, which is set with --bastion or the \"ssh_bastion_server\" config option.",
+			"The private key of the key pair of the server is taken from the \"ssh_identity_files\" config option, or from ~/.ssh/KEY_PAIR_NAME if it exists. The user is taken from --user or the \"ssh_user\" config option.",
+			`Arguments after "--" are passed to ssh after the destination, e.g. ssh options or a command to run. The CLI exits with the exit status of ssh.`,
+		),
+		Args: args.SingleArgWithPassthrough(serverArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Connect to the server named "web"`,
+				"$ stackit server ssh web"),
+			examples.NewExample(
+				`Connect to the server with ID "xxx" as user "ubuntu" through the bastion server named "bastion"`,
+				"$ stackit server ssh xxx --user ubuntu --bastion bastion"),
+			examples.NewExample(
+				`Run "uptime" on the server named "web"`,
+				"$ stackit server ssh web -- uptime"),
+			examples.NewExample(
+				`Forward local port 8080 to port 80 of the server named "web"`,
+				"$ stackit server ssh web -- -N -L 8080:localhost:80"),
+			examples.NewExample(
+				`Map the key pair "default" to a local private key and set the default user and bastion server`,
+				"$ stackit config set --ssh-identity-files default=~/.ssh/id_ed25519 --ssh-user ubuntu --ssh-bastion-server bastion"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			host, err := buildHost(ctx, model, apiClient)
+			if err != nil {
+				return err
+			}
+
+			sshPath, err := exec.LookPath("ssh")
+			if err != nil {
+				return fmt.Errorf("find ssh client, make sure it is installed: %w", err)
+			}
+			sshArgs := append(host.Args(), model.SSHArgs...)
+
+			params.Printer.Debug(print.DebugLevel, "running %s with arguments %q", sshPath, sshArgs)
+			execCmd := exec.Command(sshPath, sshArgs...) // #nosec G204
+			execCmd.Stdin = os.Stdin
+			execCmd.Stdout = os.Stdout
+			execCmd.Stderr = os.Stderr
+			return runError(execCmd.Run())
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+// runError passes the exit status of ssh through, so that the CLI exits with it
+func runError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if goerrors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return &errors.ExitCodeError{
+			Command: "ssh",
+			Code:    exitErr.ExitCode(),
+		}
+	}
+	return fmt.Errorf("run ssh: %w", err)
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(userFlag, "l", "", `User to log in as. If not set, the "ssh_user" config option is used`)
+	cmd.Flags().String(bastionFlag, "", `ID or name of the server to reach servers without public IP through. If not set, the "ssh_bastion_server" config option is used`)
+	cmd.Flags().StringP(identityFileFlag, "i", "", "Path of the private key. If not set, the private key is looked up by the key pair name of the server")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	identityFiles, err := iaasSsh.ParseIdentityFiles(viper.GetString(config.SSHIdentityFilesKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse config option %q: %w", config.SSHIdentityFilesKey, err)
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Server:          inputArgs[0],
+		User:            flags.FlagToStringValue(p, cmd, userFlag),
+		Bastion:         flags.FlagToStringValue(p, cmd, bastionFlag),
+		IdentityFile:    flags.FlagToStringValue(p, cmd, identityFileFlag),
+		IdentityFiles:   identityFiles,
+	}
+	if model.User == "" {
+		model.User = viper.GetString(config.SSHUserKey)
+	}
+	if model.Bastion == "" {
+		model.Bastion = viper.GetString(config.SSHBastionServerKey)
+	}
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		model.SSHArgs = inputArgs[dash:]
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildHost(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) (*iaasSsh.Host, error) {
+	opts := &iaasSsh.Options{
+		User:          model.User,
+		IdentityFiles: model.IdentityFiles,
+		KeyDir:        iaasSsh.DefaultKeyDir,
+	}
+
+	server, err := iaasSsh.FindServer(ctx, apiClient, model.ProjectId, model.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	// The bastion server is only needed for servers without public IP
+	var bastion *iaasSsh.Host
+	if iaasSsh.PublicIp(server) == "" && model.Bastion != "" {
+		bastionServer, err := iaasSsh.FindServer(ctx, apiClient, model.ProjectId, model.Bastion)
+		if err != nil {
+			return nil, fmt.Errorf("find bastion server: %w", err)
+		}
+		bastion, err = iaasSsh.NewHost(bastionServer, opts, nil)
+		if err != nil {
+			return nil, fmt.Errorf("bastion server: %w", err)
+		}
+	}
+
+	host, err := iaasSsh.NewHost(server, opts, bastion)
+	if err != nil {
+		return nil, err
+	}
+	if model.IdentityFile != "" {
+		host.IdentityFile = model.IdentityFile
+	}
+	return host, nil
+}
@@ -0,0 +1,265 @@
+package ssh
+
+import (
+	goerrors "errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testServerId = uuid.NewString()
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testServerId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		userFlag:                  "ubuntu",
+		bastionFlag:               "bastion",
+		identityFileFlag:          "~/.ssh/id_ed25519",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Server:        testServerId,
+		User:          "ubuntu",
+		Bastion:       "bastion",
+		IdentityFile:  "~/.ssh/id_ed25519",
+		IdentityFiles: map[string]string{},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "server name",
+			argValues:   []string{"my-server"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Server = "my-server"
+			}),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "too many arg values",
+			argValues:   []string{testServerId, "other"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[globalflags.ProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseInputPassthrough(t *testing.T) {
+	p := print.NewPrinter()
+	cmd := NewCmd(&params.CmdParams{Printer: p})
+	err := globalflags.Configure(cmd.Flags())
+	if err != nil {
+		t.Fatalf("configure global flags: %v", err)
+	}
+
+	err = cmd.ParseFlags([]string{"--project-id", testProjectId, testServerId, "--", "-L", "8080:localhost:80", "uptime"})
+	if err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	inputArgs := cmd.Flags().Args()
+	err = cmd.ValidateArgs(inputArgs)
+	if err != nil {
+		t.Fatalf("error validating args: %v", err)
+	}
+
+	model, err := parseInput(p, cmd, inputArgs)
+	if err != nil {
+		t.Fatalf("error parsing input: %v", err)
+	}
+	if model.Server != testServerId {
+		t.Fatalf("expected server %q, got %q", testServerId, model.Server)
+	}
+	diff := cmp.Diff(model.SSHArgs, []string{"-L", "8080:localhost:80", "uptime"})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestRunError(t *testing.T) {
+	shPath, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	tests := []struct {
+		description      string
+		err              error
+		expectedExitCode int
+		isValid          bool
+	}{
+		{
+			description: "no error",
+			err:         nil,
+			isValid:     true,
+		},
+		{
+			description:      "exit status",
+			err:              exec.Command(shPath, "-c", "exit 255").Run(), // #nosec G204
+			expectedExitCode: 255,
+			isValid:          false,
+		},
+		{
+			description: "other error",
+			err:         fmt.Errorf("executable not found"),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := runError(tt.err)
+			if tt.isValid {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			var exitCodeErr *errors.ExitCodeError
+			ok := goerrors.As(err, &exitCodeErr)
+			if tt.expectedExitCode == 0 {
+				if ok {
+					t.Fatalf("expected no exit code error, got %v", err)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected exit code error, got %v", err)
+			}
+			if exitCodeErr.Code != tt.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d", tt.expectedExitCode, exitCodeErr.Code)
+			}
+		})
+	}
+}
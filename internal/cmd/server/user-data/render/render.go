@@ -0,0 +1,164 @@
+package render
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/userdata"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	userDataFlag     = "user-data"
+	userDataVarFlag  = "user-data-var"
+	userDataGzipFlag = "user-data-gzip"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	UserData []string
+	Vars     map[string]string
+	Gzip     bool
+}
+
+type renderResult struct {
+	UserData string `json:"userData"`
+	// Encoding is "gzip+base64" for compressed user data, which can't be shown as text
+	Encoding string `json:"encoding,omitempty"`
+	Size     int    `json:"size"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Renders and validates server user data",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			`Renders and validates server user data, the same way as "stackit server create" does before submitting it.`,
+			`Parts starting with "#cloud-config" are validated against the cloud-init schema. Several parts are combined into a multipart MIME document.`,
+			"Compressed user data is shown base64 encoded.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Validate and show the user data from file "base.yaml"`,
+				"$ stackit server user-data render --user-data @base.yaml"),
+			examples.NewExample(
+				`Show the multipart user data combined from "base.yaml" and "app.sh", rendering the variable "hostname" in them`,
+				"$ stackit server user-data render --user-data @base.yaml --user-data @app.sh --user-data-var hostname=server1"),
+			examples.NewExample(
+				`Show the compressed user data from file "base.yaml"`,
+				"$ stackit server user-data render --user-data @base.yaml --user-data-gzip"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			parts, err := userdata.ReadParts(model.UserData)
+			if err != nil {
+				return err
+			}
+			userData, warnings, err := userdata.Build(parts, model.Vars, model.Gzip)
+			if err != nil {
+				return err
+			}
+			for _, warning := range warnings {
+				params.Printer.Warn("%s\n", warning)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, buildResult(userData, model.Gzip))
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray(userDataFlag, []string{}, `User data part. Values starting with "@" are read from file. Can be repeated to combine several parts into a multipart MIME document`)
+	cmd.Flags().StringToString(userDataVarFlag, nil, "Variables the user data is rendered with as Go template, e.g. '--user-data-var hostname=server1' replaces {{ .hostname }}")
+	cmd.Flags().Bool(userDataGzipFlag, false, "Compress the user data with gzip")
+
+	err := flags.MarkFlagsRequired(cmd, userDataFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		UserData:        flags.FlagToStringArrayValue(p, cmd, userDataFlag),
+		Vars:            utils.PtrValue(flags.FlagToStringToStringPointer(p, cmd, userDataVarFlag)),
+		Gzip:            flags.FlagToBoolValue(p, cmd, userDataGzipFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildResult(userData []byte, compressed bool) *renderResult {
+	if !compressed {
+		return &renderResult{
+			UserData: string(userData),
+			Size:     len(userData),
+		}
+	}
+	return &renderResult{
+		UserData: base64.StdEncoding.EncodeToString(userData),
+		Encoding: "gzip+base64",
+		Size:     len(userData),
+	}
+}
+
+func outputResult(p *print.Printer, outputFormat string, result *renderResult) error {
+	if result == nil {
+		return fmt.Errorf("user data is empty")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal user data: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal user data: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		if result.Encoding == "" {
+			// Output the user data exactly as it is submitted
+			p.Outputf("%s", result.UserData)
+			return nil
+		}
+		p.Outputln(result.UserData)
+
+		return nil
+	}
+}
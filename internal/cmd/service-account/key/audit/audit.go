@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/service-account/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/serviceaccount"
+)
+
+const (
+	olderThanDaysFlag = "older-than-days"
+	onlyActiveFlag    = "only-active"
+
+	defaultOlderThanDays = 90
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	OlderThanDays int64
+	OnlyActive    bool
+}
+
+// auditedKey is a key of a service account which is older than the given number of days
+type auditedKey struct {
+	ServiceAccountEmail string                                       `json:"serviceAccountEmail"`
+	AgeInDays           int64                                        `json:"ageInDays"`
+	Key                 serviceaccount.ServiceAccountKeyListResponse `json:"key"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Lists the keys of all service accounts which are older than a given number of days",
+		Long: fmt.Sprintf("%s\n%s",
+			"Lists the keys of all service accounts in the project which are older than a given number of days.",
+			`Old keys should be rotated, which can be done using the "stackit service-account key rotate" command.`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List the keys of all service accounts which are older than 90 days`,
+				"$ stackit service-account key audit"),
+			examples.NewExample(
+				`List the active keys of all service accounts which are older than 30 days`,
+				"$ stackit service-account key audit --older-than-days 30 --only-active"),
+			examples.NewExample(
+				`List the keys of all service accounts which are older than 90 days in JSON format`,
+				"$ stackit service-account key audit --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			resp, err := buildListServiceAccountsRequest(ctx, model, apiClient).Execute()
+			if err != nil {
+				return fmt.Errorf("list service accounts: %w", err)
+			}
+
+			now := time.Now()
+			keys := []auditedKey{}
+			for _, serviceAccount := range utils.PtrValue(resp.Items) {
+				email := utils.PtrString(serviceAccount.Email)
+				keysResp, err := buildListKeysRequest(ctx, model, apiClient, email).Execute()
+				if err != nil {
+					return fmt.Errorf("list keys of service account %s: %w", email, err)
+				}
+				keys = append(keys, filterKeys(model, email, utils.PtrValue(keysResp.Items), now)...)
+			}
+
+			if len(keys) == 0 {
+				projectLabel, err := projectname.GetProjectName(ctx, params.Printer, params.CliVersion, cmd)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get project name: %v", err)
+					projectLabel = model.ProjectId
+				}
+				params.Printer.Info("No service account keys older than %d days found for project %q\n", model.OlderThanDays, projectLabel)
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, keys)
+		},
+	}
+
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(olderThanDaysFlag, defaultOlderThanDays, "Minimum age of the listed keys in days")
+	cmd.Flags().Bool(onlyActiveFlag, false, "If set, only active keys are listed")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	olderThanDays := flags.FlagWithDefaultToInt64Value(p, cmd, olderThanDaysFlag)
+	if olderThanDays < 0 {
+		return nil, &errors.FlagValidationError{
+			Flag:    olderThanDaysFlag,
+			Details: "must not be negative",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		OlderThanDays:   olderThanDays,
+		OnlyActive:      flags.FlagToBoolValue(p, cmd, onlyActiveFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildListServiceAccountsRequest(ctx context.Context, model *inputModel, apiClient *serviceaccount.APIClient) serviceaccount.ApiListServiceAccountsRequest {
+	return apiClient.ListServiceAccounts(ctx, model.ProjectId)
+}
+
+func buildListKeysRequest(ctx context.Context, model *inputModel, apiClient *serviceaccount.APIClient, email string) serviceaccount.ApiListServiceAccountKeysRequest {
+	return apiClient.ListServiceAccountKeys(ctx, model.ProjectId, email)
+}
+
+// filterKeys returns the keys which were created at least the given number of days ago
+func filterKeys(model *inputModel, email string, keys []serviceaccount.ServiceAccountKeyListResponse, now time.Time) []auditedKey {
+	result := []auditedKey{}
+	for i := range keys {
+		key := keys[i]
+		if key.CreatedAt == nil {
+			continue
+		}
+		if model.OnlyActive && !utils.PtrValue(key.Active) {
+			continue
+		}
+		ageInDays := int64(now.Sub(*key.CreatedAt).Hours() / 24)
+		if ageInDays < model.OlderThanDays {
+			continue
+		}
+		result = append(result, auditedKey{
+			ServiceAccountEmail: email,
+			AgeInDays:           ageInDays,
+			Key:                 key,
+		})
+	}
+	return result
+}
+
+func outputResult(p *print.Printer, outputFormat string, keys []auditedKey) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(keys, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal service account keys: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(keys, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal service account keys: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("SERVICE ACCOUNT", "KEY ID", "ACTIVE", "CREATED_AT", "AGE (DAYS)", "VALID_UNTIL")
+		for i := range keys {
+			k := keys[i]
+			validUntil := "does not expire"
+			if k.Key.ValidUntil != nil {
+				validUntil = k.Key.ValidUntil.String()
+			}
+			table.AddRow(
+				k.ServiceAccountEmail,
+				utils.PtrString(k.Key.Id),
+				utils.PtrString(k.Key.Active),
+				utils.PtrString(k.Key.CreatedAt),
+				k.AgeInDays,
+				validUntil,
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,265 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/serviceaccount"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &serviceaccount.APIClient{}
+var testProjectId = uuid.NewString()
+var testServiceAccountEmail = "my-service-account-1234567@sa.stackit.cloud"
+var testNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag: testProjectId,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		OlderThanDays: defaultOlderThanDays,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "with values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[olderThanDaysFlag] = "30"
+				flagValues[onlyActiveFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.OlderThanDays = 30
+				model.OnlyActive = true
+			}),
+		},
+		{
+			description: "older than days negative",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[olderThanDaysFlag] = "-1"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[projectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildListKeysRequest(t *testing.T) {
+	expectedRequest := testClient.ListServiceAccountKeys(testCtx, testProjectId, testServiceAccountEmail)
+	request := buildListKeysRequest(testCtx, fixtureInputModel(), testClient, testServiceAccountEmail)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestFilterKeys(t *testing.T) {
+	oldActiveKey := serviceaccount.ServiceAccountKeyListResponse{
+		Id:        utils.Ptr("old-active"),
+		Active:    utils.Ptr(true),
+		CreatedAt: utils.Ptr(testNow.AddDate(0, 0, -100)),
+	}
+	oldInactiveKey := serviceaccount.ServiceAccountKeyListResponse{
+		Id:        utils.Ptr("old-inactive"),
+		Active:    utils.Ptr(false),
+		CreatedAt: utils.Ptr(testNow.AddDate(0, 0, -200)),
+	}
+	newKey := serviceaccount.ServiceAccountKeyListResponse{
+		Id:        utils.Ptr("new"),
+		Active:    utils.Ptr(true),
+		CreatedAt: utils.Ptr(testNow.AddDate(0, 0, -10)),
+	}
+	keyWithoutCreationDate := serviceaccount.ServiceAccountKeyListResponse{
+		Id: utils.Ptr("no-creation-date"),
+	}
+	keys := []serviceaccount.ServiceAccountKeyListResponse{oldActiveKey, oldInactiveKey, newKey, keyWithoutCreationDate}
+
+	tests := []struct {
+		description string
+		model       *inputModel
+		expected    []auditedKey
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			expected: []auditedKey{
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 100, Key: oldActiveKey},
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 200, Key: oldInactiveKey},
+			},
+		},
+		{
+			description: "only active",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.OnlyActive = true
+			}),
+			expected: []auditedKey{
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 100, Key: oldActiveKey},
+			},
+		},
+		{
+			description: "all keys",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.OlderThanDays = 0
+			}),
+			expected: []auditedKey{
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 100, Key: oldActiveKey},
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 200, Key: oldInactiveKey},
+				{ServiceAccountEmail: testServiceAccountEmail, AgeInDays: 10, Key: newKey},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := filterKeys(tt.model, testServiceAccountEmail, keys, testNow)
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		keys         []auditedKey
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "empty key in keys slice",
+			args: args{
+				keys: []auditedKey{{}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				keys:         []auditedKey{{ServiceAccountEmail: testServiceAccountEmail}},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.keys); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package key
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/audit"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/describe"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/rotate"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/service-account/key/update"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -31,4 +33,6 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(rotate.NewCmd(params))
+	cmd.AddCommand(audit.NewCmd(params))
 }
@@ -0,0 +1,357 @@
+package rotate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/fileutils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/service-account/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	sdkAuth "github.com/stackitcloud/stackit-sdk-go/core/auth"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/serviceaccount"
+)
+
+const (
+	serviceAccountEmailFlag = "email"
+	expiredInDaysFlag       = "expires-in-days"
+	publicKeyFlag           = "public-key"
+	previousKeyIdFlag       = "previous-key-id"
+	gracePeriodDaysFlag     = "grace-period-days"
+	credentialsFileFlag     = "credentials-file"
+	activateFlag            = "activate"
+	privateKeyPathFlag      = "private-key-path"
+
+	credentialsFilePermissions = 0o600
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	ServiceAccountEmail string
+	ExpiresInDays       *int64
+	PublicKey           *string
+	PreviousKeyIds      []string
+	GracePeriodDays     int64
+	CredentialsFile     *string
+	Activate            bool
+	PrivateKeyPath      *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotates the keys of a service account",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Rotates the keys of a service account.",
+			"A new key is created and, if provided, its credentials are written to the credentials file. The file is replaced atomically, so it is never left partially written.",
+			"Afterwards, the previous keys are deactivated. If a grace period is set, the previous keys remain active and expire after the grace period instead.",
+			`If no previous key IDs are provided, all other keys of the service account are considered previous keys. Previous keys which are inactive or expired are left unchanged, and a grace period never extends the expiry of a previous key.`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Rotate the keys of the service account with email "my-service-account-1234567@sa.stackit.cloud" and write the new key to "sa-key.json"`,
+				"$ stackit service-account key rotate --email my-service-account-1234567@sa.stackit.cloud --credentials-file sa-key.json"),
+			examples.NewExample(
+				`Rotate the key with ID "xxx" of the service account with email "my-service-account-1234567@sa.stackit.cloud", letting the previous key expire in 7 days`,
+				"$ stackit service-account key rotate --email my-service-account-1234567@sa.stackit.cloud --previous-key-id xxx --grace-period-days 7 --credentials-file sa-key.json"),
+			examples.NewExample(
+				`Rotate the keys of the service account with email "my-service-account-1234567@sa.stackit.cloud" and activate the new key in the STACKIT CLI`,
+				"$ stackit service-account key rotate --email my-service-account-1234567@sa.stackit.cloud --credentials-file sa-key.json --activate"),
+			examples.NewExample(
+				`Rotate the keys of the service account with email "my-service-account-1234567@sa.stackit.cloud" using a new public key and activate the new key in the STACKIT CLI with the corresponding private key`,
+				"$ stackit service-account key rotate --email my-service-account-1234567@sa.stackit.cloud --public-key @./public.pem --credentials-file sa-key.json --activate --private-key-path ./private.pem"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			keys, err := buildListRequest(ctx, model, apiClient).Execute()
+			if err != nil {
+				return fmt.Errorf("list service account keys: %w", err)
+			}
+			now := time.Now()
+			previousKeyIds, skippedKeyIds, err := getPreviousKeyIds(keys.Items, model.PreviousKeyIds, model.GracePeriodDays, now)
+			if err != nil {
+				return err
+			}
+			for _, keyId := range skippedKeyIds {
+				params.Printer.Info("Skipping previous key %q, which is inactive, expired or expires before the grace period ends\n", keyId)
+			}
+
+			if !model.AssumeYes {
+				previousKeysInfo := "Previous keys will be deactivated"
+				if model.GracePeriodDays > 0 {
+					previousKeysInfo = fmt.Sprintf("Previous keys will expire in %d days", model.GracePeriodDays)
+				}
+				prompt := fmt.Sprintf("Are you sure you want to rotate the keys of service account %s? %s (%d keys)", model.ServiceAccountEmail, previousKeysInfo, len(previousKeyIds))
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			resp, err := buildCreateRequest(ctx, model, apiClient, now).Execute()
+			if err != nil {
+				return fmt.Errorf("create service account key: %w", err)
+			}
+			params.Printer.Info("Created key for service account %s with ID %q\n", model.ServiceAccountEmail, utils.PtrString(resp.Id))
+
+			key, err := json.MarshalIndent(resp, "", "  ")
+			if err != nil {
+				return fmt.Errorf("marshal key: %w", err)
+			}
+			if model.CredentialsFile != nil {
+				err = fileutils.WriteToFileAtomically(*model.CredentialsFile, string(key), credentialsFilePermissions)
+				if err != nil {
+					return fmt.Errorf("write credentials file: %w", err)
+				}
+				params.Printer.Info("Wrote credentials of key %q to %q\n", utils.PtrString(resp.Id), *model.CredentialsFile)
+			} else {
+				params.Printer.Outputln(string(key))
+			}
+
+			for _, keyId := range previousKeyIds {
+				_, err = buildUpdatePreviousKeyRequest(ctx, model, apiClient, keyId, now).Execute()
+				if err != nil {
+					return fmt.Errorf("update previous service account key %q: %w", keyId, err)
+				}
+				if model.GracePeriodDays > 0 {
+					params.Printer.Info("Previous key %q will expire in %d days\n", keyId, model.GracePeriodDays)
+				} else {
+					params.Printer.Info("Deactivated previous key %q\n", keyId)
+				}
+			}
+
+			// The CLI is activated last, as the API client may still store the tokens of a previous key after each request
+			if model.Activate {
+				email, err := activateServiceAccount(params.Printer, model)
+				if err != nil {
+					return err
+				}
+				params.Printer.Info("Activated service account %s with key %q in the STACKIT CLI\n", email, utils.PtrString(resp.Id))
+			}
+			return nil
+		},
+	}
+
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(serviceAccountEmailFlag, "e", "", "Service account email")
+	cmd.Flags().Int64(expiredInDaysFlag, 0, "Number of days until expiration of the new key. When omitted, the key is valid until deleted")
+	cmd.Flags().Var(flags.ReadFromFileFlag(), publicKeyFlag, `Public key of the user generated RSA 2048 key-pair. Must be in x509 format. Can be a string or path to the .pem file, if prefixed with "@". If omitted, the service will generate a new key-pair`)
+	cmd.Flags().Var(flags.UUIDSliceFlag(), previousKeyIdFlag, "IDs of the previous keys to deactivate or expire. When omitted, all other keys of the service account are used")
+	cmd.Flags().Int64(gracePeriodDaysFlag, 0, "Number of days until the previous keys expire. When omitted, the previous keys are deactivated immediately")
+	cmd.Flags().String(credentialsFileFlag, "", "Path of the file the credentials of the new key are written to. When omitted, the credentials are printed")
+	cmd.Flags().Bool(activateFlag, false, "If set, activates the new key in the STACKIT CLI, like the \"auth activate-service-account\" command")
+	cmd.Flags().String(privateKeyPathFlag, "", "RSA private key path used to activate the new key. Required if a public key is provided together with the activate flag")
+
+	err := flags.MarkFlagsRequired(cmd, serviceAccountEmailFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	email := flags.FlagToStringValue(p, cmd, serviceAccountEmailFlag)
+	if email == "" {
+		return nil, &cliErr.FlagValidationError{
+			Flag:    serviceAccountEmailFlag,
+			Details: "can't be empty",
+		}
+	}
+
+	expiresInDays := flags.FlagToInt64Pointer(p, cmd, expiredInDaysFlag)
+	if expiresInDays != nil && *expiresInDays < 1 {
+		return nil, &cliErr.FlagValidationError{
+			Flag:    expiredInDaysFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	gracePeriodDays := flags.FlagToInt64Pointer(p, cmd, gracePeriodDaysFlag)
+	if gracePeriodDays != nil && *gracePeriodDays < 1 {
+		return nil, &cliErr.FlagValidationError{
+			Flag:    gracePeriodDaysFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	credentialsFile := flags.FlagToStringPointer(p, cmd, credentialsFileFlag)
+	publicKey := flags.FlagToStringPointer(p, cmd, publicKeyFlag)
+	privateKeyPath := flags.FlagToStringPointer(p, cmd, privateKeyPathFlag)
+	activate := flags.FlagToBoolValue(p, cmd, activateFlag)
+	if activate {
+		// The CLI is activated using the key written to the credentials file
+		if credentialsFile == nil {
+			return nil, &cliErr.FlagValidationError{
+				Flag:    credentialsFileFlag,
+				Details: fmt.Sprintf("must be set if %q is set", activateFlag),
+			}
+		}
+		// The private key is not returned by the API if a public key is provided
+		if publicKey != nil && privateKeyPath == nil {
+			return nil, &cliErr.FlagValidationError{
+				Flag:    privateKeyPathFlag,
+				Details: fmt.Sprintf("must be set if %q and %q are set", activateFlag, publicKeyFlag),
+			}
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel:     globalFlags,
+		ServiceAccountEmail: email,
+		ExpiresInDays:       expiresInDays,
+		PublicKey:           publicKey,
+		PreviousKeyIds:      flags.FlagToStringSliceValue(p, cmd, previousKeyIdFlag),
+		GracePeriodDays:     utils.PtrValue(gracePeriodDays),
+		CredentialsFile:     credentialsFile,
+		Activate:            activate,
+		PrivateKeyPath:      privateKeyPath,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildListRequest(ctx context.Context, model *inputModel, apiClient *serviceaccount.APIClient) serviceaccount.ApiListServiceAccountKeysRequest {
+	return apiClient.ListServiceAccountKeys(ctx, model.ProjectId, model.ServiceAccountEmail)
+}
+
+func buildCreateRequest(ctx context.Context, model *inputModel, apiClient *serviceaccount.APIClient, now time.Time) serviceaccount.ApiCreateServiceAccountKeyRequest {
+	req := apiClient.CreateServiceAccountKey(ctx, model.ProjectId, model.ServiceAccountEmail)
+
+	var validUntil *time.Time
+	if model.ExpiresInDays != nil {
+		validUntil = utils.Ptr(now.AddDate(0, 0, int(*model.ExpiresInDays)))
+	}
+
+	req = req.CreateServiceAccountKeyPayload(serviceaccount.CreateServiceAccountKeyPayload{
+		ValidUntil: validUntil,
+		PublicKey:  model.PublicKey,
+	})
+	return req
+}
+
+func buildUpdatePreviousKeyRequest(ctx context.Context, model *inputModel, apiClient *serviceaccount.APIClient, keyId string, now time.Time) serviceaccount.ApiPartialUpdateServiceAccountKeyRequest {
+	req := apiClient.PartialUpdateServiceAccountKey(ctx, model.ProjectId, model.ServiceAccountEmail, keyId)
+
+	payload := serviceaccount.PartialUpdateServiceAccountKeyPayload{}
+	if model.GracePeriodDays > 0 {
+		payload.ValidUntil = utils.Ptr(now.AddDate(0, 0, int(model.GracePeriodDays)))
+	} else {
+		payload.Active = utils.Ptr(false)
+	}
+
+	req = req.PartialUpdateServiceAccountKeyPayload(payload)
+	return req
+}
+
+// getPreviousKeyIds returns the IDs of the previous keys to update, which are the keys with the given IDs
+// or, without IDs, all listed keys. Inactive and expired keys are skipped, and with a grace period, so are
+// the keys which expire before it ends, so that their expiry isn't extended.
+func getPreviousKeyIds(keys *[]serviceaccount.ServiceAccountKeyListResponse, keyIds []string, gracePeriodDays int64, now time.Time) (previousKeyIds, skippedKeyIds []string, err error) {
+	listed := map[string]*serviceaccount.ServiceAccountKeyListResponse{}
+	allKeyIds := []string{}
+	for i := range utils.PtrValue(keys) {
+		key := &(*keys)[i]
+		if key.Id == nil {
+			continue
+		}
+		listed[*key.Id] = key
+		allKeyIds = append(allKeyIds, *key.Id)
+	}
+	if len(keyIds) == 0 {
+		keyIds = allKeyIds
+	}
+
+	previousKeyIds = []string{}
+	skippedKeyIds = []string{}
+	gracePeriodEnd := now.AddDate(0, 0, int(gracePeriodDays))
+	for _, keyId := range keyIds {
+		key, ok := listed[keyId]
+		if !ok {
+			return nil, nil, fmt.Errorf("the service account has no key with ID %q", keyId)
+		}
+		inactive := key.Active != nil && !*key.Active
+		expired := key.ValidUntil != nil && !key.ValidUntil.After(now)
+		expiresBeforeGracePeriodEnd := gracePeriodDays > 0 && key.ValidUntil != nil && !key.ValidUntil.After(gracePeriodEnd)
+		if inactive || expired || expiresBeforeGracePeriodEnd {
+			skippedKeyIds = append(skippedKeyIds, keyId)
+			continue
+		}
+		previousKeyIds = append(previousKeyIds, keyId)
+	}
+	return previousKeyIds, skippedKeyIds, nil
+}
+
+// activateServiceAccount authenticates the CLI using the new key, analogous to the "auth activate-service-account" command
+func activateServiceAccount(p *print.Printer, model *inputModel) (string, error) {
+	tokenCustomEndpoint := viper.GetString(config.TokenCustomEndpointKey)
+	err := auth.SetAuthField(auth.TOKEN_CUSTOM_ENDPOINT, tokenCustomEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("store custom token endpoint: %w", err)
+	}
+
+	cfg := &sdkConfig.Configuration{
+		ServiceAccountKeyPath: utils.PtrValue(model.CredentialsFile),
+		PrivateKeyPath:        utils.PtrValue(model.PrivateKeyPath),
+		TokenCustomUrl:        tokenCustomEndpoint,
+	}
+
+	rt, err := sdkAuth.SetupAuth(cfg)
+	if err != nil {
+		p.Debug(print.ErrorLevel, "setup auth: %v", err)
+		return "", &cliErr.ActivateServiceAccountError{}
+	}
+
+	email, _, err := auth.AuthenticateServiceAccount(p, rt, false)
+	if err != nil {
+		var activateServiceAccountError *cliErr.ActivateServiceAccountError
+		if !errors.As(err, &activateServiceAccountError) {
+			return "", fmt.Errorf("authenticate service account: %w", err)
+		}
+		return "", err
+	}
+	return email, nil
+}
@@ -0,0 +1,394 @@
+package rotate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/serviceaccount"
+)
+
+var projectIdFlag = globalflags.ProjectIdFlag
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &serviceaccount.APIClient{}
+var testProjectId = uuid.NewString()
+var testServiceAccountEmail = "my-service-account-1234567@sa.stackit.cloud"
+var testKeyId = uuid.NewString()
+var testNow = time.Now()
+var testPublicKey = "my-public-key"
+var testCredentialsFile = "sa-key.json"
+var testPrivateKeyPath = "private.pem"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		projectIdFlag:           testProjectId,
+		serviceAccountEmailFlag: testServiceAccountEmail,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ServiceAccountEmail: testServiceAccountEmail,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureCreateRequest(mods ...func(request *serviceaccount.ApiCreateServiceAccountKeyRequest)) serviceaccount.ApiCreateServiceAccountKeyRequest {
+	request := testClient.CreateServiceAccountKey(testCtx, testProjectId, testServiceAccountEmail)
+	request = request.CreateServiceAccountKeyPayload(serviceaccount.CreateServiceAccountKeyPayload{})
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func fixtureUpdatePreviousKeyRequest(mods ...func(request *serviceaccount.ApiPartialUpdateServiceAccountKeyRequest)) serviceaccount.ApiPartialUpdateServiceAccountKeyRequest {
+	request := testClient.PartialUpdateServiceAccountKey(testCtx, testProjectId, testServiceAccountEmail, testKeyId)
+	request = request.PartialUpdateServiceAccountKeyPayload(serviceaccount.PartialUpdateServiceAccountKeyPayload{
+		Active: utils.Ptr(false),
+	})
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[expiredInDaysFlag] = "90"
+				flagValues[publicKeyFlag] = testPublicKey
+				flagValues[previousKeyIdFlag] = testKeyId
+				flagValues[gracePeriodDaysFlag] = "7"
+				flagValues[credentialsFileFlag] = testCredentialsFile
+				flagValues[activateFlag] = "true"
+				flagValues[privateKeyPathFlag] = testPrivateKeyPath
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ExpiresInDays = utils.Ptr(int64(90))
+				model.PublicKey = utils.Ptr(testPublicKey)
+				model.PreviousKeyIds = []string{testKeyId}
+				model.GracePeriodDays = 7
+				model.CredentialsFile = utils.Ptr(testCredentialsFile)
+				model.Activate = true
+				model.PrivateKeyPath = utils.Ptr(testPrivateKeyPath)
+			}),
+		},
+		{
+			description: "activate with generated key",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[credentialsFileFlag] = testCredentialsFile
+				flagValues[activateFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.CredentialsFile = utils.Ptr(testCredentialsFile)
+				model.Activate = true
+			}),
+		},
+		{
+			description: "activate without credentials file",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[activateFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "activate with public key without private key",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[publicKeyFlag] = testPublicKey
+				flagValues[credentialsFileFlag] = testCredentialsFile
+				flagValues[activateFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "grace period invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[gracePeriodDaysFlag] = "0"
+			}),
+			isValid: false,
+		},
+		{
+			description: "expires in days invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[expiredInDaysFlag] = "-1"
+			}),
+			isValid: false,
+		},
+		{
+			description: "previous key id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[previousKeyIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, projectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[projectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "service account email missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, serviceAccountEmailFlag)
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCreateRequest(t *testing.T) {
+	tests := []struct {
+		description     string
+		model           *inputModel
+		expectedRequest serviceaccount.ApiCreateServiceAccountKeyRequest
+	}{
+		{
+			description:     "base",
+			model:           fixtureInputModel(),
+			expectedRequest: fixtureCreateRequest(),
+		},
+		{
+			description: "with expiration date and public key",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.ExpiresInDays = utils.Ptr(int64(10))
+				model.PublicKey = utils.Ptr(testPublicKey)
+			}),
+			expectedRequest: fixtureCreateRequest().CreateServiceAccountKeyPayload(
+				serviceaccount.CreateServiceAccountKeyPayload{
+					ValidUntil: utils.Ptr(testNow.AddDate(0, 0, 10)),
+					PublicKey:  utils.Ptr(testPublicKey),
+				}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			request := buildCreateRequest(testCtx, tt.model, testClient, testNow)
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildUpdatePreviousKeyRequest(t *testing.T) {
+	tests := []struct {
+		description     string
+		model           *inputModel
+		expectedRequest serviceaccount.ApiPartialUpdateServiceAccountKeyRequest
+	}{
+		{
+			description:     "deactivate",
+			model:           fixtureInputModel(),
+			expectedRequest: fixtureUpdatePreviousKeyRequest(),
+		},
+		{
+			description: "with grace period",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.GracePeriodDays = 7
+			}),
+			expectedRequest: fixtureUpdatePreviousKeyRequest().PartialUpdateServiceAccountKeyPayload(
+				serviceaccount.PartialUpdateServiceAccountKeyPayload{
+					ValidUntil: utils.Ptr(testNow.AddDate(0, 0, 7)),
+				}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			request := buildUpdatePreviousKeyRequest(testCtx, tt.model, testClient, testKeyId, testNow)
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetPreviousKeyIds(t *testing.T) {
+	now := time.Now()
+	keys := &[]serviceaccount.ServiceAccountKeyListResponse{
+		{Id: utils.Ptr("key-1"), Active: utils.Ptr(true)},
+		{Id: utils.Ptr("key-2"), Active: utils.Ptr(true), ValidUntil: utils.Ptr(now.AddDate(0, 0, 30))},
+		{Id: utils.Ptr("key-expiring"), Active: utils.Ptr(true), ValidUntil: utils.Ptr(now.AddDate(0, 0, 2))},
+		{Id: utils.Ptr("key-expired"), Active: utils.Ptr(true), ValidUntil: utils.Ptr(now.AddDate(0, 0, -1))},
+		{Id: utils.Ptr("key-inactive"), Active: utils.Ptr(false)},
+		{},
+	}
+
+	tests := []struct {
+		description     string
+		keys            *[]serviceaccount.ServiceAccountKeyListResponse
+		keyIds          []string
+		gracePeriodDays int64
+		isValid         bool
+		expected        []string
+		expectedSkipped []string
+	}{
+		{
+			description:     "all keys",
+			keys:            keys,
+			isValid:         true,
+			expected:        []string{"key-1", "key-2", "key-expiring"},
+			expectedSkipped: []string{"key-expired", "key-inactive"},
+		},
+		{
+			description:     "key expires before the grace period ends",
+			keys:            keys,
+			gracePeriodDays: 7,
+			isValid:         true,
+			expected:        []string{"key-1", "key-2"},
+			expectedSkipped: []string{"key-expiring", "key-expired", "key-inactive"},
+		},
+		{
+			description:     "given keys",
+			keys:            keys,
+			keyIds:          []string{"key-2", "key-inactive"},
+			isValid:         true,
+			expected:        []string{"key-2"},
+			expectedSkipped: []string{"key-inactive"},
+		},
+		{
+			description: "given key not found",
+			keys:        keys,
+			keyIds:      []string{"key-3"},
+			isValid:     false,
+		},
+		{
+			description:     "nil keys",
+			keys:            nil,
+			isValid:         true,
+			expected:        []string{},
+			expectedSkipped: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			keyIds, skippedKeyIds, err := getPreviousKeyIds(tt.keys, tt.keyIds, tt.gracePeriodDays, now)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(keyIds, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+			diff = cmp.Diff(skippedKeyIds, tt.expectedSkipped)
+			if diff != "" {
+				t.Fatalf("Skipped keys do not match: %s", diff)
+			}
+		})
+	}
+}
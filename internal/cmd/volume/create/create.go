@@ -15,6 +15,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
@@ -65,6 +66,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Create a volume with name "volume-1", from a source image with ID "xxx"`,
 				`$ stackit volume create --availability-zone eu01-1 --name volume-1 --source-id xxx --source-type image`,
 			),
+			examples.NewExample(
+				`Create a volume with name "volume-1-copy" from the volume snapshot with ID "xxx"`,
+				`$ stackit volume create --availability-zone eu01-1 --name volume-1-copy --source-id xxx --source-type snapshot`,
+			),
 			examples.NewExample(
 				`Create a volume with availability zone "eu01-1", performance class "storage_premium_perf1" and size 64 GB`,
 				`$ stackit volume create --availability-zone eu01-1 --performance-class storage_premium_perf1 --size 64`,
@@ -89,6 +94,12 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				projectLabel = model.ProjectId
 			}
 
+			// Check quotas before creating the volume
+			err = quota.Preflight(ctx, params.Printer, apiClient, model.ProjectId, requestedQuotas(model))
+			if err != nil {
+				return err
+			}
+
 			if !model.AssumeYes {
 				prompt := fmt.Sprintf("Are you sure you want to create a volume for project %q?", projectLabel)
 				err = params.Printer.PromptForConfirmation(prompt)
@@ -131,7 +142,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(performanceClassFlag, "", "Performance class")
 	cmd.Flags().Int64(sizeFlag, 0, "Volume size (GB). Either 'size' or the 'source-id' and 'source-type' flags must be given")
 	cmd.Flags().String(sourceIdFlag, "", "ID of the source object of volume. Either 'size' or the 'source-id' and 'source-type' flags must be given")
-	cmd.Flags().String(sourceTypeFlag, "", "Type of the source object of volume. Either 'size' or the 'source-id' and 'source-type' flags must be given")
+	cmd.Flags().String(sourceTypeFlag, "", "Type of the source object of volume, e.g. 'image', 'volume', 'backup' or 'snapshot'. Either 'size' or the 'source-id' and 'source-type' flags must be given")
 
 	err := flags.MarkFlagsRequired(cmd, availabilityZoneFlag)
 	cobra.CheckErr(err)
@@ -199,6 +210,15 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APICli
 	return req.CreateVolumePayload(payload)
 }
 
+// requestedQuotas returns the amounts of the project quotas the volume uses
+func requestedQuotas(model *inputModel) map[string]int64 {
+	requested := map[string]int64{quota.Volumes: 1}
+	if model.Size != nil {
+		requested[quota.Gigabytes] = *model.Size
+	}
+	return requested
+}
+
 func outputResult(p *print.Printer, model *inputModel, projectLabel string, volume *iaas.Volume) error {
 	if volume == nil {
 		return fmt.Errorf("volume response is empty")
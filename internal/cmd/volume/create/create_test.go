@@ -10,6 +10,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/quota"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
 )
@@ -285,6 +286,37 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestRequestedQuotas(t *testing.T) {
+	tests := []struct {
+		description string
+		model       *inputModel
+		expected    map[string]int64
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			expected:    map[string]int64{quota.Volumes: 1, quota.Gigabytes: 5},
+		},
+		{
+			description: "size from source",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Size = nil
+			}),
+			expected: map[string]int64{quota.Volumes: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			requested := requestedQuotas(tt.model)
+			diff := cmp.Diff(requested, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestOutputResult(t *testing.T) {
 	type args struct {
 		model        *inputModel
@@ -0,0 +1,196 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	volumeIdFlag = "volume-id"
+	nameFlag     = "name"
+	labelFlag    = "labels"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	VolumeId string
+	Name     *string
+	Labels   *map[string]string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates a snapshot of a volume",
+		Long: fmt.Sprintf("%s\n%s",
+			"Creates a snapshot of a volume.",
+			`New volumes can be created from the snapshot with "stackit volume create --source-type snapshot --source-id SNAPSHOT_ID".`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Create a snapshot of the volume with ID "xxx"`,
+				"$ stackit volume snapshot create --volume-id xxx",
+			),
+			examples.NewExample(
+				`Create a snapshot with name "before-upgrade" and labels of the volume with ID "xxx"`,
+				"$ stackit volume snapshot create --volume-id xxx --name before-upgrade --labels key=value,foo=bar",
+			),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			volumeLabel, err := iaasUtils.GetVolumeName(ctx, apiClient, model.ProjectId, model.VolumeId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get volume name: %v", err)
+				volumeLabel = model.VolumeId
+			} else if volumeLabel == "" {
+				volumeLabel = model.VolumeId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to create a snapshot of volume %q?", volumeLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("create snapshot: %w", err)
+			}
+			snapshotId := utils.PtrString(resp.Id)
+
+			// Wait for async operation, if async mode not enabled
+			if !model.Async {
+				s := spinner.New(params.Printer)
+				s.Start("Creating snapshot")
+				resp, err = iaasUtils.CreateSnapshotWaitHandler(ctx, apiClient, model.ProjectId, snapshotId).WaitWithContext(ctx)
+				if err != nil {
+					return fmt.Errorf("wait for snapshot creation: %w", err)
+				}
+				s.Stop()
+			}
+
+			return outputResult(params.Printer, model, volumeLabel, resp)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), volumeIdFlag, "ID of the volume to create the snapshot of")
+	cmd.Flags().StringP(nameFlag, "n", "", "Snapshot name")
+	cmd.Flags().StringToString(labelFlag, nil, "Labels are key-value string pairs which can be attached to a snapshot. E.g. '--labels key1=value1,key2=value2,...'")
+
+	err := flags.MarkFlagsRequired(cmd, volumeIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		VolumeId:        flags.FlagToStringValue(p, cmd, volumeIdFlag),
+		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
+		Labels:          flags.FlagToStringToStringPointer(p, cmd, labelFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiCreateSnapshotRequest {
+	req := apiClient.CreateSnapshot(ctx, model.ProjectId)
+
+	var labelsMap *map[string]interface{}
+	if model.Labels != nil && len(*model.Labels) > 0 {
+		// convert map[string]string to map[string]interface{}
+		labelsMap = utils.Ptr(map[string]interface{}{})
+		for k, v := range *model.Labels {
+			(*labelsMap)[k] = v
+		}
+	}
+
+	payload := iaas.CreateSnapshotPayload{
+		VolumeId: utils.Ptr(model.VolumeId),
+		Name:     model.Name,
+		Labels:   labelsMap,
+	}
+
+	return req.CreateSnapshotPayload(payload)
+}
+
+func outputResult(p *print.Printer, model *inputModel, volumeLabel string, snapshot *iaas.Snapshot) error {
+	if snapshot == nil {
+		return fmt.Errorf("snapshot response is empty")
+	}
+	switch model.OutputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal snapshot: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(snapshot, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal snapshot: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		operationState := "Created"
+		if model.Async {
+			operationState = "Triggered creation of"
+		}
+		p.Outputf("%s snapshot of volume %q.\nSnapshot ID: %s\n", operationState, volumeLabel, utils.PtrString(snapshot.Id))
+		return nil
+	}
+}
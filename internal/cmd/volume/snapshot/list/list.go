@@ -0,0 +1,217 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	limitFlag         = "limit"
+	labelSelectorFlag = "label-selector"
+	volumeIdFlag      = "volume-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Limit         *int64
+	LabelSelector *string
+	VolumeId      *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all volume snapshots of a project",
+		Long:  "Lists all volume snapshots of a project.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Lists all snapshots`,
+				"$ stackit volume snapshot list",
+			),
+			examples.NewExample(
+				`Lists all snapshots of the volume with ID "xxx"`,
+				"$ stackit volume snapshot list --volume-id xxx",
+			),
+			examples.NewExample(
+				`Lists all snapshots which contain the label xxx`,
+				"$ stackit volume snapshot list --label-selector xxx",
+			),
+			examples.NewExample(
+				`Lists all snapshots in JSON format`,
+				"$ stackit volume snapshot list --output-format json",
+			),
+			examples.NewExample(
+				`Lists up to 10 snapshots`,
+				"$ stackit volume snapshot list --limit 10",
+			),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("list snapshots: %w", err)
+			}
+
+			items := filterByVolume(utils.PtrValue(resp.Items), model.VolumeId)
+			if len(items) == 0 {
+				projectLabel, err := projectname.GetProjectName(ctx, params.Printer, params.CliVersion, cmd)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get project name: %v", err)
+					projectLabel = model.ProjectId
+				}
+				params.Printer.Info("No snapshots found for project %q\n", projectLabel)
+				return nil
+			}
+
+			// Truncate output
+			if model.Limit != nil && len(items) > int(*model.Limit) {
+				items = items[:*model.Limit]
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, items)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
+	cmd.Flags().String(labelSelectorFlag, "", "Filter by label")
+	cmd.Flags().Var(flags.UUIDFlag(), volumeIdFlag, "Only list the snapshots of the volume with this ID")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Limit:           limit,
+		LabelSelector:   flags.FlagToStringPointer(p, cmd, labelSelectorFlag),
+		VolumeId:        flags.FlagToStringPointer(p, cmd, volumeIdFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiListSnapshotsRequest {
+	req := apiClient.ListSnapshots(ctx, model.ProjectId)
+	if model.LabelSelector != nil {
+		req = req.LabelSelector(*model.LabelSelector)
+	}
+
+	return req
+}
+
+// filterByVolume returns the snapshots of the volume, or all snapshots if volumeId is nil
+func filterByVolume(snapshots []iaas.Snapshot, volumeId *string) []iaas.Snapshot {
+	if volumeId == nil {
+		return snapshots
+	}
+	filtered := []iaas.Snapshot{}
+	for i := range snapshots {
+		if utils.PtrString(snapshots[i].VolumeId) == *volumeId {
+			filtered = append(filtered, snapshots[i])
+		}
+	}
+	return filtered
+}
+
+func outputResult(p *print.Printer, outputFormat string, snapshots []iaas.Snapshot) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(snapshots, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal snapshots: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(snapshots, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal snapshots: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ID", "Name", "Status", "Volume", "Size (GB)", "Labels", "Created At")
+
+		for i := range snapshots {
+			snapshot := snapshots[i]
+			labels := []string{}
+			for key, value := range utils.PtrValue(snapshot.Labels) {
+				labels = append(labels, fmt.Sprintf("%s: %s", key, value))
+			}
+			sort.Strings(labels)
+			table.AddRow(
+				utils.PtrString(snapshot.Id),
+				utils.PtrString(snapshot.Name),
+				utils.PtrString(snapshot.Status),
+				utils.PtrString(snapshot.VolumeId),
+				utils.PtrString(snapshot.Size),
+				strings.Join(labels, "\n"),
+				utils.ConvertTimePToDateTimeString(snapshot.CreatedAt),
+			)
+			table.AddSeparator()
+		}
+
+		p.Outputln(table.Render())
+		return nil
+	}
+}
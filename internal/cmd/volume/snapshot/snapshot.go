@@ -0,0 +1,34 @@
+package snapshot
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/volume/snapshot/create"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/volume/snapshot/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/volume/snapshot/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/volume/snapshot/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/volume/snapshot/update"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Provides functionality for volume snapshots",
+		Long:  "Provides functionality for volume snapshots.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(create.NewCmd(params))
+	cmd.AddCommand(delete.NewCmd(params))
+	cmd.AddCommand(describe.NewCmd(params))
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(update.NewCmd(params))
+}
@@ -0,0 +1,178 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	snapshotIdArg = "SNAPSHOT_ID"
+
+	nameFlag  = "name"
+	labelFlag = "labels"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	SnapshotId string
+	Name       *string
+	Labels     *map[string]string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("update %s", snapshotIdArg),
+		Short: "Updates a volume snapshot",
+		Long:  "Updates a volume snapshot.",
+		Args:  args.SingleArg(snapshotIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Update the name of the snapshot with ID "xxx" to "before-upgrade"`,
+				"$ stackit volume snapshot update xxx --name before-upgrade",
+			),
+			examples.NewExample(
+				`Update the labels of the snapshot with ID "xxx"`,
+				"$ stackit volume snapshot update xxx --labels key=value,foo=bar",
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			snapshotLabel, err := iaasUtils.GetSnapshotName(ctx, apiClient, model.ProjectId, model.SnapshotId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get snapshot name: %v", err)
+				snapshotLabel = model.SnapshotId
+			} else if snapshotLabel == "" {
+				snapshotLabel = model.SnapshotId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to update snapshot %q?", snapshotLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("update snapshot: %w", err)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, snapshotLabel, resp)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(nameFlag, "n", "", "Snapshot name")
+	cmd.Flags().StringToString(labelFlag, nil, "Labels are key-value string pairs which can be attached to a snapshot. E.g. '--labels key1=value1,key2=value2,...'")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	snapshotId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		SnapshotId:      snapshotId,
+		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
+		Labels:          flags.FlagToStringToStringPointer(p, cmd, labelFlag),
+	}
+
+	if model.Name == nil && model.Labels == nil {
+		return nil, &cliErr.EmptyUpdateError{}
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *iaas.APIClient) iaas.ApiUpdateSnapshotRequest {
+	req := apiClient.UpdateSnapshot(ctx, model.ProjectId, model.SnapshotId)
+
+	var labelsMap *map[string]interface{}
+	if model.Labels != nil && len(*model.Labels) > 0 {
+		// convert map[string]string to map[string]interface{}
+		labelsMap = utils.Ptr(map[string]interface{}{})
+		for k, v := range *model.Labels {
+			(*labelsMap)[k] = v
+		}
+	}
+
+	payload := iaas.UpdateSnapshotPayload{
+		Name:   model.Name,
+		Labels: labelsMap,
+	}
+
+	return req.UpdateSnapshotPayload(payload)
+}
+
+func outputResult(p *print.Printer, outputFormat, snapshotLabel string, snapshot *iaas.Snapshot) error {
+	if snapshot == nil {
+		return fmt.Errorf("snapshot response is empty")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal snapshot: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(snapshot, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal snapshot: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		p.Outputf("Updated snapshot %q.\n", snapshotLabel)
+		return nil
+	}
+}
@@ -67,3 +67,49 @@ func SingleOptionalArg(argName string, validate func(value string) error) cobra.
 		return nil
 	}
 }
+
+// SingleArgWithPassthrough checks if only one non-empty argument was provided before "--" and validates it
+// using the validate function. The arguments after "--" are not checked, so that they can be passed through
+// to another program. For no validation, you can pass a nil validate function
+func SingleArgWithPassthrough(argName string, validate func(value string) error) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[:dash]
+		}
+		return SingleArg(argName, validate)(cmd, args)
+	}
+}
+
+// OneOrMoreArgs checks if at least one argument was provided and validates each of them
+// using the validate function. It returns an error if no argument is provided, or if
+// an argument is empty or invalid. For no validation, you can pass a nil validate function
+func OneOrMoreArgs(argName string, validate func(value string) error) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return &errors.SingleArgExpectedError{
+				Cmd:      cmd,
+				Expected: argName,
+				Count:    0,
+			}
+		}
+		for _, arg := range args {
+			if arg == "" {
+				return &errors.ArgValidationError{
+					Arg:     argName,
+					Details: "must not be empty",
+				}
+			}
+			if validate == nil {
+				continue
+			}
+			err := validate(arg)
+			if err != nil {
+				return &errors.ArgValidationError{
+					Arg:     argName,
+					Details: err.Error(),
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -178,3 +178,129 @@ func TestSingleOptionalArg(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleArgWithPassthrough(t *testing.T) {
+	tests := []struct {
+		description string
+		args        []string
+		isValid     bool
+	}{
+		{
+			description: "valid",
+			args:        []string{"arg"},
+			isValid:     true,
+		},
+		{
+			description: "passthrough args",
+			args:        []string{"arg", "--", "-v", "uptime"},
+			isValid:     true,
+		},
+		{
+			description: "no_arg",
+			args:        []string{},
+			isValid:     false,
+		},
+		{
+			description: "only passthrough args",
+			args:        []string{"--", "arg"},
+			isValid:     false,
+		},
+		{
+			description: "more_than_one_arg",
+			args:        []string{"arg", "arg2"},
+			isValid:     false,
+		},
+		{
+			description: "more_than_one_arg before passthrough args",
+			args:        []string{"arg", "arg2", "--", "arg3"},
+			isValid:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:   "test",
+				Short: "Test command",
+			}
+			err := cmd.Flags().Parse(tt.args)
+			if err != nil {
+				t.Fatalf("parse args: %v", err)
+			}
+
+			argFunction := SingleArgWithPassthrough("test", nil)
+			err = argFunction(cmd, cmd.Flags().Args())
+
+			if tt.isValid && err != nil {
+				t.Fatalf("should not have failed: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("should have failed")
+			}
+		})
+	}
+}
+
+func TestOneOrMoreArgs(t *testing.T) {
+	tests := []struct {
+		description  string
+		args         []string
+		validateFunc func(value string) error
+		isValid      bool
+	}{
+		{
+			description: "one_arg",
+			args:        []string{"arg"},
+			isValid:     true,
+		},
+		{
+			description: "multiple_args",
+			args:        []string{"arg", "arg2", "arg3"},
+			isValid:     true,
+		},
+		{
+			description: "no_args",
+			args:        []string{},
+			isValid:     false,
+		},
+		{
+			description: "empty_arg",
+			args:        []string{"arg", ""},
+			isValid:     false,
+		},
+		{
+			description:  "valid_args_with_validate",
+			args:         []string{"arg", "arg2"},
+			validateFunc: func(_ string) error { return nil },
+			isValid:      true,
+		},
+		{
+			description: "invalid_arg_with_validate",
+			args:        []string{"arg", "invalid"},
+			validateFunc: func(value string) error {
+				if value == "invalid" {
+					return fmt.Errorf("invalid")
+				}
+				return nil
+			},
+			isValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:   "test",
+				Short: "Test command",
+			}
+
+			argFunction := OneOrMoreArgs("test", tt.validateFunc)
+			err := argFunction(cmd, tt.args)
+
+			if tt.isValid && err != nil {
+				t.Fatalf("should not have failed: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("should have failed")
+			}
+		})
+	}
+}
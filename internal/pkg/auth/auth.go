@@ -6,7 +6,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/stackitcloud/stackit-cli/internal/pkg/cassette"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -24,7 +26,12 @@ type tokenClaims struct {
 //
 // If the user was logged in and the user session expired, reauthorizeUserRoutine is called to reauthenticate the user again.
 // If the environment variable STACKIT_ACCESS_TOKEN is set this token is used instead.
+// If requests are replayed from a cassette, no credentials are needed and the client is not authenticated.
 func AuthenticationConfig(p *print.Printer, reauthorizeUserRoutine func(p *print.Printer, _ bool) error) (authCfgOption sdkConfig.ConfigurationOption, err error) {
+	if cassette.IsReplaying() {
+		return sdkConfig.WithoutAuthentication(), nil
+	}
+
 	// Get access token from env and use this if present
 	accessToken := os.Getenv(envAccessTokenName)
 	if accessToken != "" {
@@ -82,6 +89,84 @@ func AuthenticationConfig(p *print.Printer, reauthorizeUserRoutine func(p *print
 	return authCfgOption, nil
 }
 
+// GetValidAccessToken returns a valid access token for the active authentication flow.
+// If the stored access token has expired, it is refreshed (and stored) using the refresh token.
+// Contrary to AuthenticationConfig, the user is never prompted to log in again,
+// so it can be used by non-interactive consumers, e.g. credential helpers.
+// If the environment variable STACKIT_ACCESS_TOKEN is set this token is returned instead.
+func GetValidAccessToken(p *print.Printer) (string, error) {
+	return getValidAccessToken(p, UserTokenFlow(p))
+}
+
+func getValidAccessToken(p *print.Printer, utf *userTokenFlow) (string, error) {
+	// Get access token from env and use this if present
+	accessToken := os.Getenv(envAccessTokenName)
+	if accessToken != "" {
+		return accessToken, nil
+	}
+
+	flow, err := GetAuthFlow()
+	if err != nil {
+		return "", fmt.Errorf("get authentication flow: %w", err)
+	}
+	if flow == "" {
+		return "", &errors.AuthError{}
+	}
+
+	userSessionExpired, err := UserSessionExpired()
+	if err != nil {
+		return "", fmt.Errorf("check if user session expired: %w", err)
+	}
+	if userSessionExpired {
+		return "", &errors.SessionExpiredError{}
+	}
+
+	switch flow {
+	case AUTH_FLOW_SERVICE_ACCOUNT_TOKEN:
+		p.Debug(print.DebugLevel, "getting access token of service account token flow")
+		return GetAccessToken()
+	case AUTH_FLOW_SERVICE_ACCOUNT_KEY:
+		p.Debug(print.DebugLevel, "getting access token of service account key flow")
+		keyFlow, err := initKeyFlowWithStorage()
+		if err != nil {
+			return "", fmt.Errorf("initialize service account key flow: %w", err)
+		}
+		accessToken, err := keyFlow.keyFlow.GetAccessToken()
+		if err != nil {
+			return "", fmt.Errorf("get service account access token: %w", err)
+		}
+		err = SetAuthFieldMap(map[authFieldKey]string{
+			ACCESS_TOKEN:  accessToken,
+			REFRESH_TOKEN: keyFlow.keyFlow.GetToken().RefreshToken,
+		})
+		if err != nil {
+			return "", fmt.Errorf("set access and refresh token in the storage: %w", err)
+		}
+		return accessToken, nil
+	case AUTH_FLOW_USER_TOKEN:
+		p.Debug(print.DebugLevel, "getting access token of user token flow")
+		err = loadVarsFromStorage(utf)
+		if err != nil {
+			return "", err
+		}
+		accessTokenExpired, err := TokenExpired(utf.accessToken)
+		if err != nil {
+			return "", fmt.Errorf("check if access token has expired: %w", err)
+		}
+		if accessTokenExpired {
+			p.Debug(print.DebugLevel, "access token expired, refreshing...")
+			err = refreshTokens(utf)
+			if err != nil {
+				p.Debug(print.ErrorLevel, "refresh access token: %v", err)
+				return "", &errors.SessionExpiredError{}
+			}
+		}
+		return utf.accessToken, nil
+	default:
+		return "", fmt.Errorf("the provided authentication flow (%s) is not supported", flow)
+	}
+}
+
 func UserSessionExpired() (bool, error) {
 	sessionExpiresAtString, err := GetAuthField(SESSION_EXPIRES_AT_UNIX)
 	if err != nil {
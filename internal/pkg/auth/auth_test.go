@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
@@ -345,3 +346,172 @@ func TestInitKeyFlow(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidAccessToken(t *testing.T) {
+	tests := []struct {
+		description             string
+		flow                    AuthFlow
+		sessionExpiresAt        time.Time
+		accessTokenExpiresAt    time.Time
+		saKey                   string
+		privateKeySet           bool
+		refreshTokensFails      bool
+		isValid                 bool
+		expectedTokensRefreshed bool
+	}{
+		{
+			description:          "base_service_account_token",
+			flow:                 AUTH_FLOW_SERVICE_ACCOUNT_TOKEN,
+			sessionExpiresAt:     time.Now().Add(time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			isValid:              true,
+		},
+		{
+			description:          "service_account_token_session_expired",
+			flow:                 AUTH_FLOW_SERVICE_ACCOUNT_TOKEN,
+			sessionExpiresAt:     time.Now().Add(-time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			isValid:              false,
+		},
+		{
+			description:          "base_service_account_key",
+			flow:                 AUTH_FLOW_SERVICE_ACCOUNT_KEY,
+			sessionExpiresAt:     time.Now().Add(time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			saKey:                testServiceAccountKey,
+			privateKeySet:        true,
+			isValid:              true,
+		},
+		{
+			description:          "service_account_key_invalid_key",
+			flow:                 AUTH_FLOW_SERVICE_ACCOUNT_KEY,
+			sessionExpiresAt:     time.Now().Add(time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			saKey:                testServiceAccountKey,
+			privateKeySet:        false,
+			isValid:              false,
+		},
+		{
+			description:          "base_user_token",
+			flow:                 AUTH_FLOW_USER_TOKEN,
+			sessionExpiresAt:     time.Now().Add(time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			isValid:              true,
+		},
+		{
+			description:             "user_token_refreshed",
+			flow:                    AUTH_FLOW_USER_TOKEN,
+			sessionExpiresAt:        time.Now().Add(time.Hour),
+			accessTokenExpiresAt:    time.Now().Add(-time.Hour),
+			isValid:                 true,
+			expectedTokensRefreshed: true,
+		},
+		{
+			description:          "user_token_refresh_fails",
+			flow:                 AUTH_FLOW_USER_TOKEN,
+			sessionExpiresAt:     time.Now().Add(time.Hour),
+			accessTokenExpiresAt: time.Now().Add(-time.Hour),
+			refreshTokensFails:   true,
+			isValid:              false,
+		},
+		{
+			description:          "user_token_session_expired",
+			flow:                 AUTH_FLOW_USER_TOKEN,
+			sessionExpiresAt:     time.Now().Add(-time.Hour),
+			accessTokenExpiresAt: time.Now().Add(time.Hour),
+			isValid:              false,
+		},
+		{
+			description:      "unsupported_flow",
+			flow:             "test_flow",
+			sessionExpiresAt: time.Now().Add(time.Hour),
+			isValid:          false,
+		},
+		{
+			description: "unset_flow",
+			flow:        "",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			keyring.MockInit()
+			accessToken, refreshToken, err := createTokens(tt.accessTokenExpiresAt, time.Now().Add(time.Hour))
+			if err != nil {
+				t.Fatalf("Create tokens: %v", err)
+			}
+
+			authFields := make(map[authFieldKey]string)
+			if tt.privateKeySet {
+				privateKey, err := generatePrivateKey()
+				if err != nil {
+					t.Fatalf("Generate private key: %s", err)
+				}
+				authFields[PRIVATE_KEY] = string(privateKey)
+			}
+			authFields[SESSION_EXPIRES_AT_UNIX] = strconv.FormatInt(tt.sessionExpiresAt.Unix(), 10)
+			authFields[ACCESS_TOKEN] = accessToken
+			authFields[REFRESH_TOKEN] = refreshToken
+			authFields[SERVICE_ACCOUNT_KEY] = tt.saKey
+			authFields[TOKEN_CUSTOM_ENDPOINT] = "token_url"
+			authFields[IDP_TOKEN_ENDPOINT] = testTokenEndpoint
+
+			err = SetAuthFlow(tt.flow)
+			if err != nil {
+				t.Fatalf("Failed to set auth flow: %s", err)
+			}
+			err = SetAuthFieldMap(authFields)
+			if err != nil {
+				t.Fatalf("Failed to set in auth storage: %v", err)
+			}
+
+			cmd := &cobra.Command{}
+			cmd.SetOut(io.Discard) // Suppresses console prints
+			p := &print.Printer{Cmd: cmd}
+
+			requestSent := false
+			tokensRefreshed := false
+			utf := &userTokenFlow{
+				printer: p,
+				client: &http.Client{
+					Transport: &clientTransport{
+						t:                  t,
+						refreshTokensFails: tt.refreshTokensFails,
+						requestSent:        &requestSent,
+						tokensRefreshed:    &tokensRefreshed,
+					},
+				},
+			}
+
+			token, err := getValidAccessToken(p, utf)
+
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("Expected error but no error was returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but error was returned: %v", err)
+			}
+			if tokensRefreshed != tt.expectedTokensRefreshed {
+				t.Fatalf("Expected tokens refreshed to be %t, got %t", tt.expectedTokensRefreshed, tokensRefreshed)
+			}
+			expired, err := TokenExpired(token)
+			if err != nil {
+				t.Fatalf("Check if returned token expired: %v", err)
+			}
+			if expired {
+				t.Fatalf("Returned access token is expired")
+			}
+			storedToken, err := GetAccessToken()
+			if err != nil {
+				t.Fatalf("Get access token from storage: %v", err)
+			}
+			if storedToken != token {
+				t.Fatalf("Returned access token was not stored")
+			}
+		})
+	}
+}
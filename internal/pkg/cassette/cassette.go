@@ -0,0 +1,427 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/fileutils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/goccy/go-yaml"
+	"github.com/gofrs/flock"
+	"github.com/spf13/viper"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+)
+
+// Cassette is a recording of the HTTP interactions of the CLI with the STACKIT APIs
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a request with its response. Secrets are redacted in both.
+type Interaction struct {
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+type Request struct {
+	Method string `yaml:"method"`
+	URL    string `yaml:"url"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int               `yaml:"status_code"`
+	Headers    map[string]string `yaml:"headers,omitempty"`
+	Body       string            `yaml:"body,omitempty"`
+}
+
+// cassetteHeader starts a cassette file, the interactions are appended to it
+const cassetteHeader = "interactions:\n"
+
+// fileMutex serializes the cassette file writes of all API clients of the process,
+// the file lock serializes them with the other processes
+var fileMutex sync.Mutex
+
+// players keeps the replay state per cassette file, so that all API clients of the process share it
+var (
+	players      = map[string]*player{}
+	playersMutex sync.Mutex
+)
+
+// ConfigOption returns the configuration option that records the requests of an API client to
+// the cassette file given with --record-cassette, or replays them from the one given with --replay-cassette.
+// Without these flags, the returned option doesn't change the client.
+func ConfigOption(p *print.Printer) (sdkConfig.ConfigurationOption, error) {
+	recordPath := viper.GetString(config.RecordKey)
+	replayPath := viper.GetString(config.ReplayKey)
+
+	switch {
+	case recordPath != "" && replayPath != "":
+		return nil, fmt.Errorf("requests can't be recorded and replayed at the same time")
+	case recordPath != "":
+		p.Debug(print.DebugLevel, "recording requests to cassette %q", recordPath)
+		return sdkConfig.WithMiddleware(Recorder(recordPath)), nil
+	case replayPath != "":
+		session := viper.GetString(config.CassetteSessionKey)
+		p.Debug(print.DebugLevel, "replaying requests from cassette %q in session %q", replayPath, session)
+		pl, err := getPlayer(replayPath, session)
+		if err != nil {
+			return nil, err
+		}
+		return sdkConfig.WithMiddleware(pl.middleware), nil
+	default:
+		return func(_ *sdkConfig.Configuration) error { return nil }, nil
+	}
+}
+
+// IsReplaying returns whether the requests are replayed from a cassette instead of sent to the APIs
+func IsReplaying() bool {
+	return viper.GetString(config.ReplayKey) != ""
+}
+
+// Read reads the cassette file. A missing file is an empty cassette.
+func Read(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Cassette{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+
+	cassette := &Cassette{}
+	err = yaml.Unmarshal(content, cassette)
+	if err != nil {
+		return nil, fmt.Errorf("parse cassette %q: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Write writes the cassette file
+func Write(path string, cassette *Cassette) error {
+	content, err := marshal(cassette)
+	if err != nil {
+		return err
+	}
+	err = fileutils.WriteToFileAtomically(path, string(content), 0o600)
+	if err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+func marshal(cassette *Cassette) ([]byte, error) {
+	if len(cassette.Interactions) == 0 {
+		// written as header only, so that interactions can be appended
+		return []byte(cassetteHeader), nil
+	}
+	content, err := yaml.MarshalWithOptions(cassette, yaml.IndentSequence(true), yaml.UseLiteralStyleIfMultiline(true))
+	if err != nil {
+		return nil, fmt.Errorf("marshal cassette: %w", err)
+	}
+	return content, nil
+}
+
+// lockFile locks the cassette against the other processes using it, e.g. the commands of a script.
+// The lock is taken on a separate file, since Windows doesn't allow writing to a locked file.
+func lockFile(path string) (unlock func(), err error) {
+	lock := flock.New(path + ".lock")
+	err = lock.Lock()
+	if err != nil {
+		return nil, fmt.Errorf("lock cassette: %w", err)
+	}
+	return func() { _ = lock.Unlock() }, nil
+}
+
+// appendInteraction appends the interaction to the cassette file, without rewriting the interactions
+// recorded before. A new cassette file is started with the cassette header.
+func appendInteraction(path string, interaction *Interaction) (err error) {
+	content, err := marshal(&Cassette{Interactions: []Interaction{*interaction}})
+	if err != nil {
+		return err
+	}
+	item, ok := bytes.CutPrefix(content, []byte(cassetteHeader))
+	if !ok {
+		return fmt.Errorf("marshal cassette: unexpected format %q", content)
+	}
+
+	fileMutex.Lock()
+	defer fileMutex.Unlock()
+	unlock, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open cassette: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("close cassette: %w", closeErr)
+		}
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("open cassette: %w", err)
+	}
+	if info.Size() == 0 {
+		item = content
+	}
+	_, err = file.Write(item)
+	if err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder returns a middleware which sends the requests and appends them with their responses
+// to the cassette file. Every interaction is appended once it is complete, so that a script
+// running several commands can record all of them to the same file.
+func Recorder(path string) sdkConfig.Middleware {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &recorder{transport: rt, path: path}
+	}
+}
+
+type recorder struct {
+	transport http.RoundTripper
+	path      string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body for cassette: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   redactBody(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       redactBody(respBody),
+		},
+	}
+
+	err = appendInteraction(r.path, &interaction)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// player answers the requests with the recorded responses. Interactions matching the same
+// request are replayed in the recorded order, and the last one is repeated once all were used,
+// e.g. for the requests polling a resource until it is ready.
+type player struct {
+	mutex        sync.Mutex
+	path         string
+	interactions []Interaction
+	// session identifies the script replaying the cassette. Without session, the progress is only kept by the process.
+	session string
+	state   *replayState
+}
+
+// replayState is the replay progress of a cassette. With a session, it is kept in the file "<cassette>.state",
+// so that each command of a script continues the replay where the previous command stopped.
+type replayState struct {
+	// Session identifies the script the progress belongs to, a new session starts the replay from the beginning
+	Session string `yaml:"session"`
+	// Used are the indexes of the replayed interactions
+	Used []int `yaml:"used"`
+}
+
+func getPlayer(path, session string) (*player, error) {
+	playersMutex.Lock()
+	defer playersMutex.Unlock()
+	if pl, ok := players[path]; ok {
+		return pl, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open cassette: %w", err)
+	}
+	cassette, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	pl := &player{
+		path:         path,
+		interactions: cassette.Interactions,
+		session:      session,
+		state:        &replayState{Session: session},
+	}
+	players[path] = pl
+	return pl, nil
+}
+
+func statePath(path string) string {
+	return path + ".state"
+}
+
+// readState reads the replay progress of the cassette. Without progress of the current session,
+// the replay starts from the beginning.
+func (pl *player) readState() (*replayState, error) {
+	if pl.session == "" {
+		return pl.state, nil
+	}
+	session := pl.session
+	content, err := os.ReadFile(statePath(pl.path))
+	if os.IsNotExist(err) {
+		return &replayState{Session: session}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read replay state: %w", err)
+	}
+
+	state := &replayState{}
+	err = yaml.Unmarshal(content, state)
+	if err != nil {
+		return nil, fmt.Errorf("parse replay state %q, delete it to replay the cassette from the beginning: %w", statePath(pl.path), err)
+	}
+	if state.Session != session {
+		return &replayState{Session: session}, nil
+	}
+	for _, i := range state.Used {
+		if i < 0 || i >= len(pl.interactions) {
+			// the cassette has been recorded again
+			return &replayState{Session: session}, nil
+		}
+	}
+	return state, nil
+}
+
+func (pl *player) writeState(state *replayState) error {
+	if pl.session == "" {
+		pl.state = state
+		return nil
+	}
+	content, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal replay state: %w", err)
+	}
+	err = fileutils.WriteToFileAtomically(statePath(pl.path), string(content), 0o600)
+	if err != nil {
+		return fmt.Errorf("write replay state: %w", err)
+	}
+	return nil
+}
+
+func (pl *player) middleware(_ http.RoundTripper) http.RoundTripper {
+	return pl
+}
+
+func (pl *player) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	body := redactBody(reqBody)
+
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
+	if pl.session != "" {
+		unlock, err := lockFile(pl.path)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	state, err := pl.readState()
+	if err != nil {
+		return nil, err
+	}
+	used := map[int]bool{}
+	for _, i := range state.Used {
+		used[i] = true
+	}
+
+	match := -1
+	for i := range pl.interactions {
+		if !matches(&pl.interactions[i].Request, req, body) {
+			continue
+		}
+		match = i
+		if !used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no interaction in the cassette matches the request %s %s", req.Method, req.URL.Path)
+	}
+	if !used[match] {
+		state.Used = append(state.Used, match)
+		err = pl.writeState(state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := pl.interactions[match].Response
+	header := http.Header{}
+	for key, value := range recorded.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches returns whether the recorded request has the method, path and redacted body of the request
+func matches(recorded *Request, req *http.Request, body string) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	recordedPath, err := urlPath(recorded.URL)
+	if err != nil || recordedPath != req.URL.Path {
+		return false
+	}
+	return normalizeBody(recorded.Body) == normalizeBody(body)
+}
+
+// readRequestBody reads the request body and restores it, so that the request can still be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request body for cassette: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,118 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveKeys are the parts of JSON keys whose values are redacted, compared in lower case without "_" and "-"
+var sensitiveKeys = []string{"password", "secret", "token", "privatekey", "accesskey", "apikey", "kubeconfig"}
+
+// recordedHeaders are the response headers which are recorded, all others are dropped
+var recordedHeaders = []string{"Content-Type", "Location"}
+
+// redactBody replaces the values of sensitive keys and the passwords in URLs of a JSON body.
+// Other bodies are kept as they are.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	redactedBody, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) && item != nil {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	case string:
+		return redactURL(v)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(normalized, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL replaces the password of a URL with credentials, e.g. a database connection string
+func redactURL(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	u.User = url.UserPassword(u.User.Username(), redacted)
+	return u.String()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	result := map[string]string{}
+	for _, key := range recordedHeaders {
+		if value := header.Get(key); value != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// normalizeBody returns the JSON body in compact form with sorted keys, so that bodies can be compared
+func normalizeBody(body string) string {
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return strings.TrimSpace(body)
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return strings.TrimSpace(body)
+	}
+	return string(normalized)
+}
+
+func urlPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Path, nil
+}
@@ -22,6 +22,10 @@ const (
 	IdentityProviderCustomClientIdKey               = "identity_provider_custom_client_id"
 	AllowedUrlDomainKey                             = "allowed_url_domain"
 
+	SSHUserKey          = "ssh_user"
+	SSHBastionServerKey = "ssh_bastion_server"
+	SSHIdentityFilesKey = "ssh_identity_files"
+
 	AuthorizationCustomEndpointKey     = "authorization_custom_endpoint"
 	DNSCustomEndpointKey               = "dns_custom_endpoint"
 	LoadBalancerCustomEndpointKey      = "load_balancer_custom_endpoint"
@@ -48,6 +52,9 @@ const (
 	GitCustomEndpointKey               = "git_custom_endpoint"
 
 	ProjectNameKey     = "project_name"
+	RecordKey          = "record_cassette"
+	ReplayKey          = "replay_cassette"
+	CassetteSessionKey = "cassette_session"
 	DefaultProfileName = "default"
 
 	AsyncDefault            = false
@@ -80,6 +87,10 @@ var ConfigKeys = []string{
 	IdentityProviderCustomClientIdKey,
 	AllowedUrlDomainKey,
 
+	SSHUserKey,
+	SSHBastionServerKey,
+	SSHIdentityFilesKey,
+
 	DNSCustomEndpointKey,
 	LoadBalancerCustomEndpointKey,
 	LogMeCustomEndpointKey,
@@ -190,6 +201,9 @@ func setConfigDefaults() {
 	viper.SetDefault(IaaSCustomEndpointKey, "")
 	viper.SetDefault(TokenCustomEndpointKey, "")
 	viper.SetDefault(GitCustomEndpointKey, "")
+	viper.SetDefault(SSHUserKey, "")
+	viper.SetDefault(SSHBastionServerKey, "")
+	viper.SetDefault(SSHIdentityFilesKey, "")
 }
 
 func getConfigFilePath(configFolder string) string {
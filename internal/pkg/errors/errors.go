@@ -95,6 +95,21 @@ or provide plan name and version:
 For more details on the available plans, run:
   $ stackit %[2]s plans`
 
+	DSA_INVALID_CREATE_INPUT_PLAN = `the instance plan was not correctly provided. 
+
+Either provide the plan ID:
+  $ %[1]s --plan-id <PLAN ID> [flags]
+
+or provide plan name and version:
+  $ %[1]s --plan-name <PLAN NAME> --version <VERSION> [flags]
+
+or provide the minimum CPU and RAM and the version, to use the smallest matching plan:
+  $ %[1]s --cpu <CPU> --ram <RAM> --version <VERSION> [flags]
+
+For more details on the available plans, run:
+  $ stackit %[2]s plans
+  $ stackit %[2]s plans recommend --cpu <CPU> --ram <RAM>`
+
 	DSA_INVALID_PLAN = `the provided instance plan is not valid.
 	
 %s
@@ -178,6 +193,11 @@ To list all profiles, run:
   $ stackit config profile list`
 
 	FILE_ALREADY_EXISTS = `file %q already exists in the export path. Delete the existing file or define a different export path`
+
+	IAAS_QUOTA_EXCEEDED = `the request would exceed the project quota %q: %d of %d are used and %d more are requested.
+
+To see the usage of all project quotas, run:
+  $ stackit quota list`
 )
 
 type ServerNicAttachMissingNicIdError struct {
@@ -329,6 +349,22 @@ func (e *DSAInputPlanError) Error() string {
 	return fmt.Sprintf(DSA_INVALID_INPUT_PLAN, fullCommandPath, service)
 }
 
+type DSACreateInputPlanError struct {
+	Cmd  *cobra.Command
+	Args []string
+}
+
+func (e *DSACreateInputPlanError) Error() string {
+	fullCommandPath := e.Cmd.CommandPath()
+	if len(e.Args) > 0 {
+		fullCommandPath = fmt.Sprintf("%s %s", fullCommandPath, strings.Join(e.Args, " "))
+	}
+	// Assumes a structure of the form "stackit <service> <resource> <operation>"
+	service := e.Cmd.Parent().Parent().Use
+
+	return fmt.Sprintf(DSA_INVALID_CREATE_INPUT_PLAN, fullCommandPath, service)
+}
+
 type DSAInvalidPlanError struct {
 	Service string
 	Details string
@@ -462,6 +498,17 @@ func AppendUsageTip(err error, cmd *cobra.Command) error {
 	return fmt.Errorf("%w.\n\n%s", err, tip)
 }
 
+// ExitCodeError is returned if an external command run by the CLI, e.g. ssh, exits with a non-zero status.
+// The CLI exits with the same status and doesn't print the error, as the command has already reported it.
+type ExitCodeError struct {
+	Command string
+	Code    int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("%s exited with status %d", e.Command, e.Code)
+}
+
 type InvalidProfileNameError struct {
 	Profile string
 }
@@ -499,3 +546,14 @@ type FileAlreadyExistsError struct {
 }
 
 func (e *FileAlreadyExistsError) Error() string { return fmt.Sprintf(FILE_ALREADY_EXISTS, e.Filename) }
+
+type QuotaExceededError struct {
+	Quota     string
+	Limit     int64
+	Usage     int64
+	Requested int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(IAAS_QUOTA_EXCEEDED, e.Quota, e.Usage, e.Limit, e.Requested)
+}
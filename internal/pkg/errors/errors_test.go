@@ -223,6 +223,39 @@ func TestDSAInputPlanError(t *testing.T) {
 	}
 }
 
+func TestDSACreateInputPlanError(t *testing.T) {
+	tests := []struct {
+		description string
+		args        []string
+		expectedMsg string
+	}{
+		{
+			description: "base",
+			args:        []string{"arg1", "arg2"},
+			expectedMsg: fmt.Sprintf(DSA_INVALID_CREATE_INPUT_PLAN, "stackit service resource operation arg1 arg2", "service"),
+		},
+		{
+			description: "no args",
+			args:        []string{},
+			expectedMsg: fmt.Sprintf(DSA_INVALID_CREATE_INPUT_PLAN, "stackit service resource operation", "service"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			setupCmd()
+			err := &DSACreateInputPlanError{
+				Cmd:  operation,
+				Args: tt.args,
+			}
+
+			if err.Error() != tt.expectedMsg {
+				t.Fatalf("expected error to be %s, got %s", tt.expectedMsg, err.Error())
+			}
+		})
+	}
+}
+
 func TestDSAInvalidPlanError(t *testing.T) {
 	tests := []struct {
 		description string
@@ -441,6 +474,20 @@ func TestFlagValidationError(t *testing.T) {
 	}
 }
 
+func TestQuotaExceededError(t *testing.T) {
+	err := &QuotaExceededError{
+		Quota:     "networks",
+		Limit:     10,
+		Usage:     9,
+		Requested: 2,
+	}
+
+	expectedMsg := fmt.Sprintf(IAAS_QUOTA_EXCEEDED, "networks", 9, 10, 2)
+	if err.Error() != expectedMsg {
+		t.Fatalf("expected error to be %s, got %s", expectedMsg, err.Error())
+	}
+}
+
 func TestRequiredMutuallyExclusiveFlagsError(t *testing.T) {
 	tests := []struct {
 		description string
@@ -3,6 +3,7 @@ package fileutils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // WriteToFile writes the given content to a file.
@@ -29,6 +30,46 @@ func WriteToFile(outputFileName, content string) (err error) {
 	return err
 }
 
+// WriteToFileAtomically writes the given content to a file with the given permissions.
+// The content is written to a temporary file in the same directory first, which is then renamed to the output file,
+// so that the output file is either fully written or left untouched.
+// If the file already exists, it will be overwritten.
+func WriteToFileAtomically(outputFileName, content string, perm os.FileMode) (err error) {
+	fo, err := os.CreateTemp(filepath.Dir(outputFileName), fmt.Sprintf(".%s-*.tmp", filepath.Base(outputFileName)))
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	tempFileName := fo.Name()
+	defer func() {
+		if err != nil {
+			_ = fo.Close()
+			_ = os.Remove(tempFileName)
+		}
+	}()
+
+	err = fo.Chmod(perm)
+	if err != nil {
+		return fmt.Errorf("set permissions of temporary file: %w", err)
+	}
+	_, err = fo.WriteString(content)
+	if err != nil {
+		return fmt.Errorf("write content to temporary file: %w", err)
+	}
+	err = fo.Sync()
+	if err != nil {
+		return fmt.Errorf("sync temporary file: %w", err)
+	}
+	err = fo.Close()
+	if err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+	err = os.Rename(tempFileName, outputFileName)
+	if err != nil {
+		return fmt.Errorf("rename temporary file to output file: %w", err)
+	}
+	return nil
+}
+
 // ReadFileIfExists reads the contents of a file and returns it as a string, along with a boolean indicating if the file exists.
 // If the file does not exist, it returns an empty string, false and no error.
 // If the file exists but cannot be read, it returns an error.
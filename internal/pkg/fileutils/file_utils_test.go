@@ -43,6 +43,66 @@ func TestWriteToFile(t *testing.T) {
 	}
 }
 
+func TestWriteToFileAtomically(t *testing.T) {
+	tests := []struct {
+		description  string
+		content      string
+		fileExists   bool
+		expectedPerm os.FileMode
+	}{
+		{
+			description:  "write into new file",
+			content:      "Test message",
+			expectedPerm: 0o600,
+		},
+		{
+			description:  "overwrite existing file",
+			content:      "Test message",
+			fileExists:   true,
+			expectedPerm: 0o644,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			dir := t.TempDir()
+			outputFile := filepath.Join(dir, "output.json")
+			if tt.fileExists {
+				err := os.WriteFile(outputFile, []byte("old content"), 0o600)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+			}
+
+			err := WriteToFileAtomically(outputFile, tt.content, tt.expectedPerm)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			output, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if string(output) != tt.content {
+				t.Fatalf("unexpected output: got %q, want %q", output, tt.content)
+			}
+			info, err := os.Stat(outputFile)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if info.Mode().Perm() != tt.expectedPerm {
+				t.Fatalf("unexpected permissions: got %v, want %v", info.Mode().Perm(), tt.expectedPerm)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if len(entries) != 1 {
+				t.Fatalf("temporary file was not cleaned up: found %d files", len(entries))
+			}
+		})
+	}
+}
+
 func TestReadFileIfExists(t *testing.T) {
 	tests := []struct {
 		description string
@@ -13,12 +13,15 @@ import (
 )
 
 const (
-	AsyncFlag        = "async"
-	AssumeYesFlag    = "assume-yes"
-	OutputFormatFlag = "output-format"
-	ProjectIdFlag    = "project-id"
-	RegionFlag       = "region"
-	VerbosityFlag    = "verbosity"
+	AsyncFlag           = "async"
+	AssumeYesFlag       = "assume-yes"
+	CassetteSessionFlag = "cassette-session"
+	OutputFormatFlag    = "output-format"
+	ProjectIdFlag       = "project-id"
+	RecordFlag          = "record-cassette"
+	RegionFlag          = "region"
+	ReplayFlag          = "replay-cassette"
+	VerbosityFlag       = "verbosity"
 
 	DebugVerbosity   = string(print.DebugLevel)
 	InfoVerbosity    = string(print.InfoLevel)
@@ -73,6 +76,24 @@ func Configure(flagSet *pflag.FlagSet) error {
 		return fmt.Errorf("bind --%s flag to config: %w", RegionFlag, err)
 	}
 
+	flagSet.String(RecordFlag, "", "Record the API requests and responses to a cassette file, with secrets redacted. Interactions are appended if the file exists")
+	err = viper.BindPFlag(config.RecordKey, flagSet.Lookup(RecordFlag))
+	if err != nil {
+		return fmt.Errorf("bind --%s flag to config: %w", RecordFlag, err)
+	}
+
+	flagSet.String(ReplayFlag, "", fmt.Sprintf("Answer the API requests from a cassette file recorded with --%s, without sending them. Requests are matched by method, path and body. Each command replays the cassette from the beginning, unless --%s is set", RecordFlag, CassetteSessionFlag))
+	err = viper.BindPFlag(config.ReplayKey, flagSet.Lookup(ReplayFlag))
+	if err != nil {
+		return fmt.Errorf("bind --%s flag to config: %w", ReplayFlag, err)
+	}
+
+	flagSet.String(CassetteSessionFlag, "", fmt.Sprintf("Identifier of the script replaying a cassette with --%s, can also be set with the STACKIT_CASSETTE_SESSION environment variable. The commands with the same session continue the replay where the previous command stopped, a new session replays the cassette from the beginning", ReplayFlag))
+	err = viper.BindPFlag(config.CassetteSessionKey, flagSet.Lookup(CassetteSessionFlag))
+	if err != nil {
+		return fmt.Errorf("bind --%s flag to config: %w", CassetteSessionFlag, err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,154 @@
+package recommend
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+)
+
+const (
+	CPUFlag     = "cpu"
+	RAMFlag     = "ram"
+	StorageFlag = "storage"
+	VersionFlag = "version"
+	LimitFlag   = "limit"
+)
+
+// Input holds the flag values shared by the recommend commands
+type Input struct {
+	CPU     *int64
+	RAM     *int64
+	Storage *int64
+	Version *string
+	Limit   *int64
+}
+
+// ConfigureFlags adds the flags of a recommend command. The usages of the storage and version flags depend on the service.
+func ConfigureFlags(cmd *cobra.Command, storageUsage, versionUsage string) {
+	cmd.Flags().Int64(CPUFlag, 0, "Minimum number of CPUs")
+	cmd.Flags().Int64(RAMFlag, 0, "Minimum amount of RAM (in GB)")
+	cmd.Flags().Int64(StorageFlag, 0, storageUsage)
+	cmd.Flags().String(VersionFlag, "", versionUsage)
+	cmd.Flags().Int64(LimitFlag, 0, "Maximum number of entries to list")
+}
+
+// ParseInput parses and validates the flags added by ConfigureFlags
+func ParseInput(p *print.Printer, cmd *cobra.Command) (*Input, error) {
+	cpu := flags.FlagToInt64Pointer(p, cmd, CPUFlag)
+	ram := flags.FlagToInt64Pointer(p, cmd, RAMFlag)
+	storage := flags.FlagToInt64Pointer(p, cmd, StorageFlag)
+	resources := []struct {
+		flag  string
+		value *int64
+	}{
+		{CPUFlag, cpu},
+		{RAMFlag, ram},
+		{StorageFlag, storage},
+	}
+	for _, resource := range resources {
+		if resource.value != nil && *resource.value < 0 {
+			return nil, &errors.FlagValidationError{
+				Flag:    resource.flag,
+				Details: "must not be negative",
+			}
+		}
+	}
+
+	limit := flags.FlagToInt64Pointer(p, cmd, LimitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    LimitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	return &Input{
+		CPU:     cpu,
+		RAM:     ram,
+		Storage: storage,
+		Version: flags.FlagToStringPointer(p, cmd, VersionFlag),
+		Limit:   limit,
+	}, nil
+}
+
+// RecommendPlans returns the plans with at least the resources of the input, smallest first and truncated to the limit of the input
+func RecommendPlans(plans []Candidate, input *Input) []Candidate {
+	result := Recommend(plans, Requirements{
+		CPU:     input.CPU,
+		RAM:     input.RAM,
+		Storage: input.Storage,
+	})
+	if input.Limit != nil && len(result) > int(*input.Limit) {
+		result = result[:*input.Limit]
+	}
+	return result
+}
+
+// OutputPlans prints the recommended plans of a DSA service
+func OutputPlans(p *print.Printer, outputFormat string, plans []Candidate) error {
+	table := tables.NewTable()
+	table.SetHeader("ID", "NAME", "VERSION", "CPU", "RAM (GB)", "STORAGE (GB)", "DESCRIPTION")
+	for i := range plans {
+		plan := plans[i]
+		table.AddRow(
+			plan.Id,
+			plan.Name,
+			plan.Version,
+			plan.CPU,
+			plan.RAM,
+			plan.Storage,
+			plan.Description,
+		)
+	}
+	return outputResult(p, outputFormat, plans, &table)
+}
+
+// OutputFlavors prints the recommended flavors of a Flex service
+func OutputFlavors(p *print.Printer, outputFormat string, flavors []Candidate) error {
+	table := tables.NewTable()
+	table.SetHeader("ID", "CPU", "RAM (GB)", "DESCRIPTION")
+	for i := range flavors {
+		flavor := flavors[i]
+		table.AddRow(
+			flavor.Id,
+			flavor.CPU,
+			flavor.RAM,
+			flavor.Description,
+		)
+	}
+	return outputResult(p, outputFormat, flavors, &table)
+}
+
+func outputResult(p *print.Printer, outputFormat string, candidates []Candidate, table *tables.Table) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(candidates, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal recommendations: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(candidates, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal recommendations: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
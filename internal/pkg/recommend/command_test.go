@@ -0,0 +1,224 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedInput *Input
+	}{
+		{
+			description:   "no values",
+			flagValues:    map[string]string{},
+			isValid:       true,
+			expectedInput: &Input{},
+		},
+		{
+			description: "all values",
+			flagValues: map[string]string{
+				CPUFlag:     "4",
+				RAMFlag:     "16",
+				StorageFlag: "100",
+				VersionFlag: "7",
+				LimitFlag:   "1",
+			},
+			isValid: true,
+			expectedInput: &Input{
+				CPU:     utils.Ptr(int64(4)),
+				RAM:     utils.Ptr(int64(16)),
+				Storage: utils.Ptr(int64(100)),
+				Version: utils.Ptr("7"),
+				Limit:   utils.Ptr(int64(1)),
+			},
+		},
+		{
+			description: "cpu negative",
+			flagValues: map[string]string{
+				CPUFlag: "-1",
+			},
+			isValid: false,
+		},
+		{
+			description: "storage negative",
+			flagValues: map[string]string{
+				StorageFlag: "-1",
+			},
+			isValid: false,
+		},
+		{
+			description: "limit invalid",
+			flagValues: map[string]string{
+				LimitFlag: "0",
+			},
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := &cobra.Command{}
+			ConfigureFlags(cmd, "storage", "version")
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			input, err := ParseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(input, tt.expectedInput)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRecommendPlans(t *testing.T) {
+	small := Candidate{Id: "small", CPU: 1, RAM: 2, Storage: 10}
+	medium := Candidate{Id: "medium", CPU: 2, RAM: 4, Storage: 20}
+	large := Candidate{Id: "large", CPU: 4, RAM: 16, Storage: 80}
+	plans := []Candidate{large, small, medium}
+
+	tests := []struct {
+		description string
+		input       *Input
+		expected    []Candidate
+	}{
+		{
+			description: "no requirements",
+			input:       &Input{},
+			expected:    []Candidate{small, medium, large},
+		},
+		{
+			description: "storage",
+			input: &Input{
+				Storage: utils.Ptr(int64(20)),
+			},
+			expected: []Candidate{medium, large},
+		},
+		{
+			description: "limit",
+			input: &Input{
+				CPU:   utils.Ptr(int64(2)),
+				Limit: utils.Ptr(int64(1)),
+			},
+			expected: []Candidate{medium},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := RecommendPlans(plans, tt.input)
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputPlans(t *testing.T) {
+	type args struct {
+		outputFormat string
+		plans        []Candidate
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "slice with empty elements",
+			args: args{
+				plans: []Candidate{{}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "json output",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				plans:        []Candidate{{Id: "id", CPU: 1, RAM: 2, Storage: 10}},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OutputPlans(p, tt.args.outputFormat, tt.args.plans); (err != nil) != tt.wantErr {
+				t.Errorf("OutputPlans() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputFlavors(t *testing.T) {
+	type args struct {
+		outputFormat string
+		flavors      []Candidate
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "slice with empty elements",
+			args: args{
+				flavors: []Candidate{{}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml output",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				flavors:      []Candidate{{Id: "id", CPU: 2, RAM: 4}},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OutputFlavors(p, tt.args.outputFormat, tt.args.flavors); (err != nil) != tt.wantErr {
+				t.Errorf("OutputFlavors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+)
+
+// StorageRange is the range of storage sizes (in GB) supported by a flavor
+type StorageRange struct {
+	Min int64
+	Max int64
+}
+
+// FlavorClient lists the options of a Flex service, converted from the service specific API responses
+type FlavorClient interface {
+	ListVersions(ctx context.Context) ([]string, error)
+	ListFlavors(ctx context.Context) ([]Candidate, error)
+	// GetStorageRange returns nil if the flavor has no storage range
+	GetStorageRange(ctx context.Context, flavorId string) (*StorageRange, error)
+}
+
+// RecommendFlavors returns the flavors with at least the CPU and RAM of the input, smallest first and truncated to the limit of the input.
+// If the input has a storage size, only flavors supporting it are returned. If it has a version, the version must be available.
+func RecommendFlavors(ctx context.Context, input *Input, client FlavorClient) ([]Candidate, error) {
+	if input.Version != nil {
+		versions, err := client.ListVersions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(versions, *input.Version) {
+			return nil, &errors.FlagValidationError{
+				Flag:    VersionFlag,
+				Details: fmt.Sprintf("version %q is not available, available versions are: %s", *input.Version, strings.Join(versions, ", ")),
+			}
+		}
+	}
+
+	flavors, err := client.ListFlavors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recommended := Recommend(flavors, Requirements{
+		CPU: input.CPU,
+		RAM: input.RAM,
+	})
+
+	result := []Candidate{}
+	for i := range recommended {
+		if input.Limit != nil && len(result) >= int(*input.Limit) {
+			break
+		}
+		flavor := recommended[i]
+		if input.Storage != nil {
+			storageRange, err := client.GetStorageRange(ctx, flavor.Id)
+			if err != nil {
+				return nil, err
+			}
+			if storageRange == nil || *input.Storage < storageRange.Min || *input.Storage > storageRange.Max {
+				continue
+			}
+			flavor.Storage = *input.Storage
+		}
+		result = append(result, flavor)
+	}
+	return result, nil
+}
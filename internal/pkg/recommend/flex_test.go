@@ -0,0 +1,170 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type flavorClientMocked struct {
+	listVersionsFails    bool
+	listFlavorsFails     bool
+	getStorageRangeFails bool
+
+	// Maximum storage size per flavor ID, flavors without one have no storage range
+	maxStorages map[string]int64
+}
+
+func (c *flavorClientMocked) ListVersions(_ context.Context) ([]string, error) {
+	if c.listVersionsFails {
+		return nil, fmt.Errorf("list versions failed")
+	}
+	return []string{"15", "16"}, nil
+}
+
+func (c *flavorClientMocked) ListFlavors(_ context.Context) ([]Candidate, error) {
+	if c.listFlavorsFails {
+		return nil, fmt.Errorf("list flavors failed")
+	}
+	return []Candidate{
+		{Id: "large", CPU: 8, RAM: 32},
+		{Id: "small", CPU: 2, RAM: 4},
+		{Id: "medium", CPU: 4, RAM: 16},
+		{Id: "no-storage", CPU: 4, RAM: 16},
+	}, nil
+}
+
+func (c *flavorClientMocked) GetStorageRange(_ context.Context, flavorId string) (*StorageRange, error) {
+	if c.getStorageRangeFails {
+		return nil, fmt.Errorf("get storage range failed")
+	}
+	maxStorage, ok := c.maxStorages[flavorId]
+	if !ok {
+		return nil, nil
+	}
+	return &StorageRange{Min: 5, Max: maxStorage}, nil
+}
+
+func TestRecommendFlavors(t *testing.T) {
+	tests := []struct {
+		description          string
+		input                *Input
+		listVersionsFails    bool
+		listFlavorsFails     bool
+		getStorageRangeFails bool
+		isValid              bool
+		expected             []Candidate
+	}{
+		{
+			description: "cpu and ram",
+			input: &Input{
+				CPU: utils.Ptr(int64(4)),
+				RAM: utils.Ptr(int64(16)),
+			},
+			isValid: true,
+			expected: []Candidate{
+				{Id: "medium", CPU: 4, RAM: 16},
+				{Id: "no-storage", CPU: 4, RAM: 16},
+				{Id: "large", CPU: 8, RAM: 32},
+			},
+		},
+		{
+			description: "limit",
+			input: &Input{
+				CPU:   utils.Ptr(int64(4)),
+				Limit: utils.Ptr(int64(1)),
+			},
+			isValid:  true,
+			expected: []Candidate{{Id: "medium", CPU: 4, RAM: 16}},
+		},
+		{
+			description: "storage",
+			input: &Input{
+				CPU:     utils.Ptr(int64(4)),
+				Storage: utils.Ptr(int64(200)),
+			},
+			isValid:  true,
+			expected: []Candidate{{Id: "large", CPU: 8, RAM: 32, Storage: 200}},
+		},
+		{
+			description: "storage with limit",
+			input: &Input{
+				Storage: utils.Ptr(int64(50)),
+				Limit:   utils.Ptr(int64(2)),
+			},
+			isValid: true,
+			expected: []Candidate{
+				{Id: "small", CPU: 2, RAM: 4, Storage: 50},
+				{Id: "medium", CPU: 4, RAM: 16, Storage: 50},
+			},
+		},
+		{
+			description: "available version",
+			input: &Input{
+				CPU:     utils.Ptr(int64(8)),
+				Version: utils.Ptr("16"),
+			},
+			isValid:  true,
+			expected: []Candidate{{Id: "large", CPU: 8, RAM: 32}},
+		},
+		{
+			description: "unavailable version",
+			input: &Input{
+				Version: utils.Ptr("9"),
+			},
+			isValid: false,
+		},
+		{
+			description:       "list versions fails",
+			input:             &Input{Version: utils.Ptr("16")},
+			listVersionsFails: true,
+			isValid:           false,
+		},
+		{
+			description:      "list flavors fails",
+			input:            &Input{},
+			listFlavorsFails: true,
+			isValid:          false,
+		},
+		{
+			description:          "get storage range fails",
+			input:                &Input{Storage: utils.Ptr(int64(20))},
+			getStorageRangeFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &flavorClientMocked{
+				listVersionsFails:    tt.listVersionsFails,
+				listFlavorsFails:     tt.listFlavorsFails,
+				getStorageRangeFails: tt.getStorageRangeFails,
+				maxStorages: map[string]int64{
+					"small":  100,
+					"medium": 100,
+					"large":  500,
+				},
+			}
+
+			flavors, err := RecommendFlavors(context.Background(), tt.input, client)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error recommending flavors: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(flavors, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
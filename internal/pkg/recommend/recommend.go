@@ -0,0 +1,127 @@
+package recommend
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+// DSA plan names encode the resources of the plan, e.g. "stackit-redis-1.2.10-replica" has 1 CPU, 2 GB RAM and 10 GB storage
+var dsaPlanNameRegex = regexp.MustCompile(`-(\d+)\.(\d+)\.(\d+)(-|$)`)
+
+// Candidate is a plan or flavor which can be recommended. Resources which are not known are 0.
+type Candidate struct {
+	Id          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	CPU         int64  `json:"cpu"`
+	RAM         int64  `json:"ram"`
+	Storage     int64  `json:"storage,omitempty"`
+}
+
+// Requirements are the minimum resources a candidate must have. Requirements which are nil are ignored.
+type Requirements struct {
+	CPU     *int64
+	RAM     *int64
+	Storage *int64
+}
+
+// ParseDSAPlanName returns the number of CPUs, the RAM (in GB) and the storage (in GB) encoded in the name of a DSA plan
+func ParseDSAPlanName(name string) (cpu, ram, storage int64, ok bool) {
+	matches := dsaPlanNameRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return 0, 0, 0, false
+	}
+	values := make([]int64, 3)
+	for i := range values {
+		value, err := strconv.ParseInt(matches[i+1], 10, 64)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		values[i] = value
+	}
+	return values[0], values[1], values[2], true
+}
+
+// NewDSAPlanCandidate returns the candidate of a DSA plan, with the resources parsed from the name of the plan.
+// It returns false if the plan has no ID or its name does not encode its resources.
+func NewDSAPlanCandidate(id, name, version, description *string) (Candidate, bool) {
+	cpu, ram, storage, ok := ParseDSAPlanName(utils.PtrString(name))
+	if !ok || id == nil {
+		return Candidate{}, false
+	}
+	return Candidate{
+		Id:          *id,
+		Name:        utils.PtrString(name),
+		Version:     utils.PtrString(version),
+		Description: utils.PtrString(description),
+		CPU:         cpu,
+		RAM:         ram,
+		Storage:     storage,
+	}, true
+}
+
+// LoadDSAPlanId returns the ID of the smallest of the plans of the given version with at least the given CPU and RAM
+func LoadDSAPlanId(service string, cpu, ram *int64, version string, availableVersions []string, plans []Candidate) (*string, error) {
+	if len(plans) == 0 {
+		versions := ""
+		for _, v := range availableVersions {
+			versions = fmt.Sprintf("%s\n- %s", versions, v)
+		}
+		details := fmt.Sprintf("There are no plans for version %q. Available versions are: %s", version, versions)
+		return nil, &errors.DSAInvalidPlanError{
+			Service: service,
+			Details: details,
+		}
+	}
+
+	recommended := Recommend(plans, Requirements{CPU: cpu, RAM: ram})
+	if len(recommended) == 0 {
+		availablePlanNames := ""
+		for _, c := range plans {
+			availablePlanNames = fmt.Sprintf("%s\n- %s (%d CPU, %d GB RAM)", availablePlanNames, c.Name, c.CPU, c.RAM)
+		}
+		details := fmt.Sprintf("There is no plan for version %s with at least %d CPU and %d GB RAM. Available plans for that version are: %s", version, utils.PtrValue(cpu), utils.PtrValue(ram), availablePlanNames)
+		return nil, &errors.DSAInvalidPlanError{
+			Service: service,
+			Details: details,
+		}
+	}
+	return utils.Ptr(recommended[0].Id), nil
+}
+
+// Recommend returns the candidates which fulfill the requirements, smallest first.
+// Candidates with the same resources keep their order.
+func Recommend(candidates []Candidate, requirements Requirements) []Candidate {
+	result := []Candidate{}
+	for i := range candidates {
+		c := candidates[i]
+		if !fulfills(c.CPU, requirements.CPU) || !fulfills(c.RAM, requirements.RAM) || !fulfills(c.Storage, requirements.Storage) {
+			continue
+		}
+		result = append(result, c)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CPU != result[j].CPU {
+			return result[i].CPU < result[j].CPU
+		}
+		if result[i].RAM != result[j].RAM {
+			return result[i].RAM < result[j].RAM
+		}
+		return result[i].Storage < result[j].Storage
+	})
+	return result
+}
+
+func fulfills(value int64, required *int64) bool {
+	if required == nil {
+		return true
+	}
+	return value >= *required
+}
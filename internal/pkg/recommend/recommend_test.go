@@ -0,0 +1,182 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDSAPlanName(t *testing.T) {
+	tests := []struct {
+		description     string
+		name            string
+		isValid         bool
+		expectedCPU     int64
+		expectedRAM     int64
+		expectedStorage int64
+	}{
+		{
+			description:     "single",
+			name:            "stackit-redis-1.2.10-single",
+			isValid:         true,
+			expectedCPU:     1,
+			expectedRAM:     2,
+			expectedStorage: 10,
+		},
+		{
+			description:     "replica",
+			name:            "stackit-opensearch-4.16.80-replica",
+			isValid:         true,
+			expectedCPU:     4,
+			expectedRAM:     16,
+			expectedStorage: 80,
+		},
+		{
+			description:     "without suffix",
+			name:            "stackit-mariadb-2.4.20",
+			isValid:         true,
+			expectedCPU:     2,
+			expectedRAM:     4,
+			expectedStorage: 20,
+		},
+		{
+			description: "no resources",
+			name:        "stackit-redis-small",
+			isValid:     false,
+		},
+		{
+			description: "empty",
+			name:        "",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cpu, ram, storage, ok := ParseDSAPlanName(tt.name)
+			if ok != tt.isValid {
+				t.Fatalf("expected ok to be %t, got %t", tt.isValid, ok)
+			}
+			if cpu != tt.expectedCPU || ram != tt.expectedRAM || storage != tt.expectedStorage {
+				t.Fatalf("expected %d/%d/%d, got %d/%d/%d", tt.expectedCPU, tt.expectedRAM, tt.expectedStorage, cpu, ram, storage)
+			}
+		})
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	small := Candidate{Id: "small", CPU: 1, RAM: 2, Storage: 10}
+	medium := Candidate{Id: "medium", CPU: 2, RAM: 4, Storage: 20}
+	mediumMoreRAM := Candidate{Id: "medium-more-ram", CPU: 2, RAM: 8, Storage: 20}
+	large := Candidate{Id: "large", CPU: 4, RAM: 16, Storage: 80}
+	unknownStorage := Candidate{Id: "unknown-storage", CPU: 4, RAM: 16}
+	candidates := []Candidate{large, unknownStorage, mediumMoreRAM, small, medium}
+
+	tests := []struct {
+		description  string
+		requirements Requirements
+		expected     []Candidate
+	}{
+		{
+			description:  "no requirements",
+			requirements: Requirements{},
+			expected:     []Candidate{small, medium, mediumMoreRAM, unknownStorage, large},
+		},
+		{
+			description: "cpu and ram",
+			requirements: Requirements{
+				CPU: utils.Ptr(int64(2)),
+				RAM: utils.Ptr(int64(5)),
+			},
+			expected: []Candidate{mediumMoreRAM, unknownStorage, large},
+		},
+		{
+			description: "storage",
+			requirements: Requirements{
+				Storage: utils.Ptr(int64(50)),
+			},
+			expected: []Candidate{large},
+		},
+		{
+			description: "no match",
+			requirements: Requirements{
+				CPU: utils.Ptr(int64(16)),
+			},
+			expected: []Candidate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := Recommend(candidates, tt.requirements)
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadDSAPlanId(t *testing.T) {
+	plans := []Candidate{
+		{Id: "large", Name: "stackit-redis-4.16.80-single", CPU: 4, RAM: 16, Storage: 80},
+		{Id: "small", Name: "stackit-redis-1.2.10-single", CPU: 1, RAM: 2, Storage: 10},
+	}
+
+	tests := []struct {
+		description string
+		cpu         *int64
+		ram         *int64
+		plans       []Candidate
+		isValid     bool
+		expectedId  string
+	}{
+		{
+			description: "smallest matching plan",
+			cpu:         utils.Ptr(int64(1)),
+			ram:         utils.Ptr(int64(2)),
+			plans:       plans,
+			isValid:     true,
+			expectedId:  "small",
+		},
+		{
+			description: "only ram",
+			ram:         utils.Ptr(int64(4)),
+			plans:       plans,
+			isValid:     true,
+			expectedId:  "large",
+		},
+		{
+			description: "no matching plan",
+			cpu:         utils.Ptr(int64(8)),
+			plans:       plans,
+			isValid:     false,
+		},
+		{
+			description: "no plans",
+			cpu:         utils.Ptr(int64(1)),
+			plans:       []Candidate{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			id, err := LoadDSAPlanId("redis", tt.cpu, tt.ram, "7", []string{"6", "7"}, tt.plans)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error loading plan id: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if *id != tt.expectedId {
+				t.Fatalf("expected plan id %q, got %q", tt.expectedId, *id)
+			}
+		})
+	}
+}
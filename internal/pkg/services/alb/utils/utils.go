@@ -1,4 +1,169 @@
 package utils
 
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+const (
+	ProtocolHTTP  = "PROTOCOL_HTTP"
+	ProtocolHTTPS = "PROTOCOL_HTTPS"
+)
+
+// enforce implementation of interfaces
+var (
+	_ AlbClient = &alb.APIClient{}
+)
+
 type AlbClient interface {
+	GetLoadBalancerExecute(ctx context.Context, projectId, region, name string) (*alb.LoadBalancer, error)
+	UpdateLoadBalancer(ctx context.Context, projectId, region, name string) alb.ApiUpdateLoadBalancerRequest
+}
+
+// ToPayloadLoadBalancer returns the payload to update the load balancer with its current configuration.
+// The version is kept, so that the update fails if the load balancer was changed in the meantime.
+func ToPayloadLoadBalancer(loadBalancer *alb.LoadBalancer) *alb.UpdateLoadBalancerPayload {
+	if loadBalancer == nil {
+		return nil
+	}
+	payload := alb.UpdateLoadBalancerPayload(*loadBalancer)
+	// Errors and status are set by the API
+	payload.Errors = nil
+	payload.Status = nil
+	return &payload
+}
+
+// FindListenerByPort returns the listener of the load balancer on the given port
+func FindListenerByPort(loadBalancer *alb.LoadBalancer, port int64) *alb.Listener {
+	if loadBalancer == nil || loadBalancer.Listeners == nil {
+		return nil
+	}
+	for i := range *loadBalancer.Listeners {
+		listener := &(*loadBalancer.Listeners)[i]
+		if listener.Port != nil && *listener.Port == port {
+			return listener
+		}
+	}
+	return nil
+}
+
+func AddListener(loadBalancer *alb.LoadBalancer, listener *alb.Listener) error {
+	if loadBalancer == nil {
+		return fmt.Errorf("load balancer is nil")
+	}
+	if listener == nil || listener.Port == nil {
+		return fmt.Errorf("listener port is not set")
+	}
+	if FindListenerByPort(loadBalancer, *listener.Port) != nil {
+		return fmt.Errorf("a listener on port %d already exists", *listener.Port)
+	}
+	if loadBalancer.Listeners == nil {
+		loadBalancer.Listeners = &[]alb.Listener{}
+	}
+	*loadBalancer.Listeners = append(*loadBalancer.Listeners, *listener)
+	return nil
+}
+
+func RemoveListener(loadBalancer *alb.LoadBalancer, port int64) error {
+	if FindListenerByPort(loadBalancer, port) == nil {
+		return fmt.Errorf("no listener on port %d found", port)
+	}
+	*loadBalancer.Listeners = slices.DeleteFunc(*loadBalancer.Listeners, func(listener alb.Listener) bool {
+		return listener.Port != nil && *listener.Port == port
+	})
+	return nil
+}
+
+// AddRule adds a routing rule for the host to the listener on the given port.
+// The host config is created if the listener has no rules for the host yet.
+func AddRule(loadBalancer *alb.LoadBalancer, port int64, host string, rule *alb.Rule) error {
+	if rule == nil || rule.TargetPool == nil {
+		return fmt.Errorf("target pool of the rule is not set")
+	}
+	listener := FindListenerByPort(loadBalancer, port)
+	if listener == nil {
+		return fmt.Errorf("no listener on port %d found", port)
+	}
+	if !slices.ContainsFunc(utils.PtrValue(loadBalancer.TargetPools), func(pool alb.TargetPool) bool {
+		return utils.PtrString(pool.Name) == *rule.TargetPool
+	}) {
+		return fmt.Errorf("target pool %q not found", *rule.TargetPool)
+	}
+
+	if listener.Http == nil {
+		listener.Http = &alb.ProtocolOptionsHTTP{}
+	}
+	if listener.Http.Hosts == nil {
+		listener.Http.Hosts = &[]alb.HostConfig{}
+	}
+	hosts := listener.Http.Hosts
+	index := slices.IndexFunc(*hosts, func(hostConfig alb.HostConfig) bool {
+		return utils.PtrString(hostConfig.Host) == host
+	})
+	if index < 0 {
+		*hosts = append(*hosts, alb.HostConfig{Host: utils.Ptr(host), Rules: &[]alb.Rule{}})
+		index = len(*hosts) - 1
+	}
+	hostConfig := &(*hosts)[index]
+	if hostConfig.Rules == nil {
+		hostConfig.Rules = &[]alb.Rule{}
+	}
+
+	pathPrefix := normalizePathPrefix(rule.PathPrefix)
+	if slices.ContainsFunc(*hostConfig.Rules, func(r alb.Rule) bool {
+		return normalizePathPrefix(r.PathPrefix) == pathPrefix
+	}) {
+		return fmt.Errorf("a rule for host %q and path prefix %q already exists on the listener on port %d", host, pathPrefix, port)
+	}
+	*hostConfig.Rules = append(*hostConfig.Rules, *rule)
+	return nil
+}
+
+// RemoveRule removes the routing rule for the host and path prefix from the listener on the given port.
+// The host config is removed if it has no rules left.
+func RemoveRule(loadBalancer *alb.LoadBalancer, port int64, host, pathPrefix string) error {
+	listener := FindListenerByPort(loadBalancer, port)
+	if listener == nil {
+		return fmt.Errorf("no listener on port %d found", port)
+	}
+	if listener.Http == nil || listener.Http.Hosts == nil {
+		return fmt.Errorf("no rules for host %q found on the listener on port %d", host, port)
+	}
+	hosts := listener.Http.Hosts
+	index := slices.IndexFunc(*hosts, func(hostConfig alb.HostConfig) bool {
+		return utils.PtrString(hostConfig.Host) == host
+	})
+	if index < 0 {
+		return fmt.Errorf("no rules for host %q found on the listener on port %d", host, port)
+	}
+	hostConfig := &(*hosts)[index]
+
+	pathPrefix = normalizePathPrefix(&pathPrefix)
+	rules := utils.PtrValue(hostConfig.Rules)
+	remaining := slices.DeleteFunc(slices.Clone(rules), func(r alb.Rule) bool {
+		return normalizePathPrefix(r.PathPrefix) == pathPrefix
+	})
+	if len(remaining) == len(rules) {
+		return fmt.Errorf("no rule for host %q and path prefix %q found on the listener on port %d", host, pathPrefix, port)
+	}
+
+	if len(remaining) == 0 {
+		*hosts = slices.Delete(*hosts, index, index+1)
+		return nil
+	}
+	hostConfig.Rules = &remaining
+	return nil
+}
+
+// normalizePathPrefix returns "/" for an empty path prefix, as both match the root path
+func normalizePathPrefix(pathPrefix *string) string {
+	if pathPrefix == nil || *pathPrefix == "" {
+		return "/"
+	}
+	return *pathPrefix
 }
@@ -1,4 +1,254 @@
 package utils
 
-type AlbClientMocked struct {
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stackitcloud/stackit-sdk-go/services/alb"
+)
+
+func fixtureLoadBalancer(mods ...func(*alb.LoadBalancer)) *alb.LoadBalancer {
+	lb := &alb.LoadBalancer{
+		Name:    utils.Ptr("my-load-balancer"),
+		Status:  utils.Ptr("STATUS_READY"),
+		Version: utils.Ptr("1"),
+		Errors:  &[]alb.LoadBalancerError{},
+		Listeners: &[]alb.Listener{
+			{
+				Port:     utils.Ptr(int64(80)),
+				Protocol: utils.Ptr(ProtocolHTTP),
+				Http: &alb.ProtocolOptionsHTTP{
+					Hosts: &[]alb.HostConfig{
+						{
+							Host: utils.Ptr("example.com"),
+							Rules: &[]alb.Rule{
+								{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("web")},
+							},
+						},
+					},
+				},
+			},
+		},
+		TargetPools: &[]alb.TargetPool{
+			{Name: utils.Ptr("web")},
+			{Name: utils.Ptr("api")},
+		},
+	}
+	for _, mod := range mods {
+		mod(lb)
+	}
+	return lb
+}
+
+func TestToPayloadLoadBalancer(t *testing.T) {
+	payload := ToPayloadLoadBalancer(fixtureLoadBalancer())
+	if payload == nil {
+		t.Fatalf("payload is nil")
+	}
+	if payload.Status != nil || payload.Errors != nil {
+		t.Fatalf("status and errors must not be set in the payload")
+	}
+	if utils.PtrString(payload.Version) != "1" {
+		t.Fatalf("expected version to be kept, got %q", utils.PtrString(payload.Version))
+	}
+	if ToPayloadLoadBalancer(nil) != nil {
+		t.Fatalf("expected nil payload for nil load balancer")
+	}
+}
+
+func TestAddListener(t *testing.T) {
+	tests := []struct {
+		description string
+		lb          *alb.LoadBalancer
+		listener    *alb.Listener
+		isValid     bool
+		expected    int
+	}{
+		{
+			description: "base",
+			lb:          fixtureLoadBalancer(),
+			listener:    &alb.Listener{Port: utils.Ptr(int64(443)), Protocol: utils.Ptr(ProtocolHTTPS)},
+			isValid:     true,
+			expected:    2,
+		},
+		{
+			description: "no listeners",
+			lb:          fixtureLoadBalancer(func(lb *alb.LoadBalancer) { lb.Listeners = nil }),
+			listener:    &alb.Listener{Port: utils.Ptr(int64(443)), Protocol: utils.Ptr(ProtocolHTTPS)},
+			isValid:     true,
+			expected:    1,
+		},
+		{
+			description: "port in use",
+			lb:          fixtureLoadBalancer(),
+			listener:    &alb.Listener{Port: utils.Ptr(int64(80)), Protocol: utils.Ptr(ProtocolHTTP)},
+			isValid:     false,
+		},
+		{
+			description: "no port",
+			lb:          fixtureLoadBalancer(),
+			listener:    &alb.Listener{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := AddListener(tt.lb, tt.listener)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("add listener: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if len(*tt.lb.Listeners) != tt.expected {
+				t.Fatalf("expected %d listeners, got %d", tt.expected, len(*tt.lb.Listeners))
+			}
+		})
+	}
+}
+
+func TestRemoveListener(t *testing.T) {
+	lb := fixtureLoadBalancer()
+	err := RemoveListener(lb, 443)
+	if err == nil {
+		t.Fatalf("expected error for unknown port")
+	}
+	err = RemoveListener(lb, 80)
+	if err != nil {
+		t.Fatalf("remove listener: %v", err)
+	}
+	if len(*lb.Listeners) != 0 {
+		t.Fatalf("expected no listeners, got %d", len(*lb.Listeners))
+	}
+}
+
+func TestAddRule(t *testing.T) {
+	tests := []struct {
+		description   string
+		port          int64
+		host          string
+		rule          *alb.Rule
+		isValid       bool
+		expectedHosts []alb.HostConfig
+	}{
+		{
+			description: "existing host",
+			port:        80,
+			host:        "example.com",
+			rule:        &alb.Rule{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")},
+			isValid:     true,
+			expectedHosts: []alb.HostConfig{
+				{
+					Host: utils.Ptr("example.com"),
+					Rules: &[]alb.Rule{
+						{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("web")},
+						{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")},
+					},
+				},
+			},
+		},
+		{
+			description: "new host",
+			port:        80,
+			host:        "api.example.com",
+			rule:        &alb.Rule{TargetPool: utils.Ptr("api")},
+			isValid:     true,
+			expectedHosts: []alb.HostConfig{
+				{
+					Host: utils.Ptr("example.com"),
+					Rules: &[]alb.Rule{
+						{PathPrefix: utils.Ptr("/"), TargetPool: utils.Ptr("web")},
+					},
+				},
+				{
+					Host: utils.Ptr("api.example.com"),
+					Rules: &[]alb.Rule{
+						{TargetPool: utils.Ptr("api")},
+					},
+				},
+			},
+		},
+		{
+			description: "duplicate path prefix",
+			port:        80,
+			host:        "example.com",
+			rule:        &alb.Rule{PathPrefix: utils.Ptr(""), TargetPool: utils.Ptr("api")},
+			isValid:     false,
+		},
+		{
+			description: "unknown target pool",
+			port:        80,
+			host:        "example.com",
+			rule:        &alb.Rule{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("unknown")},
+			isValid:     false,
+		},
+		{
+			description: "unknown listener",
+			port:        443,
+			host:        "example.com",
+			rule:        &alb.Rule{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			lb := fixtureLoadBalancer()
+			err := AddRule(lb, tt.port, tt.host, tt.rule)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("add rule: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(*(*lb.Listeners)[0].Http.Hosts, tt.expectedHosts)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	lb := fixtureLoadBalancer()
+	err := AddRule(lb, 80, "example.com", &alb.Rule{PathPrefix: utils.Ptr("/api"), TargetPool: utils.Ptr("api")})
+	if err != nil {
+		t.Fatalf("add rule: %v", err)
+	}
+
+	err = RemoveRule(lb, 80, "example.com", "/unknown")
+	if err == nil {
+		t.Fatalf("expected error for unknown path prefix")
+	}
+	err = RemoveRule(lb, 80, "unknown.example.com", "/")
+	if err == nil {
+		t.Fatalf("expected error for unknown host")
+	}
+
+	err = RemoveRule(lb, 80, "example.com", "/api")
+	if err != nil {
+		t.Fatalf("remove rule: %v", err)
+	}
+	hosts := *(*lb.Listeners)[0].Http.Hosts
+	if len(hosts) != 1 || len(*hosts[0].Rules) != 1 {
+		t.Fatalf("expected 1 host with 1 rule, got %+v", hosts)
+	}
+
+	// Removing the last rule of a host removes the host
+	err = RemoveRule(lb, 80, "example.com", "")
+	if err != nil {
+		t.Fatalf("remove rule: %v", err)
+	}
+	if len(*(*lb.Listeners)[0].Http.Hosts) != 0 {
+		t.Fatalf("expected no hosts, got %+v", *(*lb.Listeners)[0].Http.Hosts)
+	}
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/cassette"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
@@ -29,6 +30,12 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*authorization.APICli
 		cfgOptions = append(cfgOptions, sdkConfig.WithEndpoint(customEndpoint))
 	}
 
+	cassetteCfgOption, err := cassette.ConfigOption(p)
+	if err != nil {
+		return nil, err
+	}
+	cfgOptions = append(cfgOptions, cassetteCfgOption)
+
 	if p.IsVerbosityDebug() {
 		cfgOptions = append(cfgOptions,
 			sdkConfig.WithMiddleware(print.RequestResponseCapturer(p, nil)),
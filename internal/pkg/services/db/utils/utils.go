@@ -0,0 +1,664 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	logmeClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/client"
+	mariadbClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mongodbflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
+	opensearchClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/opensearch/client"
+	postgresflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	rabbitmqClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/rabbitmq/client"
+	redisClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/redis/client"
+	sqlserverflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/logme"
+	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/opensearch"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/rabbitmq"
+	"github.com/stackitcloud/stackit-sdk-go/services/redis"
+	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
+)
+
+const (
+	ServicePostgresFlex  = "postgresflex"
+	ServiceMongoDBFlex   = "mongodbflex"
+	ServiceSQLServerFlex = "sqlserverflex"
+	ServiceMariaDB       = "mariadb"
+	ServiceRedis         = "redis"
+	ServiceRabbitMQ      = "rabbitmq"
+	ServiceOpenSearch    = "opensearch"
+	ServiceLogMe         = "logme"
+
+	FormatURI    = "uri"
+	FormatEnv    = "env"
+	FormatDotnet = "dotnet"
+	FormatJDBC   = "jdbc"
+
+	// Environment variable used to pass the connection URI to clients which can't read the password from the environment
+	ConnectionURIEnvVar = "STACKIT_DB_URI"
+)
+
+var serviceToURIScheme = map[string]string{
+	ServicePostgresFlex:  "postgresql",
+	ServiceMongoDBFlex:   "mongodb",
+	ServiceSQLServerFlex: "sqlserver",
+	ServiceMariaDB:       "mysql",
+	ServiceRedis:         "redis",
+	ServiceRabbitMQ:      "amqp",
+	ServiceOpenSearch:    "https",
+	ServiceLogMe:         "https",
+}
+
+var serviceToFormats = map[string][]string{
+	ServicePostgresFlex:  {FormatURI, FormatEnv, FormatDotnet, FormatJDBC},
+	ServiceMongoDBFlex:   {FormatURI, FormatEnv, FormatDotnet},
+	ServiceSQLServerFlex: {FormatURI, FormatEnv, FormatDotnet, FormatJDBC},
+	ServiceMariaDB:       {FormatURI, FormatEnv, FormatDotnet, FormatJDBC},
+	ServiceRedis:         {FormatURI, FormatEnv, FormatDotnet},
+	ServiceRabbitMQ:      {FormatURI, FormatEnv},
+	ServiceOpenSearch:    {FormatURI, FormatEnv},
+	ServiceLogMe:         {FormatURI, FormatEnv},
+}
+
+var serviceToClient = map[string]string{
+	ServicePostgresFlex:  "psql",
+	ServiceMongoDBFlex:   "mongosh",
+	ServiceSQLServerFlex: "sqlcmd",
+	ServiceMariaDB:       "mysql",
+	ServiceRedis:         "redis-cli",
+}
+
+// ConnectionInfo holds the details needed to connect to a database instance
+type ConnectionInfo struct {
+	Service  string
+	Host     string
+	Port     int64
+	Username string
+	Password string
+	Database string
+}
+
+// ClientCommand is a local client invocation. Secrets are only ever part of Env, never of Args.
+type ClientCommand struct {
+	Name string
+	Args []string
+	Env  []string
+}
+
+// ConnectionInfoOptions identifies the user or credentials to get the connection details for
+type ConnectionInfoOptions struct {
+	Service       string
+	ProjectId     string
+	Region        string
+	InstanceId    string
+	UserId        string
+	ResetPassword bool
+}
+
+func AvailableServices() []string {
+	services := make([]string, 0, len(serviceToURIScheme))
+	for service := range serviceToURIScheme {
+		services = append(services, service)
+	}
+	// Dict keys aren't iterated in a consistent order
+	// So we sort the array to make the output consistent
+	slices.Sort(services)
+	return services
+}
+
+func AvailableFormats() []string {
+	return []string{FormatURI, FormatEnv, FormatDotnet, FormatJDBC}
+}
+
+// ValidateFormat checks whether connection strings of the given format can be generated for the given service
+func ValidateFormat(service, format string) error {
+	formats, ok := serviceToFormats[service]
+	if !ok {
+		return fmt.Errorf("unsupported service %q", service)
+	}
+	if !slices.Contains(formats, format) {
+		return fmt.Errorf("format %q is not supported for service %q, supported formats are: %s", format, service, strings.Join(formats, ", "))
+	}
+	return nil
+}
+
+// SupportsPasswordRetrieval returns whether the password of a user of the given service can be retrieved after its creation
+func SupportsPasswordRetrieval(service string) bool {
+	switch service {
+	case ServicePostgresFlex, ServiceMongoDBFlex, ServiceSQLServerFlex:
+		return false
+	default:
+		return true
+	}
+}
+
+// GetConnectionInfo configures the API client of the given service and gets the connection details of the user or credentials
+func GetConnectionInfo(ctx context.Context, p *print.Printer, cliVersion string, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	switch opts.Service {
+	case ServicePostgresFlex:
+		apiClient, err := postgresflexClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetPostgresFlexConnectionInfo(ctx, apiClient, opts)
+	case ServiceMongoDBFlex:
+		apiClient, err := mongodbflexClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetMongoDBFlexConnectionInfo(ctx, apiClient, opts)
+	case ServiceSQLServerFlex:
+		apiClient, err := sqlserverflexClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetSQLServerFlexConnectionInfo(ctx, apiClient, opts)
+	case ServiceMariaDB:
+		apiClient, err := mariadbClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetMariaDBConnectionInfo(ctx, apiClient, opts)
+	case ServiceRedis:
+		apiClient, err := redisClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetRedisConnectionInfo(ctx, apiClient, opts)
+	case ServiceRabbitMQ:
+		apiClient, err := rabbitmqClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetRabbitMQConnectionInfo(ctx, apiClient, opts)
+	case ServiceOpenSearch:
+		apiClient, err := opensearchClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetOpenSearchConnectionInfo(ctx, apiClient, opts)
+	case ServiceLogMe:
+		apiClient, err := logmeClient.ConfigureClient(p, cliVersion)
+		if err != nil {
+			return nil, err
+		}
+		return GetLogMeConnectionInfo(ctx, apiClient, opts)
+	default:
+		return nil, fmt.Errorf("unsupported service %q", opts.Service)
+	}
+}
+
+type PostgresFlexClient interface {
+	GetUserExecute(ctx context.Context, projectId, region, instanceId, userId string) (*postgresflex.GetUserResponse, error)
+	ResetUserExecute(ctx context.Context, projectId, region, instanceId, userId string) (*postgresflex.ResetUserResponse, error)
+}
+
+func GetPostgresFlexConnectionInfo(ctx context.Context, apiClient PostgresFlexClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	if opts.ResetPassword {
+		resp, err := apiClient.ResetUserExecute(ctx, opts.ProjectId, opts.Region, opts.InstanceId, opts.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("reset PostgreSQL Flex user password: %w", err)
+		}
+		if resp.Item == nil {
+			return nil, fmt.Errorf("empty PostgreSQL Flex user")
+		}
+		return &ConnectionInfo{
+			Service:  ServicePostgresFlex,
+			Host:     utils.PtrValue(resp.Item.Host),
+			Port:     utils.PtrValue(resp.Item.Port),
+			Username: utils.PtrValue(resp.Item.Username),
+			Password: utils.PtrValue(resp.Item.Password),
+			Database: utils.PtrValue(resp.Item.Database),
+		}, nil
+	}
+
+	resp, err := apiClient.GetUserExecute(ctx, opts.ProjectId, opts.Region, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get PostgreSQL Flex user: %w", err)
+	}
+	if resp.Item == nil {
+		return nil, fmt.Errorf("empty PostgreSQL Flex user")
+	}
+	return &ConnectionInfo{
+		Service:  ServicePostgresFlex,
+		Host:     utils.PtrValue(resp.Item.Host),
+		Port:     utils.PtrValue(resp.Item.Port),
+		Username: utils.PtrValue(resp.Item.Username),
+	}, nil
+}
+
+type MongoDBFlexClient interface {
+	GetUserExecute(ctx context.Context, projectId, instanceId, userId string) (*mongodbflex.GetUserResponse, error)
+	ResetUserExecute(ctx context.Context, projectId, instanceId, userId string) (*mongodbflex.User, error)
+}
+
+func GetMongoDBFlexConnectionInfo(ctx context.Context, apiClient MongoDBFlexClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	if opts.ResetPassword {
+		resp, err := apiClient.ResetUserExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("reset MongoDB Flex user password: %w", err)
+		}
+		return &ConnectionInfo{
+			Service:  ServiceMongoDBFlex,
+			Host:     utils.PtrValue(resp.Host),
+			Port:     utils.PtrValue(resp.Port),
+			Username: utils.PtrValue(resp.Username),
+			Password: utils.PtrValue(resp.Password),
+			Database: utils.PtrValue(resp.Database),
+		}, nil
+	}
+
+	resp, err := apiClient.GetUserExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get MongoDB Flex user: %w", err)
+	}
+	if resp.Item == nil {
+		return nil, fmt.Errorf("empty MongoDB Flex user")
+	}
+	return &ConnectionInfo{
+		Service:  ServiceMongoDBFlex,
+		Host:     utils.PtrValue(resp.Item.Host),
+		Port:     utils.PtrValue(resp.Item.Port),
+		Username: utils.PtrValue(resp.Item.Username),
+		Database: utils.PtrValue(resp.Item.Database),
+	}, nil
+}
+
+type SQLServerFlexClient interface {
+	GetUserExecute(ctx context.Context, projectId, instanceId, userId, region string) (*sqlserverflex.GetUserResponse, error)
+	ResetUserExecute(ctx context.Context, projectId, instanceId, userId, region string) (*sqlserverflex.ResetUserResponse, error)
+}
+
+func GetSQLServerFlexConnectionInfo(ctx context.Context, apiClient SQLServerFlexClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	if opts.ResetPassword {
+		resp, err := apiClient.ResetUserExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId, opts.Region)
+		if err != nil {
+			return nil, fmt.Errorf("reset SQLServer Flex user password: %w", err)
+		}
+		if resp.Item == nil {
+			return nil, fmt.Errorf("empty SQLServer Flex user")
+		}
+		return &ConnectionInfo{
+			Service:  ServiceSQLServerFlex,
+			Host:     utils.PtrValue(resp.Item.Host),
+			Port:     utils.PtrValue(resp.Item.Port),
+			Username: utils.PtrValue(resp.Item.Username),
+			Password: utils.PtrValue(resp.Item.Password),
+			Database: utils.PtrValue(resp.Item.DefaultDatabase),
+		}, nil
+	}
+
+	resp, err := apiClient.GetUserExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId, opts.Region)
+	if err != nil {
+		return nil, fmt.Errorf("get SQLServer Flex user: %w", err)
+	}
+	if resp.Item == nil {
+		return nil, fmt.Errorf("empty SQLServer Flex user")
+	}
+	return &ConnectionInfo{
+		Service:  ServiceSQLServerFlex,
+		Host:     utils.PtrValue(resp.Item.Host),
+		Port:     utils.PtrValue(resp.Item.Port),
+		Username: utils.PtrValue(resp.Item.Username),
+		Database: utils.PtrValue(resp.Item.DefaultDatabase),
+	}, nil
+}
+
+type MariaDBClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*mariadb.CredentialsResponse, error)
+}
+
+func GetMariaDBConnectionInfo(ctx context.Context, apiClient MariaDBClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	resp, err := apiClient.GetCredentialsExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get MariaDB credentials: %w", err)
+	}
+	if resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("empty MariaDB credentials")
+	}
+	credentials := resp.Raw.Credentials
+	return &ConnectionInfo{
+		Service:  ServiceMariaDB,
+		Host:     getHost(credentials.Host, credentials.Hosts),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrValue(credentials.Username),
+		Password: utils.PtrValue(credentials.Password),
+		Database: utils.PtrValue(credentials.Name),
+	}, nil
+}
+
+type RedisClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*redis.CredentialsResponse, error)
+}
+
+func GetRedisConnectionInfo(ctx context.Context, apiClient RedisClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	resp, err := apiClient.GetCredentialsExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get Redis credentials: %w", err)
+	}
+	if resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("empty Redis credentials")
+	}
+	credentials := resp.Raw.Credentials
+	return &ConnectionInfo{
+		Service:  ServiceRedis,
+		Host:     getHost(credentials.Host, credentials.Hosts),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrValue(credentials.Username),
+		Password: utils.PtrValue(credentials.Password),
+	}, nil
+}
+
+type RabbitMQClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*rabbitmq.CredentialsResponse, error)
+}
+
+func GetRabbitMQConnectionInfo(ctx context.Context, apiClient RabbitMQClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	resp, err := apiClient.GetCredentialsExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get RabbitMQ credentials: %w", err)
+	}
+	if resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("empty RabbitMQ credentials")
+	}
+	credentials := resp.Raw.Credentials
+	return &ConnectionInfo{
+		Service:  ServiceRabbitMQ,
+		Host:     getHost(credentials.Host, credentials.Hosts),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrValue(credentials.Username),
+		Password: utils.PtrValue(credentials.Password),
+	}, nil
+}
+
+type OpenSearchClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*opensearch.CredentialsResponse, error)
+}
+
+func GetOpenSearchConnectionInfo(ctx context.Context, apiClient OpenSearchClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	resp, err := apiClient.GetCredentialsExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get OpenSearch credentials: %w", err)
+	}
+	if resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("empty OpenSearch credentials")
+	}
+	credentials := resp.Raw.Credentials
+	return &ConnectionInfo{
+		Service:  ServiceOpenSearch,
+		Host:     getHost(credentials.Host, credentials.Hosts),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrValue(credentials.Username),
+		Password: utils.PtrValue(credentials.Password),
+	}, nil
+}
+
+type LogMeClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*logme.CredentialsResponse, error)
+}
+
+func GetLogMeConnectionInfo(ctx context.Context, apiClient LogMeClient, opts *ConnectionInfoOptions) (*ConnectionInfo, error) {
+	resp, err := apiClient.GetCredentialsExecute(ctx, opts.ProjectId, opts.InstanceId, opts.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("get LogMe credentials: %w", err)
+	}
+	if resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("empty LogMe credentials")
+	}
+	credentials := resp.Raw.Credentials
+	return &ConnectionInfo{
+		Service:  ServiceLogMe,
+		Host:     utils.PtrValue(credentials.Host),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrValue(credentials.Username),
+		Password: utils.PtrValue(credentials.Password),
+	}, nil
+}
+
+// getHost returns the host of the credentials, falling back to the first of the hosts if it is not set
+func getHost(host *string, hosts *[]string) string {
+	if host != nil && *host != "" {
+		return *host
+	}
+	if hosts != nil && len(*hosts) > 0 {
+		return (*hosts)[0]
+	}
+	return ""
+}
+
+// BuildConnectionString builds a connection string of the given format from the connection details
+func BuildConnectionString(info *ConnectionInfo, format string) (string, error) {
+	err := ValidateFormat(info.Service, format)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case FormatURI:
+		return buildURI(info), nil
+	case FormatEnv:
+		return buildEnv(info), nil
+	case FormatDotnet:
+		return buildDotnet(info), nil
+	case FormatJDBC:
+		return buildJDBC(info), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func hostPort(info *ConnectionInfo) string {
+	return net.JoinHostPort(info.Host, strconv.FormatInt(info.Port, 10))
+}
+
+func buildURI(info *ConnectionInfo) string {
+	u := url.URL{
+		Scheme: serviceToURIScheme[info.Service],
+		Host:   hostPort(info),
+	}
+	switch {
+	case info.Username != "" && info.Password != "":
+		u.User = url.UserPassword(info.Username, info.Password)
+	case info.Username != "":
+		u.User = url.User(info.Username)
+	}
+	if info.Database != "" {
+		if info.Service == ServiceSQLServerFlex {
+			u.RawQuery = url.Values{"database": {info.Database}}.Encode()
+		} else {
+			u.Path = "/" + info.Database
+		}
+	}
+	return u.String()
+}
+
+func buildEnv(info *ConnectionInfo) string {
+	vars := [][2]string{
+		{"DB_HOST", info.Host},
+		{"DB_PORT", strconv.FormatInt(info.Port, 10)},
+		{"DB_USERNAME", info.Username},
+		{"DB_PASSWORD", info.Password},
+		{"DB_DATABASE", info.Database},
+		{"DB_URI", buildURI(info)},
+	}
+	lines := []string{}
+	for _, v := range vars {
+		if v[1] == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s=%s", v[0], shellQuote(v[1])))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shellQuote quotes the value so that it can be used in shells and .env files
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func buildDotnet(info *ConnectionInfo) string {
+	var parts [][2]string
+	port := strconv.FormatInt(info.Port, 10)
+	switch info.Service {
+	case ServicePostgresFlex:
+		parts = [][2]string{{"Host", info.Host}, {"Port", port}, {"Database", info.Database}, {"Username", info.Username}, {"Password", info.Password}}
+	case ServiceSQLServerFlex:
+		parts = [][2]string{{"Server", fmt.Sprintf("tcp:%s,%s", info.Host, port)}, {"Initial Catalog", info.Database}, {"User ID", info.Username}, {"Password", info.Password}}
+	case ServiceMariaDB:
+		parts = [][2]string{{"Server", info.Host}, {"Port", port}, {"Database", info.Database}, {"Uid", info.Username}, {"Pwd", info.Password}}
+	case ServiceRedis:
+		// StackExchange.Redis configuration string
+		options := []string{hostPort(info)}
+		if info.Username != "" {
+			options = append(options, fmt.Sprintf("user=%s", info.Username))
+		}
+		if info.Password != "" {
+			options = append(options, fmt.Sprintf("password=%s", info.Password))
+		}
+		return strings.Join(options, ",")
+	default:
+		// The .NET drivers of the other services take a URI
+		return buildURI(info)
+	}
+
+	items := []string{}
+	for _, part := range parts {
+		if part[1] == "" {
+			continue
+		}
+		items = append(items, fmt.Sprintf("%s=%s", part[0], dotnetQuote(part[1])))
+	}
+	return strings.Join(items, ";")
+}
+
+// dotnetQuote quotes the value of an ADO.NET connection string if needed, like DbConnectionStringBuilder does.
+// Values are quoted with double quotes, or single quotes if they contain only double quotes, and the quote character is doubled.
+func dotnetQuote(value string) string {
+	if !strings.ContainsAny(value, `;='"{}`) && strings.TrimSpace(value) == value {
+		return value
+	}
+	if strings.Contains(value, `"`) && !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+// jdbcSQLServerQuote quotes the property value of a SQL Server JDBC connection string with braces if needed, doubling the closing braces
+func jdbcSQLServerQuote(value string) string {
+	if !strings.ContainsAny(value, ";={}") && strings.TrimSpace(value) == value {
+		return value
+	}
+	return "{" + strings.ReplaceAll(value, "}", "}}") + "}"
+}
+
+func buildJDBC(info *ConnectionInfo) string {
+	switch info.Service {
+	case ServiceSQLServerFlex:
+		connStr := fmt.Sprintf("jdbc:sqlserver://%s", hostPort(info))
+		properties := [][2]string{{"databaseName", info.Database}, {"user", info.Username}, {"password", info.Password}}
+		for _, property := range properties {
+			if property[1] == "" {
+				continue
+			}
+			connStr += fmt.Sprintf(";%s=%s", property[0], jdbcSQLServerQuote(property[1]))
+		}
+		return connStr
+	default:
+		scheme := "postgresql"
+		if info.Service == ServiceMariaDB {
+			scheme = "mariadb"
+		}
+		query := url.Values{}
+		if info.Username != "" {
+			query.Set("user", info.Username)
+		}
+		if info.Password != "" {
+			query.Set("password", info.Password)
+		}
+		connStr := fmt.Sprintf("jdbc:%s://%s/%s", scheme, hostPort(info), url.PathEscape(info.Database))
+		if len(query) > 0 {
+			connStr += "?" + query.Encode()
+		}
+		return connStr
+	}
+}
+
+// SupportsClient returns whether a local client can be launched for the given service
+func SupportsClient(service string) bool {
+	_, ok := serviceToClient[service]
+	return ok
+}
+
+// BuildClientCommand builds the invocation of the local client matching the service.
+// The password is passed through the environment, so that it does not show up in the process list.
+func BuildClientCommand(info *ConnectionInfo, clientPath string) (*ClientCommand, error) {
+	name, ok := serviceToClient[info.Service]
+	if !ok {
+		return nil, fmt.Errorf("connecting to service %q is not supported", info.Service)
+	}
+	if clientPath != "" {
+		name = clientPath
+	}
+	port := strconv.FormatInt(info.Port, 10)
+
+	command := &ClientCommand{
+		Name: name,
+		Args: []string{},
+		Env:  []string{},
+	}
+	switch info.Service {
+	case ServicePostgresFlex:
+		command.Env = append(command.Env, "PGHOST="+info.Host, "PGPORT="+port, "PGUSER="+info.Username)
+		if info.Database != "" {
+			command.Env = append(command.Env, "PGDATABASE="+info.Database)
+		}
+		if info.Password != "" {
+			command.Env = append(command.Env, "PGPASSWORD="+info.Password)
+		}
+	case ServiceMongoDBFlex:
+		if info.Password == "" {
+			// Without a password, mongosh prompts for it
+			command.Args = append(command.Args, buildURI(info))
+			break
+		}
+		// mongosh doesn't read the password from the environment, so the URI is read by the shell itself
+		command.Args = append(command.Args, "--nodb", "--shell", "--eval", fmt.Sprintf("db = connect(process.env.%s)", ConnectionURIEnvVar))
+		command.Env = append(command.Env, ConnectionURIEnvVar+"="+buildURI(info))
+	case ServiceSQLServerFlex:
+		command.Args = append(command.Args, "-S", fmt.Sprintf("%s,%s", info.Host, port), "-U", info.Username)
+		if info.Database != "" {
+			command.Args = append(command.Args, "-d", info.Database)
+		}
+		if info.Password != "" {
+			command.Env = append(command.Env, "SQLCMDPASSWORD="+info.Password)
+		}
+	case ServiceMariaDB:
+		command.Args = append(command.Args, "-h", info.Host, "-P", port, "-u", info.Username)
+		if info.Password != "" {
+			command.Env = append(command.Env, "MYSQL_PWD="+info.Password)
+		}
+		if info.Database != "" {
+			command.Args = append(command.Args, info.Database)
+		}
+	case ServiceRedis:
+		command.Args = append(command.Args, "-h", info.Host, "-p", port)
+		if info.Username != "" {
+			command.Args = append(command.Args, "--user", info.Username)
+		}
+		if info.Password != "" {
+			command.Env = append(command.Env, "REDISCLI_AUTH="+info.Password)
+		}
+	}
+	return command, nil
+}
@@ -0,0 +1,479 @@
+package check
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusMismatch = "mismatch"
+	StatusMissing  = "missing"
+	StatusError    = "error"
+	StatusSkipped  = "skipped"
+
+	defaultPort    = "53"
+	defaultTimeout = 5 * time.Second
+	udpBufferSize  = 4096
+
+	// Types not known by dnsmessage
+	typeDNAME dnsmessage.Type = 39
+	typeCAA   dnsmessage.Type = 257
+)
+
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"NS":    dnsmessage.TypeNS,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"SRV":   dnsmessage.TypeSRV,
+	"PTR":   dnsmessage.TypePTR,
+	"SOA":   dnsmessage.TypeSOA,
+	"DNAME": typeDNAME,
+	"CAA":   typeCAA,
+}
+
+// RecordCheck is the result of comparing a record set stored in the API with the answer of a nameserver
+type RecordCheck struct {
+	Nameserver string   `json:"nameserver"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Status     string   `json:"status"`
+	Expected   []string `json:"expected,omitempty"`
+	Actual     []string `json:"actual,omitempty"`
+	Details    string   `json:"details,omitempty"`
+}
+
+// SerialCheck is the result of comparing the SOA serial of the zone with the one served by a nameserver
+type SerialCheck struct {
+	Nameserver string `json:"nameserver"`
+	Status     string `json:"status"`
+	Expected   int64  `json:"expected"`
+	Actual     int64  `json:"actual,omitempty"`
+	Details    string `json:"details,omitempty"`
+}
+
+// DelegationCheck is the result of comparing the nameservers of the zone with the delegation in a parent zone nameserver
+type DelegationCheck struct {
+	ParentNameserver string   `json:"parentNameserver"`
+	Status           string   `json:"status"`
+	Expected         []string `json:"expected,omitempty"`
+	Actual           []string `json:"actual,omitempty"`
+	Details          string   `json:"details,omitempty"`
+}
+
+// Query sends a non-recursive query to the nameserver (host:port) over UDP, retrying over TCP if the answer is truncated
+func Query(ctx context.Context, server, name string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	qname, err := dnsmessage.NewName(zonefile.Qualify(name, "."))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+	id := uint16(rand.N(1 << 16)) //nolint:gosec // the query ID doesn't need to be cryptographically secure here
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	var opt dnsmessage.ResourceHeader
+	err = opt.SetEDNS0(udpBufferSize, dnsmessage.RCodeSuccess, false)
+	if err != nil {
+		return nil, fmt.Errorf("set EDNS0: %w", err)
+	}
+	query.Additionals = []dnsmessage.Resource{{Header: opt, Body: &dnsmessage.OPTResource{}}}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack query: %w", err)
+	}
+
+	resp, err := exchange(ctx, "udp", server, packed)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Truncated {
+		resp, err = exchange(ctx, "tcp", server, packed)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.ID != id {
+		return nil, fmt.Errorf("query %s %s at %s: answer has unexpected ID", name, qtype, server)
+	}
+	return resp, nil
+}
+
+func exchange(ctx context.Context, network, server string, packed []byte) (*dnsmessage.Message, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", server, err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return nil, fmt.Errorf("set deadline: %w", err)
+		}
+	}
+
+	var buf []byte
+	if network == "tcp" {
+		// Messages sent over TCP are prefixed with their length
+		length := make([]byte, 2)
+		binary.BigEndian.PutUint16(length, uint16(len(packed))) //nolint:gosec // DNS messages are shorter than 64 KiB
+		_, err = conn.Write(append(length, packed...))
+		if err != nil {
+			return nil, fmt.Errorf("send query to %s: %w", server, err)
+		}
+		_, err = io.ReadFull(conn, length)
+		if err != nil {
+			return nil, fmt.Errorf("read answer from %s: %w", server, err)
+		}
+		buf = make([]byte, binary.BigEndian.Uint16(length))
+		_, err = io.ReadFull(conn, buf)
+		if err != nil {
+			return nil, fmt.Errorf("read answer from %s: %w", server, err)
+		}
+	} else {
+		_, err = conn.Write(packed)
+		if err != nil {
+			return nil, fmt.Errorf("send query to %s: %w", server, err)
+		}
+		buf = make([]byte, udpBufferSize)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("read answer from %s: %w", server, err)
+		}
+		buf = buf[:n]
+	}
+
+	var resp dnsmessage.Message
+	err = resp.Unpack(buf)
+	if err != nil {
+		return nil, fmt.Errorf("parse answer from %s: %w", server, err)
+	}
+	return &resp, nil
+}
+
+// ResolveNameserver returns the address (host:port) of a nameserver given by host name or IP address, with optional port
+func ResolveNameserver(ctx context.Context, server string) (string, error) {
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		host = server
+		port = defaultPort
+	}
+	host = strings.TrimSuffix(host, ".")
+	if net.ParseIP(host) != nil {
+		return net.JoinHostPort(host, port), nil
+	}
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return "", fmt.Errorf("resolve nameserver %q: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("resolve nameserver %q: no addresses found", host)
+	}
+	return net.JoinHostPort(addrs[0], port), nil
+}
+
+// ParentNameservers returns the host names of the nameservers of the parent zone, found with the system resolver
+func ParentNameservers(ctx context.Context, zoneDnsName string) ([]string, error) {
+	zone := strings.TrimSuffix(zonefile.Qualify(zoneDnsName, "."), ".")
+	_, parent, found := strings.Cut(zone, ".")
+	if !found || parent == "" {
+		return nil, fmt.Errorf("zone %q has no parent zone", zoneDnsName)
+	}
+	nameservers, err := net.DefaultResolver.LookupNS(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("get nameservers of parent zone %q: %w", parent, err)
+	}
+	hosts := []string{}
+	for _, ns := range nameservers {
+		hosts = append(hosts, ns.Host)
+	}
+	return hosts, nil
+}
+
+// CheckSerials compares the SOA serial of the zone with the ones served by the nameservers
+func CheckSerials(ctx context.Context, nameservers []string, zoneDnsName string, expected int64) []SerialCheck {
+	checks := []SerialCheck{}
+	for _, ns := range nameservers {
+		check := SerialCheck{Nameserver: ns, Expected: expected}
+		resp, err := Query(ctx, ns, zoneDnsName, dnsmessage.TypeSOA)
+		if err != nil {
+			check.Status = StatusError
+			check.Details = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		if !resp.Authoritative {
+			check.Status = StatusError
+			check.Details = "nameserver is not authoritative for the zone"
+			checks = append(checks, check)
+			continue
+		}
+
+		found := false
+		for _, rr := range resp.Answers {
+			soa, ok := rr.Body.(*dnsmessage.SOAResource)
+			if !ok {
+				continue
+			}
+			found = true
+			check.Actual = int64(soa.Serial)
+		}
+		switch {
+		case !found:
+			check.Status = StatusMissing
+			check.Details = fmt.Sprintf("no SOA record found, response code %s", resp.RCode)
+		case check.Actual == expected:
+			check.Status = StatusOK
+		case check.Actual < expected:
+			check.Status = StatusMismatch
+			check.Details = "nameserver has not picked up the latest changes yet"
+		default:
+			check.Status = StatusMismatch
+			check.Details = "nameserver serves a newer serial than the API"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// CheckRecordSets compares the record sets stored in the API with the answers of the nameservers.
+// SOA record sets are skipped, as they are compared by CheckSerials.
+func CheckRecordSets(ctx context.Context, nameservers []string, zoneDnsName string, recordSets []dns.RecordSet) []RecordCheck {
+	origin := zonefile.Qualify(zoneDnsName, ".")
+	checks := []RecordCheck{}
+	for _, ns := range nameservers {
+		for i := range recordSets {
+			rs := recordSets[i]
+			recordType := utils.PtrString(rs.Type)
+			if recordType == "SOA" {
+				continue
+			}
+			name := zonefile.Qualify(utils.PtrString(rs.Name), origin)
+			check := RecordCheck{Nameserver: ns, Name: name, Type: recordType}
+			for _, record := range utils.PtrValue(rs.Records) {
+				check.Expected = append(check.Expected, normalizeContent(recordType, utils.PtrString(record.Content), origin))
+			}
+			slices.Sort(check.Expected)
+
+			qtype, ok := recordTypes[recordType]
+			if !ok {
+				check.Status = StatusSkipped
+				check.Details = fmt.Sprintf("record type %s can't be queried", recordType)
+				checks = append(checks, check)
+				continue
+			}
+			resp, err := Query(ctx, ns, name, qtype)
+			if err != nil {
+				check.Status = StatusError
+				check.Details = err.Error()
+				checks = append(checks, check)
+				continue
+			}
+
+			resources := resp.Answers
+			// NS record sets below the apex are delegations, which are answered as referral
+			if qtype == dnsmessage.TypeNS && name != origin {
+				resources = append(resources, resp.Authorities...)
+			}
+			check.Actual = formatResources(resources, name, qtype)
+			slices.Sort(check.Actual)
+			check.Actual = slices.Compact(check.Actual)
+
+			switch {
+			case len(check.Actual) == 0:
+				check.Status = StatusMissing
+				check.Details = fmt.Sprintf("no records found, response code %s", resp.RCode)
+			case slices.Equal(check.Expected, check.Actual):
+				check.Status = StatusOK
+			default:
+				check.Status = StatusMismatch
+				check.Details = fmt.Sprintf("expected %q, got %q", check.Expected, check.Actual)
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// CheckDelegation compares the expected nameservers of the zone with the NS records served by the parent zone nameservers
+func CheckDelegation(ctx context.Context, parentNameservers []string, zoneDnsName string, expected []string) []DelegationCheck {
+	name := zonefile.Qualify(zoneDnsName, ".")
+	expectedNameservers := []string{}
+	for _, ns := range expected {
+		expectedNameservers = append(expectedNameservers, zonefile.Qualify(ns, "."))
+	}
+	slices.Sort(expectedNameservers)
+
+	checks := []DelegationCheck{}
+	for _, parent := range parentNameservers {
+		check := DelegationCheck{ParentNameserver: parent, Expected: expectedNameservers}
+		resp, err := Query(ctx, parent, name, dnsmessage.TypeNS)
+		if err != nil {
+			check.Status = StatusError
+			check.Details = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+
+		// Parent zone nameservers answer with a referral, which has the NS records in the authority section
+		check.Actual = formatResources(append(resp.Answers, resp.Authorities...), name, dnsmessage.TypeNS)
+		slices.Sort(check.Actual)
+		check.Actual = slices.Compact(check.Actual)
+		switch {
+		case len(check.Actual) == 0:
+			check.Status = StatusMissing
+			check.Details = fmt.Sprintf("zone is not delegated, response code %s", resp.RCode)
+		case slices.Equal(check.Expected, check.Actual):
+			check.Status = StatusOK
+		default:
+			check.Status = StatusMismatch
+			check.Details = fmt.Sprintf("expected %q, got %q", check.Expected, check.Actual)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// formatResources returns the records with the given name and type in the form of normalizeContent
+func formatResources(resources []dnsmessage.Resource, name string, qtype dnsmessage.Type) []string {
+	records := []string{}
+	for _, rr := range resources {
+		if rr.Header.Type != qtype || !strings.EqualFold(rr.Header.Name.String(), name) {
+			continue
+		}
+		switch body := rr.Body.(type) {
+		case *dnsmessage.AResource:
+			records = append(records, net.IP(body.A[:]).String())
+		case *dnsmessage.AAAAResource:
+			records = append(records, net.IP(body.AAAA[:]).String())
+		case *dnsmessage.CNAMEResource:
+			records = append(records, strings.ToLower(body.CNAME.String()))
+		case *dnsmessage.NSResource:
+			records = append(records, strings.ToLower(body.NS.String()))
+		case *dnsmessage.PTRResource:
+			records = append(records, strings.ToLower(body.PTR.String()))
+		case *dnsmessage.MXResource:
+			records = append(records, fmt.Sprintf("%d %s", body.Pref, strings.ToLower(body.MX.String())))
+		case *dnsmessage.SRVResource:
+			records = append(records, fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, strings.ToLower(body.Target.String())))
+		case *dnsmessage.TXTResource:
+			records = append(records, strings.Join(body.TXT, ""))
+		case *dnsmessage.UnknownResource:
+			record, ok := formatUnknownResource(body)
+			if ok {
+				records = append(records, record)
+			}
+		}
+	}
+	return records
+}
+
+func formatUnknownResource(body *dnsmessage.UnknownResource) (string, bool) {
+	data := body.Data
+	switch body.Type {
+	case typeCAA:
+		// Flags, tag length, tag and value, see RFC 8659 section 4.1
+		if len(data) < 2 || len(data) < 2+int(data[1]) {
+			return "", false
+		}
+		tag := strings.ToLower(string(data[2 : 2+data[1]]))
+		return fmt.Sprintf("%d %s %q", data[0], tag, string(data[2+data[1]:])), true
+	case typeDNAME:
+		// The target of a DNAME record is never compressed, see RFC 6672 section 2.5
+		labels := []string{}
+		for i := 0; i < len(data); {
+			length := int(data[i])
+			if length == 0 {
+				return strings.ToLower(strings.Join(labels, ".")) + ".", true
+			}
+			if i+1+length > len(data) {
+				return "", false
+			}
+			labels = append(labels, string(data[i+1:i+1+length]))
+			i += 1 + length
+		}
+	}
+	return "", false
+}
+
+// normalizeContent returns the content of a record stored in the API in the form of the records served by nameservers
+func normalizeContent(recordType, content, origin string) string {
+	fields := strings.Fields(content)
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(content); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "PTR", "DNAME":
+		return zonefile.Qualify(content, origin)
+	case "MX":
+		if len(fields) == 2 {
+			return fmt.Sprintf("%s %s", fields[0], zonefile.Qualify(fields[1], origin))
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			return fmt.Sprintf("%s %s %s %s", fields[0], fields[1], fields[2], zonefile.Qualify(fields[3], origin))
+		}
+	case "TXT":
+		return joinTxtStrings(content)
+	case "CAA":
+		if len(fields) < 3 {
+			break
+		}
+		flags, err := strconv.Atoi(fields[0])
+		if err != nil {
+			break
+		}
+		value := strings.Join(fields[2:], " ")
+		return fmt.Sprintf("%d %s %q", flags, strings.ToLower(fields[1]), strings.Trim(value, `"`))
+	}
+	return content
+}
+
+// joinTxtStrings returns the concatenated character strings of a TXT record, which can be made of several quoted strings
+func joinTxtStrings(content string) string {
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && inString && i+1 < len(content):
+			i++
+			sb.WriteByte(content[i])
+		case c == '"':
+			inString = !inString
+		case inString:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,288 @@
+package check
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stackitcloud/stackit-sdk-go/core/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type testZone struct {
+	authoritative bool
+	answers       []dnsmessage.Resource
+	authorities   []dnsmessage.Resource
+}
+
+// startTestServer starts a DNS server on a local UDP port, which answers with the resources matching the question
+func startTestServer(t *testing.T, zone testZone) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+			q := query.Questions[0]
+			resp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: zone.authoritative},
+				Questions: query.Questions,
+			}
+			for _, rr := range zone.answers {
+				if rr.Header.Type == q.Type && strings.EqualFold(rr.Header.Name.String(), q.Name.String()) {
+					resp.Answers = append(resp.Answers, rr)
+				}
+			}
+			for _, rr := range zone.authorities {
+				if strings.EqualFold(rr.Header.Name.String(), q.Name.String()) {
+					resp.Authorities = append(resp.Authorities, rr)
+				}
+			}
+			if len(resp.Answers) == 0 && len(resp.Authorities) == 0 {
+				resp.RCode = dnsmessage.RCodeNameError
+			}
+			packed, err := resp.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(packed, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func resource(name string, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 60},
+		Body:   body,
+	}
+}
+
+func withType(rr dnsmessage.Resource, t dnsmessage.Type) dnsmessage.Resource {
+	rr.Header.Type = t
+	return rr
+}
+
+func testZoneResources() testZone {
+	caa := []byte{0, 5}
+	caa = append(caa, []byte("issueletsencrypt.org")...)
+	return testZone{
+		authoritative: true,
+		answers: []dnsmessage.Resource{
+			withType(resource("example.com.", &dnsmessage.SOAResource{
+				NS: dnsmessage.MustNewName("ns1.example.net."), MBox: dnsmessage.MustNewName("hostmaster.example.net."), Serial: 2024010102,
+			}), dnsmessage.TypeSOA),
+			withType(resource("example.com.", &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.net.")}), dnsmessage.TypeNS),
+			withType(resource("www.example.com.", &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}}), dnsmessage.TypeA),
+			withType(resource("www.example.com.", &dnsmessage.AResource{A: [4]byte{5, 6, 7, 8}}), dnsmessage.TypeA),
+			withType(resource("mail.example.com.", &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("MX.example.com.")}), dnsmessage.TypeMX),
+			withType(resource("example.com.", &dnsmessage.TXTResource{TXT: []string{"part one", "part two"}}), dnsmessage.TypeTXT),
+			withType(resource("example.com.", &dnsmessage.UnknownResource{Type: typeCAA, Data: caa}), typeCAA),
+			withType(resource("api.example.com.", &dnsmessage.AAAAResource{AAAA: [16]byte{15: 1}}), dnsmessage.TypeAAAA),
+		},
+		authorities: []dnsmessage.Resource{
+			withType(resource("sub.example.com.", &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns.sub.example.net.")}), dnsmessage.TypeNS),
+		},
+	}
+}
+
+func fixtureRecordSet(name, recordType string, records ...string) dns.RecordSet {
+	rs := dns.RecordSet{
+		Name:    utils.Ptr(name),
+		Type:    dns.RecordSetGetTypeAttributeType(utils.Ptr(recordType)),
+		Records: &[]dns.Record{},
+	}
+	for _, record := range records {
+		*rs.Records = append(*rs.Records, dns.Record{Content: utils.Ptr(record)})
+	}
+	return rs
+}
+
+func TestQuery(t *testing.T) {
+	server := startTestServer(t, testZoneResources())
+
+	resp, err := Query(context.Background(), server, "www.example.com", dnsmessage.TypeA)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if !resp.Authoritative {
+		t.Fatalf("expected authoritative answer")
+	}
+	records := formatResources(resp.Answers, "www.example.com.", dnsmessage.TypeA)
+	diff := cmp.Diff(records, []string{"1.2.3.4", "5.6.7.8"})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestCheckRecordSets(t *testing.T) {
+	server := startTestServer(t, testZoneResources())
+	recordSets := []dns.RecordSet{
+		fixtureRecordSet("example.com.", "SOA", "ns1.example.net. hostmaster.example.net. 2024010102 3600 600 1209600 60"),
+		fixtureRecordSet("www.example.com.", "A", "5.6.7.8", "1.2.3.4"),
+		fixtureRecordSet("mail.example.com.", "MX", "10 mx"),
+		fixtureRecordSet("example.com.", "TXT", `"part one" "part two"`),
+		fixtureRecordSet("example.com.", "CAA", `0 issue "letsencrypt.org"`),
+		fixtureRecordSet("api.example.com.", "AAAA", "0:0::1", "::2"),
+		fixtureRecordSet("sub.example.com.", "NS", "ns.sub.example.net."),
+		fixtureRecordSet("new.example.com.", "A", "9.9.9.9"),
+		fixtureRecordSet("alias.example.com.", "ALIAS", "www.example.com."),
+	}
+
+	checks := CheckRecordSets(context.Background(), []string{server}, "example.com", recordSets)
+
+	expected := []RecordCheck{
+		{Nameserver: server, Name: "www.example.com.", Type: "A", Status: StatusOK, Expected: []string{"1.2.3.4", "5.6.7.8"}, Actual: []string{"1.2.3.4", "5.6.7.8"}},
+		{Nameserver: server, Name: "mail.example.com.", Type: "MX", Status: StatusOK, Expected: []string{"10 mx.example.com."}, Actual: []string{"10 mx.example.com."}},
+		{Nameserver: server, Name: "example.com.", Type: "TXT", Status: StatusOK, Expected: []string{"part onepart two"}, Actual: []string{"part onepart two"}},
+		{Nameserver: server, Name: "example.com.", Type: "CAA", Status: StatusOK, Expected: []string{`0 issue "letsencrypt.org"`}, Actual: []string{`0 issue "letsencrypt.org"`}},
+		{
+			Nameserver: server, Name: "api.example.com.", Type: "AAAA", Status: StatusMismatch,
+			Expected: []string{"::1", "::2"}, Actual: []string{"::1"}, Details: `expected ["::1" "::2"], got ["::1"]`,
+		},
+		{Nameserver: server, Name: "sub.example.com.", Type: "NS", Status: StatusOK, Expected: []string{"ns.sub.example.net."}, Actual: []string{"ns.sub.example.net."}},
+		{
+			Nameserver: server, Name: "new.example.com.", Type: "A", Status: StatusMissing,
+			Expected: []string{"9.9.9.9"}, Actual: []string{}, Details: "no records found, response code RCodeNameError",
+		},
+		{
+			Nameserver: server, Name: "alias.example.com.", Type: "ALIAS", Status: StatusSkipped,
+			Expected: []string{"www.example.com."}, Details: "record type ALIAS can't be queried",
+		},
+	}
+	diff := cmp.Diff(checks, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestCheckSerials(t *testing.T) {
+	server := startTestServer(t, testZoneResources())
+	notAuthoritative := startTestServer(t, testZone{})
+
+	tests := []struct {
+		description    string
+		nameserver     string
+		expectedSerial int64
+		expectedStatus string
+	}{
+		{
+			description:    "up to date",
+			nameserver:     server,
+			expectedSerial: 2024010102,
+			expectedStatus: StatusOK,
+		},
+		{
+			description:    "outdated",
+			nameserver:     server,
+			expectedSerial: 2024010103,
+			expectedStatus: StatusMismatch,
+		},
+		{
+			description:    "not authoritative",
+			nameserver:     notAuthoritative,
+			expectedSerial: 2024010102,
+			expectedStatus: StatusError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			checks := CheckSerials(context.Background(), []string{tt.nameserver}, "example.com", tt.expectedSerial)
+			if len(checks) != 1 {
+				t.Fatalf("expected 1 check, got %d", len(checks))
+			}
+			if checks[0].Status != tt.expectedStatus {
+				t.Fatalf("expected status %q, got %q (%s)", tt.expectedStatus, checks[0].Status, checks[0].Details)
+			}
+		})
+	}
+}
+
+func TestCheckDelegation(t *testing.T) {
+	parent := startTestServer(t, testZone{
+		authorities: []dnsmessage.Resource{
+			withType(resource("example.com.", &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.net.")}), dnsmessage.TypeNS),
+			withType(resource("example.com.", &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns2.example.net.")}), dnsmessage.TypeNS),
+		},
+	})
+
+	tests := []struct {
+		description    string
+		zone           string
+		expected       []string
+		expectedStatus string
+	}{
+		{
+			description:    "delegated",
+			zone:           "example.com",
+			expected:       []string{"ns2.example.net", "ns1.example.net."},
+			expectedStatus: StatusOK,
+		},
+		{
+			description:    "wrong nameservers",
+			zone:           "example.com",
+			expected:       []string{"ns1.example.net."},
+			expectedStatus: StatusMismatch,
+		},
+		{
+			description:    "not delegated",
+			zone:           "other.com",
+			expected:       []string{"ns1.example.net."},
+			expectedStatus: StatusMissing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			checks := CheckDelegation(context.Background(), []string{parent}, tt.zone, tt.expected)
+			if len(checks) != 1 {
+				t.Fatalf("expected 1 check, got %d", len(checks))
+			}
+			if checks[0].Status != tt.expectedStatus {
+				t.Fatalf("expected status %q, got %q (%s)", tt.expectedStatus, checks[0].Status, checks[0].Details)
+			}
+		})
+	}
+}
+
+func TestNormalizeContent(t *testing.T) {
+	tests := []struct {
+		recordType string
+		content    string
+		expected   string
+	}{
+		{"A", "1.2.3.4", "1.2.3.4"},
+		{"AAAA", "2001:DB8:0::1", "2001:db8::1"},
+		{"CNAME", "www", "www.example.com."},
+		{"MX", "10 mail.example.org.", "10 mail.example.org."},
+		{"SRV", "10 5 443 target", "10 5 443 target.example.com."},
+		{"TXT", "unquoted text", "unquoted text"},
+		{"TXT", `"say \"hello\"" "again"`, `say "hello"again`},
+		{"CAA", `0 ISSUE "ca.example.net"`, `0 issue "ca.example.net"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recordType+" "+tt.content, func(t *testing.T) {
+			result := normalizeContent(tt.recordType, tt.content, "example.com.")
+			if result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
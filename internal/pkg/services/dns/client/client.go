@@ -4,6 +4,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/cassette"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
@@ -30,6 +31,12 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*dns.APIClient, error
 		cfgOptions = append(cfgOptions, sdkConfig.WithEndpoint(customEndpoint))
 	}
 
+	cassetteCfgOption, err := cassette.ConfigOption(p)
+	if err != nil {
+		return nil, err
+	}
+	cfgOptions = append(cfgOptions, cassetteCfgOption)
+
 	if p.IsVerbosityDebug() {
 		cfgOptions = append(cfgOptions,
 			sdkConfig.WithMiddleware(print.RequestResponseCapturer(p, nil)),
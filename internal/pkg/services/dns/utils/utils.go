@@ -2,13 +2,33 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns/wait"
 )
 
+const (
+	RecordSetActionCreate = "create"
+	RecordSetActionUpdate = "update"
+	RecordSetActionDelete = "delete"
+
+	recordSetsPageSize   = 100
+	deleteSucceededState = "DELETE_SUCCEEDED"
+	ownerTagPrefix       = "managed-by="
+)
+
+// recordSetActionOrder is the order in which the record set changes are applied
+var recordSetActionOrder = []string{RecordSetActionDelete, RecordSetActionUpdate, RecordSetActionCreate}
+
 type DNSClient interface {
 	GetZoneExecute(ctx context.Context, projectId, zoneId string) (*dns.ZoneResponse, error)
 	GetRecordSetExecute(ctx context.Context, projectId, zoneId, recordSetId string) (*dns.RecordSetResponse, error)
@@ -38,6 +58,294 @@ func GetRecordSetType(ctx context.Context, apiClient DNSClient, projectId, zoneI
 	return resp.Rrset.Type, nil
 }
 
+type DNSRecordSetsClient interface {
+	ListRecordSets(ctx context.Context, projectId, zoneId string) dns.ApiListRecordSetsRequest
+}
+
+// RecordSetChange is a change needed to bring the record sets of a zone to the desired state
+type RecordSetChange struct {
+	Action      string   `json:"action"`
+	RecordSetId string   `json:"recordSetId,omitempty"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	TTL         int64    `json:"ttl"`
+	Records     []string `json:"records"`
+	Comment     *string  `json:"comment,omitempty"`
+}
+
+// RecordSetPlanOptions control which changes PlanRecordSetChanges returns
+type RecordSetPlanOptions struct {
+	// Delete the existing record sets which are not desired
+	Prune bool
+	// If set, only record sets whose comment carries the owner tag are updated or deleted, and created record sets get it
+	OwnerTag string
+}
+
+// ListAllRecordSets returns all record sets of a zone which are not deleted, going through all pages
+func ListAllRecordSets(ctx context.Context, apiClient DNSRecordSetsClient, projectId, zoneId string) ([]dns.RecordSet, error) {
+	page := 1
+	recordSets := []dns.RecordSet{}
+	for {
+		resp, err := apiClient.ListRecordSets(ctx, projectId, zoneId).
+			StateNeq(deleteSucceededState).
+			PageSize(recordSetsPageSize).
+			Page(int32(page)).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("get DNS record sets: %w", err)
+		}
+		respRecordSets := utils.PtrValue(resp.RrSets)
+		recordSets = append(recordSets, respRecordSets...)
+		// Stop if no more pages
+		if len(respRecordSets) < recordSetsPageSize {
+			break
+		}
+		page++
+	}
+	return recordSets, nil
+}
+
+// IsManagedRecordSet returns whether the record set is managed by STACKIT DNS, which is the case for the SOA and NS record sets at the zone apex
+func IsManagedRecordSet(zoneDnsName, name, recordType string) bool {
+	if recordType == "SOA" {
+		return true
+	}
+	return recordType == "NS" && zonefile.Qualify(name, ".") == zonefile.Qualify(zoneDnsName, ".")
+}
+
+// ToZoneFileRecordSets converts record sets returned by the API, using fully qualified names
+func ToZoneFileRecordSets(zoneDnsName string, recordSets []dns.RecordSet) []zonefile.RecordSet {
+	origin := zonefile.Qualify(zoneDnsName, ".")
+	result := []zonefile.RecordSet{}
+	for i := range recordSets {
+		rs := recordSets[i]
+		recordType := utils.PtrString(rs.Type)
+		records := []string{}
+		for _, record := range utils.PtrValue(rs.Records) {
+			records = append(records, zonefile.NormalizeRecord(recordType, utils.PtrString(record.Content)))
+		}
+		result = append(result, zonefile.RecordSet{
+			Name:    zonefile.Qualify(utils.PtrString(rs.Name), origin),
+			Type:    recordType,
+			TTL:     utils.PtrValue(rs.Ttl),
+			Records: records,
+		})
+	}
+	return result
+}
+
+// OwnerTagComment returns the record set comment which marks a record set as managed by the owner tag
+func OwnerTagComment(ownerTag string) string {
+	return ownerTagPrefix + ownerTag
+}
+
+// IsOwnedBy returns whether the record set comment carries the owner tag
+func IsOwnedBy(comment *string, ownerTag string) bool {
+	if comment == nil {
+		return false
+	}
+	return slices.Contains(strings.Fields(*comment), OwnerTagComment(ownerTag))
+}
+
+// PlanRecordSetChanges returns the changes needed to bring the existing record sets of a zone to the desired ones.
+// Record sets managed by STACKIT DNS are never changed. Existing record sets which are not desired are only deleted if prune is set.
+// If an owner tag is set, desired record sets which exist but are not owned by it are a conflict and existing record sets which
+// are not owned by it are never deleted.
+func PlanRecordSetChanges(zoneDnsName string, desired []zonefile.RecordSet, existing []dns.RecordSet, opts RecordSetPlanOptions) ([]RecordSetChange, error) {
+	origin := zonefile.Qualify(zoneDnsName, ".")
+	existingRecordSets := ToZoneFileRecordSets(zoneDnsName, existing)
+	existingIndex := map[string]int{}
+	for i, rs := range existingRecordSets {
+		existingIndex[rs.Name+" "+rs.Type] = i
+	}
+	var comment *string
+	if opts.OwnerTag != "" {
+		comment = utils.Ptr(OwnerTagComment(opts.OwnerTag))
+	}
+
+	changes := []RecordSetChange{}
+	desiredKeys := map[string]bool{}
+	for _, rs := range desired {
+		name := zonefile.Qualify(rs.Name, origin)
+		if IsManagedRecordSet(zoneDnsName, name, rs.Type) {
+			continue
+		}
+		key := name + " " + rs.Type
+		desiredKeys[key] = true
+
+		idx, ok := existingIndex[key]
+		if !ok {
+			changes = append(changes, RecordSetChange{
+				Action:  RecordSetActionCreate,
+				Name:    name,
+				Type:    rs.Type,
+				TTL:     rs.TTL,
+				Records: rs.Records,
+				Comment: comment,
+			})
+			continue
+		}
+		if opts.OwnerTag != "" && !IsOwnedBy(existing[idx].Comment, opts.OwnerTag) {
+			return nil, fmt.Errorf("record set %s %s already exists and is not managed by owner tag %q", name, rs.Type, opts.OwnerTag)
+		}
+		current := existingRecordSets[idx]
+		if current.TTL == rs.TTL && sameRecords(current.Records, rs.Records) {
+			continue
+		}
+		changes = append(changes, RecordSetChange{
+			Action:      RecordSetActionUpdate,
+			RecordSetId: utils.PtrString(existing[idx].Id),
+			Name:        name,
+			Type:        rs.Type,
+			TTL:         rs.TTL,
+			Records:     rs.Records,
+		})
+	}
+
+	if !opts.Prune {
+		return changes, nil
+	}
+	for i, rs := range existingRecordSets {
+		if desiredKeys[rs.Name+" "+rs.Type] || IsManagedRecordSet(zoneDnsName, rs.Name, rs.Type) {
+			continue
+		}
+		if opts.OwnerTag != "" && !IsOwnedBy(existing[i].Comment, opts.OwnerTag) {
+			continue
+		}
+		changes = append(changes, RecordSetChange{
+			Action:      RecordSetActionDelete,
+			RecordSetId: utils.PtrString(existing[i].Id),
+			Name:        rs.Name,
+			Type:        rs.Type,
+			TTL:         rs.TTL,
+			Records:     rs.Records,
+		})
+	}
+	return changes, nil
+}
+
+// ApplyRecordSetChange triggers the change and, if waitForChange is set, waits for it to be finished
+func ApplyRecordSetChange(ctx context.Context, apiClient *dns.APIClient, projectId, zoneId string, change *RecordSetChange, waitForChange bool) error {
+	records := make([]dns.RecordPayload, 0, len(change.Records))
+	for _, r := range change.Records {
+		records = append(records, dns.RecordPayload{Content: utils.Ptr(r)})
+	}
+
+	switch change.Action {
+	case RecordSetActionCreate:
+		resp, err := apiClient.CreateRecordSet(ctx, projectId, zoneId).CreateRecordSetPayload(dns.CreateRecordSetPayload{
+			Comment: change.Comment,
+			Name:    utils.Ptr(change.Name),
+			Type:    utils.Ptr(change.Type),
+			Ttl:     utils.Ptr(change.TTL),
+			Records: &records,
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("create DNS record set %s %s: %w", change.Name, change.Type, err)
+		}
+		change.RecordSetId = utils.PtrString(resp.Rrset.Id)
+		if waitForChange {
+			_, err = wait.CreateRecordSetWaitHandler(ctx, apiClient, projectId, zoneId, change.RecordSetId).WaitWithContext(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for DNS record set %s %s creation: %w", change.Name, change.Type, err)
+			}
+		}
+	case RecordSetActionUpdate:
+		_, err := apiClient.PartialUpdateRecordSet(ctx, projectId, zoneId, change.RecordSetId).PartialUpdateRecordSetPayload(dns.PartialUpdateRecordSetPayload{
+			Comment: change.Comment,
+			Ttl:     utils.Ptr(change.TTL),
+			Records: &records,
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("update DNS record set %s %s: %w", change.Name, change.Type, err)
+		}
+		if waitForChange {
+			_, err = wait.PartialUpdateRecordSetWaitHandler(ctx, apiClient, projectId, zoneId, change.RecordSetId).WaitWithContext(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for DNS record set %s %s update: %w", change.Name, change.Type, err)
+			}
+		}
+	case RecordSetActionDelete:
+		_, err := apiClient.DeleteRecordSet(ctx, projectId, zoneId, change.RecordSetId).Execute()
+		if err != nil {
+			return fmt.Errorf("delete DNS record set %s %s: %w", change.Name, change.Type, err)
+		}
+		if waitForChange {
+			_, err = wait.DeleteRecordSetWaitHandler(ctx, apiClient, projectId, zoneId, change.RecordSetId).WaitWithContext(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for DNS record set %s %s deletion: %w", change.Name, change.Type, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown record set action %q", change.Action)
+	}
+	return nil
+}
+
+// ApplyRecordSetChanges applies the changes with at most parallelism changes in progress at the same time.
+// The deletions are applied first, then the updates and then the creations, so that a record set can be
+// replaced by one with another type, e.g. an A record set by a CNAME record set with the same name.
+// All changes are tried, the errors of the failed ones are returned together.
+func ApplyRecordSetChanges(ctx context.Context, apiClient *dns.APIClient, projectId, zoneId string, changes []RecordSetChange, parallelism int, waitForChanges bool) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	errs := make([]error, len(changes))
+	for _, action := range recordSetActionOrder {
+		semaphore := make(chan struct{}, parallelism)
+		var wg sync.WaitGroup
+		for i := range changes {
+			if changes[i].Action != action {
+				continue
+			}
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				errs[i] = ApplyRecordSetChange(ctx, apiClient, projectId, zoneId, &changes[i], waitForChanges)
+			}(i)
+		}
+		wg.Wait()
+	}
+	for i := range changes {
+		if !slices.Contains(recordSetActionOrder, changes[i].Action) {
+			errs[i] = fmt.Errorf("unknown record set action %q", changes[i].Action)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DescribeRecordSetChanges returns a summary of the changes, e.g. "create 2 and delete 1 record sets"
+func DescribeRecordSetChanges(changes []RecordSetChange) string {
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	parts := []string{}
+	for _, action := range []string{RecordSetActionCreate, RecordSetActionUpdate, RecordSetActionDelete} {
+		if counts[action] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", action, counts[action]))
+		}
+	}
+	summary := parts[len(parts)-1]
+	if len(parts) > 1 {
+		summary = strings.Join(parts[:len(parts)-1], ", ") + " and " + summary
+	}
+	return summary + " record sets"
+}
+
+func sameRecords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Clone(a)
+	sortedB := slices.Clone(b)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
+
 func FormatTxtRecord(input string) (string, error) {
 	length := float64(len(input))
 	if length <= 255 {
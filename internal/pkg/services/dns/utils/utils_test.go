@@ -2,11 +2,20 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/zonefile"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
 	"github.com/stackitcloud/stackit-sdk-go/services/dns"
 )
 
@@ -262,3 +271,570 @@ func TestFormatTxtRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestListAllRecordSets(t *testing.T) {
+	tests := []struct {
+		description         string
+		totalItems          int
+		apiCallFails        bool
+		expectedNumAPICalls int
+	}{
+		{
+			description:         "single page",
+			totalItems:          10,
+			expectedNumAPICalls: 1,
+		},
+		{
+			description:         "several pages",
+			totalItems:          250,
+			expectedNumAPICalls: 3,
+		},
+		{
+			description:         "full last page",
+			totalItems:          200,
+			expectedNumAPICalls: 3,
+		},
+		{
+			description:  "request fails",
+			apiCallFails: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			numAPICalls := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				numAPICalls++
+
+				w.Header().Set("Content-Type", "application/json")
+				if tt.apiCallFails {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				query := r.URL.Query()
+				if query.Get("state[neq]") != deleteSucceededState {
+					t.Errorf("expected deleted record sets to be filtered, got query %q", r.URL.RawQuery)
+				}
+				page, err := strconv.Atoi(query.Get("page"))
+				if err != nil {
+					t.Errorf("Failed to parse query param page: %v", err)
+				}
+				offset := (page - 1) * recordSetsPageSize
+				numItemsToReturn := min(max(tt.totalItems-offset, 0), recordSetsPageSize)
+
+				recordSets := make([]dns.RecordSet, numItemsToReturn)
+				mockedRespBytes, err := json.Marshal(dns.ListRecordSetsResponse{RrSets: &recordSets})
+				if err != nil {
+					t.Fatalf("Failed to marshal mocked response: %v", err)
+				}
+				_, err = w.Write(mockedRespBytes)
+				if err != nil {
+					t.Errorf("Failed to write response: %v", err)
+				}
+			})
+			mockedServer := httptest.NewServer(handler)
+			defer mockedServer.Close()
+			client, err := dns.NewAPIClient(
+				sdkConfig.WithEndpoint(mockedServer.URL),
+				sdkConfig.WithoutAuthentication(),
+			)
+			if err != nil {
+				t.Fatalf("Failed to initialize client: %v", err)
+			}
+
+			recordSets, err := ListAllRecordSets(context.Background(), client, testProjectId, testZoneId)
+			if err != nil {
+				if !tt.apiCallFails {
+					t.Fatalf("failed on valid input: %v", err)
+				}
+				return
+			}
+			if tt.apiCallFails {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if numAPICalls != tt.expectedNumAPICalls {
+				t.Fatalf("Expected %d API calls, got %d", tt.expectedNumAPICalls, numAPICalls)
+			}
+			if len(recordSets) != tt.totalItems {
+				t.Fatalf("Expected %d record sets, got %d", tt.totalItems, len(recordSets))
+			}
+		})
+	}
+}
+
+func TestIsManagedRecordSet(t *testing.T) {
+	tests := []struct {
+		description string
+		name        string
+		recordType  string
+		expected    bool
+	}{
+		{"soa", "example.com.", "SOA", true},
+		{"ns at apex", "example.com.", "NS", true},
+		{"ns at apex without dot", "example.com", "NS", true},
+		{"delegation", "sub.example.com.", "NS", false},
+		{"a at apex", "example.com.", "A", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := IsManagedRecordSet("example.com", tt.name, tt.recordType)
+			if result != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
+
+func fixtureRecordSet(id, name, recordType string, ttl int64, records ...string) dns.RecordSet {
+	recordSetRecords := []dns.Record{}
+	for _, r := range records {
+		recordSetRecords = append(recordSetRecords, dns.Record{Content: utils.Ptr(r)})
+	}
+	return dns.RecordSet{
+		Id:      utils.Ptr(id),
+		Name:    utils.Ptr(name),
+		Type:    utils.Ptr(recordType),
+		Ttl:     utils.Ptr(ttl),
+		Records: &recordSetRecords,
+	}
+}
+
+func TestPlanRecordSetChanges(t *testing.T) {
+	existing := []dns.RecordSet{
+		fixtureRecordSet("soa", "example.com.", "SOA", 3600, "ns1.stackit.cloud. hostmaster.stackit.cloud. 1 2 3 4 5"),
+		fixtureRecordSet("ns", "example.com.", "NS", 3600, "ns1.stackit.cloud."),
+		fixtureRecordSet("www", "www.example.com.", "A", 300, "5.6.7.8", "1.2.3.4"),
+		fixtureRecordSet("txt", "example.com.", "TXT", 300, `"v=spf1 -all"`),
+		fixtureRecordSet("mail", "mail.example.com.", "A", 300, "1.1.1.1"),
+		fixtureRecordSet("old", "old.example.com.", "CNAME", 300, "www.example.com."),
+	}
+	desired := []zonefile.RecordSet{
+		{Name: "example.com.", Type: "NS", TTL: 60, Records: []string{"ns.other-provider.net."}},
+		{Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4", "5.6.7.8"}},
+		{Name: "example.com.", Type: "TXT", TTL: 300, Records: []string{"v=spf1 -all"}},
+		{Name: "mail.example.com.", Type: "A", TTL: 600, Records: []string{"1.1.1.1"}},
+		{Name: "new.example.com.", Type: "AAAA", TTL: 300, Records: []string{"::1"}},
+	}
+
+	tests := []struct {
+		description string
+		prune       bool
+		expected    []RecordSetChange
+	}{
+		{
+			description: "without prune",
+			expected: []RecordSetChange{
+				{Action: RecordSetActionUpdate, RecordSetId: "mail", Name: "mail.example.com.", Type: "A", TTL: 600, Records: []string{"1.1.1.1"}},
+				{Action: RecordSetActionCreate, Name: "new.example.com.", Type: "AAAA", TTL: 300, Records: []string{"::1"}},
+			},
+		},
+		{
+			description: "with prune",
+			prune:       true,
+			expected: []RecordSetChange{
+				{Action: RecordSetActionUpdate, RecordSetId: "mail", Name: "mail.example.com.", Type: "A", TTL: 600, Records: []string{"1.1.1.1"}},
+				{Action: RecordSetActionCreate, Name: "new.example.com.", Type: "AAAA", TTL: 300, Records: []string{"::1"}},
+				{Action: RecordSetActionDelete, RecordSetId: "old", Name: "old.example.com.", Type: "CNAME", TTL: 300, Records: []string{"www.example.com."}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			changes, err := PlanRecordSetChanges("example.com", desired, existing, RecordSetPlanOptions{Prune: tt.prune})
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(changes, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyRecordSetChange(t *testing.T) {
+	tests := []struct {
+		description    string
+		change         *RecordSetChange
+		apiCallFails   bool
+		isValid        bool
+		expectedMethod string
+		expectedId     string
+	}{
+		{
+			description:    "create",
+			change:         &RecordSetChange{Action: RecordSetActionCreate, Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4"}},
+			isValid:        true,
+			expectedMethod: http.MethodPost,
+			expectedId:     testRecordSetId,
+		},
+		{
+			description:    "update",
+			change:         &RecordSetChange{Action: RecordSetActionUpdate, RecordSetId: testRecordSetId, Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4"}},
+			isValid:        true,
+			expectedMethod: http.MethodPatch,
+			expectedId:     testRecordSetId,
+		},
+		{
+			description:    "delete",
+			change:         &RecordSetChange{Action: RecordSetActionDelete, RecordSetId: testRecordSetId, Name: "www.example.com.", Type: "A"},
+			isValid:        true,
+			expectedMethod: http.MethodDelete,
+			expectedId:     testRecordSetId,
+		},
+		{
+			description:  "api call fails",
+			change:       &RecordSetChange{Action: RecordSetActionCreate, Name: "www.example.com.", Type: "A"},
+			apiCallFails: true,
+			isValid:      false,
+		},
+		{
+			description: "unknown action",
+			change:      &RecordSetChange{Action: "foo"},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			method := ""
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				w.Header().Set("Content-Type", "application/json")
+				if tt.apiCallFails {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				if r.Method == http.MethodPost {
+					w.WriteHeader(http.StatusAccepted)
+				}
+				respBytes, err := json.Marshal(dns.RecordSetResponse{
+					Rrset: &dns.RecordSet{Id: utils.Ptr(testRecordSetId)},
+				})
+				if err != nil {
+					t.Fatalf("Failed to marshal mocked response: %v", err)
+				}
+				_, err = w.Write(respBytes)
+				if err != nil {
+					t.Errorf("Failed to write response: %v", err)
+				}
+			})
+			mockedServer := httptest.NewServer(handler)
+			defer mockedServer.Close()
+			client, err := dns.NewAPIClient(
+				sdkConfig.WithEndpoint(mockedServer.URL),
+				sdkConfig.WithoutAuthentication(),
+			)
+			if err != nil {
+				t.Fatalf("Failed to initialize client: %v", err)
+			}
+
+			err = ApplyRecordSetChange(context.Background(), client, testProjectId, testZoneId, tt.change, false)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if method != tt.expectedMethod {
+				t.Fatalf("expected method %s, got %s", tt.expectedMethod, method)
+			}
+			if tt.change.RecordSetId != tt.expectedId {
+				t.Fatalf("expected record set ID %s, got %s", tt.expectedId, tt.change.RecordSetId)
+			}
+		})
+	}
+}
+
+func TestIsOwnedBy(t *testing.T) {
+	tests := []struct {
+		description string
+		comment     *string
+		expected    bool
+	}{
+		{"nil comment", nil, false},
+		{"owner tag", utils.Ptr("managed-by=infra"), true},
+		{"owner tag with other text", utils.Ptr("web server managed-by=infra"), true},
+		{"other owner tag", utils.Ptr("managed-by=infra-2"), false},
+		{"no owner tag", utils.Ptr("infra"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := IsOwnedBy(tt.comment, "infra")
+			if result != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPlanRecordSetChangesWithOwnerTag(t *testing.T) {
+	owned := fixtureRecordSet("owned", "owned.example.com.", "A", 300, "1.1.1.1")
+	owned.Comment = utils.Ptr(OwnerTagComment("infra"))
+	ownedObsolete := fixtureRecordSet("owned-obsolete", "obsolete.example.com.", "A", 300, "2.2.2.2")
+	ownedObsolete.Comment = utils.Ptr(OwnerTagComment("infra"))
+	notOwned := fixtureRecordSet("not-owned", "manual.example.com.", "A", 300, "3.3.3.3")
+	existing := []dns.RecordSet{owned, ownedObsolete, notOwned}
+
+	tests := []struct {
+		description string
+		desired     []zonefile.RecordSet
+		isValid     bool
+		expected    []RecordSetChange
+	}{
+		{
+			description: "base",
+			desired: []zonefile.RecordSet{
+				{Name: "owned", Type: "A", TTL: 300, Records: []string{"1.1.1.2"}},
+				{Name: "new", Type: "A", TTL: 300, Records: []string{"4.4.4.4"}},
+			},
+			isValid: true,
+			expected: []RecordSetChange{
+				{Action: RecordSetActionUpdate, RecordSetId: "owned", Name: "owned.example.com.", Type: "A", TTL: 300, Records: []string{"1.1.1.2"}},
+				{Action: RecordSetActionCreate, Name: "new.example.com.", Type: "A", TTL: 300, Records: []string{"4.4.4.4"}, Comment: utils.Ptr("managed-by=infra")},
+				{Action: RecordSetActionDelete, RecordSetId: "owned-obsolete", Name: "obsolete.example.com.", Type: "A", TTL: 300, Records: []string{"2.2.2.2"}},
+			},
+		},
+		{
+			description: "record set not owned",
+			desired: []zonefile.RecordSet{
+				{Name: "manual", Type: "A", TTL: 300, Records: []string{"3.3.3.3"}},
+			},
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			changes, err := PlanRecordSetChanges("example.com", tt.desired, existing, RecordSetPlanOptions{Prune: true, OwnerTag: "infra"})
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(changes, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyRecordSetChanges(t *testing.T) {
+	tests := []struct {
+		description  string
+		numChanges   int
+		parallelism  int
+		failingCalls int
+		isValid      bool
+	}{
+		{
+			description: "sequential",
+			numChanges:  5,
+			parallelism: 1,
+			isValid:     true,
+		},
+		{
+			description: "parallel",
+			numChanges:  20,
+			parallelism: 4,
+			isValid:     true,
+		},
+		{
+			description:  "some changes fail",
+			numChanges:   10,
+			parallelism:  3,
+			failingCalls: 2,
+			isValid:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var mu sync.Mutex
+			numAPICalls := 0
+			inProgress := 0
+			maxInProgress := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				mu.Lock()
+				numAPICalls++
+				call := numAPICalls
+				inProgress++
+				maxInProgress = max(maxInProgress, inProgress)
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					inProgress--
+					mu.Unlock()
+				}()
+				time.Sleep(5 * time.Millisecond)
+
+				w.Header().Set("Content-Type", "application/json")
+				if call <= tt.failingCalls {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+				respBytes, err := json.Marshal(dns.RecordSetResponse{
+					Rrset: &dns.RecordSet{Id: utils.Ptr(testRecordSetId)},
+				})
+				if err != nil {
+					t.Errorf("Failed to marshal mocked response: %v", err)
+				}
+				_, err = w.Write(respBytes)
+				if err != nil {
+					t.Errorf("Failed to write response: %v", err)
+				}
+			})
+			mockedServer := httptest.NewServer(handler)
+			defer mockedServer.Close()
+			client, err := dns.NewAPIClient(
+				sdkConfig.WithEndpoint(mockedServer.URL),
+				sdkConfig.WithoutAuthentication(),
+			)
+			if err != nil {
+				t.Fatalf("Failed to initialize client: %v", err)
+			}
+
+			changes := make([]RecordSetChange, tt.numChanges)
+			for i := range changes {
+				changes[i] = RecordSetChange{Action: RecordSetActionCreate, Name: fmt.Sprintf("rs-%d.example.com.", i), Type: "A"}
+			}
+
+			err = ApplyRecordSetChanges(context.Background(), client, testProjectId, testZoneId, changes, tt.parallelism, false)
+			if err != nil {
+				if !tt.isValid {
+					if numAPICalls != tt.numChanges {
+						t.Fatalf("expected all %d changes to be tried, got %d", tt.numChanges, numAPICalls)
+					}
+					return
+				}
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if numAPICalls != tt.numChanges {
+				t.Fatalf("expected %d API calls, got %d", tt.numChanges, numAPICalls)
+			}
+			if maxInProgress > tt.parallelism {
+				t.Fatalf("expected at most %d changes in progress, got %d", tt.parallelism, maxInProgress)
+			}
+			for i := range changes {
+				if changes[i].RecordSetId != testRecordSetId {
+					t.Fatalf("expected record set ID of change %d to be set", i)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRecordSetChangesOrder(t *testing.T) {
+	var mu sync.Mutex
+	deleted := false
+	calls := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method)
+		isDeleted := deleted
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodDelete:
+			// the deletion is slow, so that a creation started at the same time would conflict
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			deleted = true
+			mu.Unlock()
+		case http.MethodPost:
+			if !isDeleted {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+		respBytes, err := json.Marshal(dns.RecordSetResponse{
+			Rrset: &dns.RecordSet{Id: utils.Ptr(testRecordSetId)},
+		})
+		if err != nil {
+			t.Errorf("Failed to marshal mocked response: %v", err)
+		}
+		_, err = w.Write(respBytes)
+		if err != nil {
+			t.Errorf("Failed to write response: %v", err)
+		}
+	})
+	mockedServer := httptest.NewServer(handler)
+	defer mockedServer.Close()
+	client, err := dns.NewAPIClient(
+		sdkConfig.WithEndpoint(mockedServer.URL),
+		sdkConfig.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to initialize client: %v", err)
+	}
+
+	// replace the A record set "www" by a CNAME record set
+	changes := []RecordSetChange{
+		{Action: RecordSetActionCreate, Name: "www.example.com.", Type: "CNAME", Records: []string{"web.example.com."}},
+		{Action: RecordSetActionDelete, Name: "www.example.com.", Type: "A", RecordSetId: "a-record-set-id"},
+	}
+
+	err = ApplyRecordSetChanges(context.Background(), client, testProjectId, testZoneId, changes, 2, false)
+	if err != nil {
+		t.Fatalf("failed on valid input: %v", err)
+	}
+	diff := cmp.Diff(calls, []string{http.MethodDelete, http.MethodPost})
+	if diff != "" {
+		t.Fatalf("API calls do not match: %s", diff)
+	}
+}
+
+func TestDescribeRecordSetChanges(t *testing.T) {
+	tests := []struct {
+		description string
+		actions     []string
+		expected    string
+	}{
+		{
+			description: "single action",
+			actions:     []string{RecordSetActionCreate, RecordSetActionCreate},
+			expected:    "create 2 record sets",
+		},
+		{
+			description: "two actions",
+			actions:     []string{RecordSetActionDelete, RecordSetActionCreate},
+			expected:    "create 1 and delete 1 record sets",
+		},
+		{
+			description: "all actions",
+			actions:     []string{RecordSetActionUpdate, RecordSetActionDelete, RecordSetActionCreate},
+			expected:    "create 1, update 1 and delete 1 record sets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			changes := []RecordSetChange{}
+			for _, action := range tt.actions {
+				changes = append(changes, RecordSetChange{Action: action})
+			}
+			result := DescribeRecordSetChanges(changes)
+			if result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
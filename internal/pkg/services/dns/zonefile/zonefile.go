@@ -0,0 +1,431 @@
+package zonefile
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Based on RFC 1035 section 3.3, a character string is limited to 255 characters
+const maxTxtStringLength = 255
+
+// Record types supported by STACKIT DNS
+var supportedTypes = []string{"A", "AAAA", "SOA", "CNAME", "NS", "MX", "TXT", "SRV", "PTR", "ALIAS", "DNAME", "CAA"}
+
+// Position of the domain name in the record data of types which reference another domain.
+// Relative domain names in these positions are qualified with the current origin.
+var domainNamePositions = map[string]int{
+	"CNAME": 0,
+	"NS":    0,
+	"PTR":   0,
+	"ALIAS": 0,
+	"DNAME": 0,
+	"MX":    1,
+	"SRV":   3,
+}
+
+// RecordSet is a record set read from or written to a zone file.
+// Names are fully qualified and end with a dot.
+type RecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int64    `json:"ttl"`
+	Records []string `json:"records"`
+}
+
+type token struct {
+	value  string
+	quoted bool
+}
+
+type line struct {
+	number int
+	tokens []token
+	// Lines starting with white space have no owner and use the one of the previous record
+	inheritsOwner bool
+}
+
+// Parse parses a zone file in RFC 1035 master file format.
+// Names are relative to origin until an $ORIGIN directive is found, and records without TTL get defaultTTL until a $TTL directive is found.
+// Records with the same name and type are merged into one record set, which gets the lowest TTL of its records.
+func Parse(content, origin string, defaultTTL int64) ([]RecordSet, error) {
+	lines, err := splitLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = Qualify(origin, ".")
+	recordSets := []RecordSet{}
+	index := map[string]int{}
+	owner := ""
+	for _, l := range lines {
+		tokens := l.tokens
+		if !l.inheritsOwner && strings.HasPrefix(tokens[0].value, "$") && !tokens[0].quoted {
+			directive := strings.ToUpper(tokens[0].value)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN expects exactly one domain name", l.number)
+				}
+				origin = Qualify(tokens[1].value, origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL expects exactly one value", l.number)
+				}
+				defaultTTL, err = parseTTL(tokens[1].value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", l.number, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: directive %s is not supported", l.number, tokens[0].value)
+			}
+			continue
+		}
+
+		if !l.inheritsOwner {
+			owner = Qualify(tokens[0].value, origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record has no owner name", l.number)
+		}
+
+		ttl := defaultTTL
+		// TTL and class are optional and can be given in any order
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			value := strings.ToUpper(tokens[0].value)
+			if value == "IN" {
+				tokens = tokens[1:]
+				continue
+			}
+			if value == "CH" || value == "HS" || value == "CS" {
+				return nil, fmt.Errorf("line %d: class %s is not supported, only IN is", l.number, value)
+			}
+			if t, err := parseTTL(tokens[0].value); err == nil {
+				ttl = t
+				tokens = tokens[1:]
+			}
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: record type is missing", l.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0].value)
+		if !IsSupportedType(recordType) {
+			return nil, fmt.Errorf("line %d: record type %q is not supported, supported types are %q", l.number, tokens[0].value, supportedTypes)
+		}
+		if len(tokens) == 1 {
+			return nil, fmt.Errorf("line %d: record data is missing", l.number)
+		}
+		record := buildRecord(recordType, tokens[1:], origin)
+
+		key := owner + " " + recordType
+		idx, ok := index[key]
+		if !ok {
+			index[key] = len(recordSets)
+			recordSets = append(recordSets, RecordSet{
+				Name:    owner,
+				Type:    recordType,
+				TTL:     ttl,
+				Records: []string{record},
+			})
+			continue
+		}
+		rs := &recordSets[idx]
+		if ttl < rs.TTL {
+			rs.TTL = ttl
+		}
+		if !slices.Contains(rs.Records, record) {
+			rs.Records = append(rs.Records, record)
+		}
+	}
+	return recordSets, nil
+}
+
+// Format writes the record sets in RFC 1035 master file format.
+// Names within origin are written relative to it. Record sets are sorted by name, with SOA and NS record sets of a name first.
+func Format(origin string, defaultTTL int64, recordSets []RecordSet) string {
+	origin = Qualify(origin, ".")
+	sorted := make([]RecordSet, len(recordSets))
+	copy(sorted, recordSets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Name != b.Name {
+			if a.Name == origin || b.Name == origin {
+				return a.Name == origin
+			}
+			return a.Name < b.Name
+		}
+		if typeRank(a.Type) != typeRank(b.Type) {
+			return typeRank(a.Type) < typeRank(b.Type)
+		}
+		return a.Type < b.Type
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(&sb, "$TTL %d\n", defaultTTL)
+	for _, rs := range sorted {
+		name := relativeName(rs.Name, origin)
+		for _, record := range rs.Records {
+			if rs.Type == "TXT" {
+				record = quoteTxt(record)
+			}
+			fmt.Fprintf(&sb, "%s\t%d\tIN\t%s\t%s\n", name, rs.TTL, rs.Type, record)
+		}
+	}
+	return sb.String()
+}
+
+// NormalizeRecord returns the record content in the form used by Parse, so records from the API and from zone files can be compared
+func NormalizeRecord(recordType, content string) string {
+	if recordType != "TXT" {
+		return content
+	}
+	if len(content) < 2 || !strings.HasPrefix(content, `"`) || !strings.HasSuffix(content, `"`) {
+		return content
+	}
+	inner := content[1 : len(content)-1]
+	// Content made of several quoted strings is kept as is
+	if strings.Contains(strings.ReplaceAll(inner, `\"`, ""), `"`) {
+		return content
+	}
+	inner = strings.ReplaceAll(inner, `\"`, `"`)
+	inner = strings.ReplaceAll(inner, `\\`, `\`)
+	if len(inner) > maxTxtStringLength {
+		return content
+	}
+	return inner
+}
+
+// QualifyRecord returns the record content with its domain name qualified the way Parse does it,
+// e.g. the target "web" of a CNAME record becomes "web.example.com." for the origin "example.com.".
+// Records of types without a domain name are returned unchanged.
+func QualifyRecord(recordType, content, origin string) string {
+	if _, ok := domainNamePositions[recordType]; !ok {
+		return content
+	}
+	fields := strings.Fields(content)
+	data := make([]token, len(fields))
+	for i := range fields {
+		data[i] = token{value: fields[i]}
+	}
+	return buildRecord(recordType, data, origin)
+}
+
+// Qualify returns the fully qualified, lower case form of name. Relative names are appended to origin, "@" is origin itself.
+func Qualify(name, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return strings.ToLower(origin)
+	case strings.HasSuffix(name, "."):
+		return name
+	case origin == "." || origin == "":
+		return name + "."
+	default:
+		return name + "." + strings.ToLower(origin)
+	}
+}
+
+func relativeName(name, origin string) string {
+	if name == origin {
+		return "@"
+	}
+	if strings.HasSuffix(name, "."+origin) {
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+func buildRecord(recordType string, data []token, origin string) string {
+	if recordType == "TXT" {
+		if len(data) == 1 && len(data[0].value) <= maxTxtStringLength {
+			return data[0].value
+		}
+		// Multiple strings are kept quoted, which is how STACKIT DNS stores TXT records longer than 255 characters
+		parts := []string{}
+		for i := range data {
+			value := data[i].value
+			for len(value) > maxTxtStringLength {
+				parts = append(parts, quoteTxt(value[:maxTxtStringLength]))
+				value = value[maxTxtStringLength:]
+			}
+			parts = append(parts, quoteTxt(value))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	parts := make([]string, len(data))
+	for i := range data {
+		if data[i].quoted {
+			parts[i] = quoteTxt(data[i].value)
+		} else {
+			parts[i] = data[i].value
+		}
+	}
+	if pos, ok := domainNamePositions[recordType]; ok && pos < len(parts) && !data[pos].quoted {
+		parts[pos] = Qualify(parts[pos], origin)
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteTxt quotes a character string, unless it is already made of quoted strings
+func quoteTxt(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// splitLines splits the content into logical lines of tokens, removing comments and joining lines within parentheses
+func splitLines(content string) ([]line, error) {
+	lines := []line{}
+	current := line{number: 1}
+	lineNumber := 1
+	depth := 0
+	atLineStart := true
+
+	var value strings.Builder
+	inToken := false
+	flush := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token{value: value.String()})
+			value.Reset()
+			inToken = false
+		}
+	}
+	endLine := func() {
+		flush()
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = line{number: lineNumber}
+		atLineStart = true
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if atLineStart {
+			atLineStart = false
+			if depth == 0 && (r == ' ' || r == '\t') {
+				current.inheritsOwner = true
+			}
+		}
+		switch {
+		case r == '\n':
+			lineNumber++
+			if depth > 0 {
+				flush()
+				continue
+			}
+			endLine()
+		case r == ';':
+			flush()
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == '(':
+			flush()
+			depth++
+		case r == ')':
+			flush()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+			}
+			depth--
+		case r == '"':
+			flush()
+			var quoted strings.Builder
+			closed := false
+			for i+1 < len(runes) {
+				i++
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					quoted.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					break
+				}
+				if runes[i] == '\n' {
+					lineNumber++
+				}
+				quoted.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+			}
+			current.tokens = append(current.tokens, token{value: quoted.String(), quoted: true})
+		case r == ' ' || r == '\t' || r == '\r':
+			flush()
+		default:
+			value.WriteRune(r)
+			inToken = true
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+	}
+	endLine()
+	return lines, nil
+}
+
+// parseTTL parses a TTL in seconds, optionally using the BIND units s, m, h, d and w (e.g. "1h30m")
+func parseTTL(value string) (int64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("empty TTL")
+	}
+	if ttl, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if ttl < 0 {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		return ttl, nil
+	}
+
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total int64
+	number := ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		unit, ok := units[c|0x20]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		total += n * unit
+		number = ""
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return total, nil
+}
+
+func typeRank(recordType string) int {
+	switch recordType {
+	case "SOA":
+		return 0
+	case "NS":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// IsSupportedType returns whether STACKIT DNS supports the record type
+func IsSupportedType(recordType string) bool {
+	return slices.Contains(supportedTypes, recordType)
+}
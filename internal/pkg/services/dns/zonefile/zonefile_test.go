@@ -0,0 +1,279 @@
+package zonefile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 3600
+; SOA and NS records are managed by STACKIT
+@	IN	SOA	ns1.stackit.cloud. hostmaster.stackit.cloud. (
+		2024010101 ; serial
+		3600       ; refresh
+		600        ; retry
+		1209600    ; expire
+		60 )       ; minimum
+	IN	NS	ns1.stackit.cloud.
+www	300	IN	A	1.2.3.4
+	300	IN	A	5.6.7.8
+WWW	IN	600	A	1.2.3.4
+mail.example.com.	MX	10 mx
+@	TXT	"v=spf1 include:example.net ~all"
+txt	TXT	"part one" "part two"
+quoted	TXT	"say \"hello\""
+alias	1h	CNAME	www
+$ORIGIN sub
+api	AAAA	::1
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		content     string
+		origin      string
+		defaultTTL  int64
+		isValid     bool
+		expected    []RecordSet
+	}{
+		{
+			description: "base",
+			content:     testZoneFile,
+			origin:      "example.com",
+			defaultTTL:  60,
+			isValid:     true,
+			expected: []RecordSet{
+				{
+					Name:    "example.com.",
+					Type:    "SOA",
+					TTL:     3600,
+					Records: []string{"ns1.stackit.cloud. hostmaster.stackit.cloud. 2024010101 3600 600 1209600 60"},
+				},
+				{Name: "example.com.", Type: "NS", TTL: 3600, Records: []string{"ns1.stackit.cloud."}},
+				{Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4", "5.6.7.8"}},
+				{Name: "mail.example.com.", Type: "MX", TTL: 3600, Records: []string{"10 mx.example.com."}},
+				{Name: "example.com.", Type: "TXT", TTL: 3600, Records: []string{"v=spf1 include:example.net ~all"}},
+				{Name: "txt.example.com.", Type: "TXT", TTL: 3600, Records: []string{`"part one" "part two"`}},
+				{Name: "quoted.example.com.", Type: "TXT", TTL: 3600, Records: []string{`say "hello"`}},
+				{Name: "alias.example.com.", Type: "CNAME", TTL: 3600, Records: []string{"www.example.com."}},
+				{Name: "api.sub.example.com.", Type: "AAAA", TTL: 3600, Records: []string{"::1"}},
+			},
+		},
+		{
+			description: "default ttl and origin",
+			content:     "www A 1.2.3.4\n",
+			origin:      "example.com.",
+			defaultTTL:  60,
+			isValid:     true,
+			expected: []RecordSet{
+				{Name: "www.example.com.", Type: "A", TTL: 60, Records: []string{"1.2.3.4"}},
+			},
+		},
+		{
+			description: "long txt record is split",
+			content:     "txt TXT " + strings.Repeat("a", 300) + "\n",
+			origin:      "example.com",
+			isValid:     true,
+			expected: []RecordSet{
+				{Name: "txt.example.com.", Type: "TXT", Records: []string{`"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`}},
+			},
+		},
+		{
+			description: "empty",
+			content:     "; nothing here\n\n",
+			origin:      "example.com",
+			isValid:     true,
+			expected:    []RecordSet{},
+		},
+		{
+			description: "unsupported type",
+			content:     "www HINFO cpu os\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "unsupported class",
+			content:     "www CH A 1.2.3.4\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "unsupported directive",
+			content:     "$INCLUDE other.zone\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "missing owner",
+			content:     "  A 1.2.3.4\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "missing data",
+			content:     "www A\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "unbalanced parentheses",
+			content:     "@ SOA ns. host. ( 1 2 3 4 5\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "unterminated string",
+			content:     "txt TXT \"foo\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+		{
+			description: "invalid ttl",
+			content:     "$TTL 1x\n",
+			origin:      "example.com",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			recordSets, err := Parse(tt.content, tt.origin, tt.defaultTTL)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing zone file: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(recordSets, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	recordSets := []RecordSet{
+		{Name: "www.example.com.", Type: "A", TTL: 300, Records: []string{"1.2.3.4", "5.6.7.8"}},
+		{Name: "example.com.", Type: "TXT", TTL: 3600, Records: []string{`say "hello"`}},
+		{Name: "example.com.", Type: "NS", TTL: 3600, Records: []string{"ns1.stackit.cloud."}},
+		{Name: "other.org.", Type: "CNAME", TTL: 60, Records: []string{"www.example.com."}},
+	}
+	expected := "$ORIGIN example.com.\n" +
+		"$TTL 3600\n" +
+		"@\t3600\tIN\tNS\tns1.stackit.cloud.\n" +
+		"@\t3600\tIN\tTXT\t\"say \\\"hello\\\"\"\n" +
+		"other.org.\t60\tIN\tCNAME\twww.example.com.\n" +
+		"www\t300\tIN\tA\t1.2.3.4\n" +
+		"www\t300\tIN\tA\t5.6.7.8\n"
+
+	output := Format("example.com", 3600, recordSets)
+	diff := cmp.Diff(output, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+
+	// The output must be parsed back to the same record sets
+	parsed, err := Parse(output, "example.com", 0)
+	if err != nil {
+		t.Fatalf("error parsing formatted zone file: %v", err)
+	}
+	if len(parsed) != len(recordSets) {
+		t.Fatalf("expected %d record sets, got %d", len(recordSets), len(parsed))
+	}
+}
+
+func TestNormalizeRecord(t *testing.T) {
+	tests := []struct {
+		description string
+		recordType  string
+		content     string
+		expected    string
+	}{
+		{
+			description: "not txt",
+			recordType:  "A",
+			content:     "1.2.3.4",
+			expected:    "1.2.3.4",
+		},
+		{
+			description: "unquoted txt",
+			recordType:  "TXT",
+			content:     "foo bar",
+			expected:    "foo bar",
+		},
+		{
+			description: "quoted txt",
+			recordType:  "TXT",
+			content:     `"say \"hello\""`,
+			expected:    `say "hello"`,
+		},
+		{
+			description: "several strings",
+			recordType:  "TXT",
+			content:     `"foo" "bar"`,
+			expected:    `"foo" "bar"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := NormalizeRecord(tt.recordType, tt.content)
+			if result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestQualify(t *testing.T) {
+	tests := []struct {
+		name     string
+		origin   string
+		expected string
+	}{
+		{"@", "example.com.", "example.com."},
+		{"www", "example.com.", "www.example.com."},
+		{"WWW.Example.com.", "example.com.", "www.example.com."},
+		{"example.com", ".", "example.com."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Qualify(tt.name, tt.origin)
+			if result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestQualifyRecord(t *testing.T) {
+	tests := []struct {
+		recordType string
+		content    string
+		expected   string
+	}{
+		{"CNAME", "web", "web.example.com."},
+		{"CNAME", "web.other.com.", "web.other.com."},
+		{"MX", "10 mail", "10 mail.example.com."},
+		{"SRV", "10 60 5060 sip", "10 60 5060 sip.example.com."},
+		{"NS", "@", "example.com."},
+		{"A", "1.2.3.4", "1.2.3.4"},
+		{"TXT", "some text", "some text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recordType+" "+tt.content, func(t *testing.T) {
+			result := QualifyRecord(tt.recordType, tt.content, "example.com.")
+			if result != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package client
 
 import (
+	"fmt"
+
 	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/cassette"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
@@ -13,6 +16,17 @@ import (
 )
 
 func ConfigureClient(p *print.Printer, cliVersion string) (*iaas.APIClient, error) {
+	return ConfigureClientForRegion(p, cliVersion, viper.GetString(config.RegionKey))
+}
+
+// ConfigureClientForRegion configures a client for the given region instead of the configured one.
+// A custom endpoint serves a single region, so it fails if a custom endpoint is configured and the region is not the configured one.
+func ConfigureClientForRegion(p *print.Printer, cliVersion, region string) (*iaas.APIClient, error) {
+	err := checkCustomEndpointRegion(region)
+	if err != nil {
+		return nil, err
+	}
+
 	authCfgOption, err := auth.AuthenticationConfig(p, auth.AuthorizeUser)
 	if err != nil {
 		p.Debug(print.ErrorLevel, "configure authentication: %v", err)
@@ -27,10 +41,15 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*iaas.APIClient, erro
 	if customEndpoint != "" {
 		cfgOptions = append(cfgOptions, sdkConfig.WithEndpoint(customEndpoint))
 	} else {
-		region := viper.GetString(config.RegionKey)
 		cfgOptions = append(cfgOptions, authCfgOption, sdkConfig.WithRegion(region))
 	}
 
+	cassetteCfgOption, err := cassette.ConfigOption(p)
+	if err != nil {
+		return nil, err
+	}
+	cfgOptions = append(cfgOptions, cassetteCfgOption)
+
 	if p.IsVerbosityDebug() {
 		cfgOptions = append(cfgOptions,
 			sdkConfig.WithMiddleware(print.RequestResponseCapturer(p, nil)),
@@ -45,3 +64,12 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*iaas.APIClient, erro
 
 	return apiClient, nil
 }
+
+func checkCustomEndpointRegion(region string) error {
+	customEndpoint := viper.GetString(config.IaaSCustomEndpointKey)
+	configuredRegion := viper.GetString(config.RegionKey)
+	if customEndpoint == "" || region == configuredRegion {
+		return nil
+	}
+	return fmt.Errorf("the custom IaaS endpoint %q is configured for region %q and can't be used for region %q, unset the %q config option to use another region", customEndpoint, configuredRegion, region, config.IaaSCustomEndpointKey)
+}
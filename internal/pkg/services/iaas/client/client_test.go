@@ -0,0 +1,51 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+)
+
+func TestCheckCustomEndpointRegion(t *testing.T) {
+	tests := []struct {
+		description    string
+		customEndpoint string
+		region         string
+		isValid        bool
+	}{
+		{
+			description: "no custom endpoint",
+			region:      "eu02",
+			isValid:     true,
+		},
+		{
+			description:    "custom endpoint with configured region",
+			customEndpoint: "https://iaas.example.com",
+			region:         "eu01",
+			isValid:        true,
+		},
+		{
+			description:    "custom endpoint with other region",
+			customEndpoint: "https://iaas.example.com",
+			region:         "eu02",
+			isValid:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			viper.Set(config.IaaSCustomEndpointKey, tt.customEndpoint)
+			viper.Set(config.RegionKey, "eu01")
+			defer viper.Reset()
+
+			err := checkCustomEndpointRegion(tt.region)
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	IssueOverlap            = "overlap"
+	IssueOutsideRange       = "outside-range"
+	IssueUnreachableNexthop = "unreachable-next-hop"
+)
+
+// Network is a network of a project attached to a network area
+type Network struct {
+	ProjectId string         `json:"projectId"`
+	NetworkId string         `json:"networkId"`
+	Name      string         `json:"name"`
+	Prefixes  []netip.Prefix `json:"prefixes"`
+}
+
+// Route is a static route of a network area
+type Route struct {
+	RouteId string       `json:"routeId"`
+	Prefix  netip.Prefix `json:"prefix"`
+	Nexthop netip.Addr   `json:"nexthop"`
+}
+
+// Area holds the address allocations of a network area
+type Area struct {
+	Ranges   []netip.Prefix `json:"ranges"`
+	Networks []Network      `json:"networks"`
+	Routes   []Route        `json:"routes"`
+}
+
+// Issue is a problem found in the address allocations of a network area
+type Issue struct {
+	Type     string `json:"type"`
+	Resource string `json:"resource"`
+	Prefix   string `json:"prefix"`
+	Details  string `json:"details"`
+}
+
+// LoadArea returns the network ranges and routes of the network area, and the networks of all projects attached to it
+func LoadArea(ctx context.Context, apiClient *iaas.APIClient, organizationId, areaId string) (*Area, error) {
+	area := &Area{
+		Ranges:   []netip.Prefix{},
+		Networks: []Network{},
+		Routes:   []Route{},
+	}
+
+	rangesResp, err := apiClient.ListNetworkAreaRangesExecute(ctx, organizationId, areaId)
+	if err != nil {
+		return nil, fmt.Errorf("list network ranges: %w", err)
+	}
+	for _, networkRange := range utils.PtrValue(rangesResp.Items) {
+		prefix, err := netip.ParsePrefix(utils.PtrString(networkRange.Prefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse network range %q: %w", utils.PtrString(networkRange.Prefix), err)
+		}
+		area.Ranges = append(area.Ranges, prefix.Masked())
+	}
+
+	routesResp, err := apiClient.ListNetworkAreaRoutesExecute(ctx, organizationId, areaId)
+	if err != nil {
+		return nil, fmt.Errorf("list static routes: %w", err)
+	}
+	for _, route := range utils.PtrValue(routesResp.Items) {
+		prefix, err := netip.ParsePrefix(utils.PtrString(route.Prefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse prefix of route %q: %w", utils.PtrString(route.RouteId), err)
+		}
+		nexthop, err := netip.ParseAddr(utils.PtrString(route.Nexthop))
+		if err != nil {
+			return nil, fmt.Errorf("parse next hop of route %q: %w", utils.PtrString(route.RouteId), err)
+		}
+		area.Routes = append(area.Routes, Route{
+			RouteId: utils.PtrString(route.RouteId),
+			Prefix:  prefix.Masked(),
+			Nexthop: nexthop,
+		})
+	}
+
+	projects, err := iaasUtils.ListAttachedProjects(ctx, apiClient, organizationId, areaId)
+	if err != nil {
+		return nil, err
+	}
+	for _, projectId := range projects {
+		networksResp, err := apiClient.ListNetworksExecute(ctx, projectId)
+		if err != nil {
+			return nil, fmt.Errorf("list networks of project %q: %w", projectId, err)
+		}
+		for _, network := range utils.PtrValue(networksResp.Items) {
+			n := Network{
+				ProjectId: projectId,
+				NetworkId: utils.PtrString(network.NetworkId),
+				Name:      utils.PtrString(network.Name),
+				Prefixes:  []netip.Prefix{},
+			}
+			for _, p := range utils.PtrValue(network.Prefixes) {
+				prefix, err := netip.ParsePrefix(p)
+				if err != nil {
+					return nil, fmt.Errorf("parse prefix of network %q: %w", n.NetworkId, err)
+				}
+				n.Prefixes = append(n.Prefixes, prefix.Masked())
+			}
+			area.Networks = append(area.Networks, n)
+		}
+	}
+	return area, nil
+}
+
+// Allocated returns the prefixes of all networks of the area
+func (a *Area) Allocated() []netip.Prefix {
+	allocated := []netip.Prefix{}
+	for _, network := range a.Networks {
+		allocated = append(allocated, network.Prefixes...)
+	}
+	return allocated
+}
+
+// Free returns the parts of the ranges which don't overlap any allocated prefix, as the largest possible prefixes
+func Free(ranges, allocated []netip.Prefix) []netip.Prefix {
+	free := []netip.Prefix{}
+	for _, r := range ranges {
+		free = append(free, subtract(r.Masked(), allocated)...)
+	}
+	slices.SortFunc(free, comparePrefixes)
+	return free
+}
+
+func subtract(prefix netip.Prefix, allocated []netip.Prefix) []netip.Prefix {
+	overlapping := false
+	for _, a := range allocated {
+		if !a.Overlaps(prefix) {
+			continue
+		}
+		if a.Bits() <= prefix.Bits() {
+			// The prefix is fully allocated
+			return nil
+		}
+		overlapping = true
+	}
+	if !overlapping {
+		return []netip.Prefix{prefix}
+	}
+	lower, upper := split(prefix)
+	return append(subtract(lower, allocated), subtract(upper, allocated)...)
+}
+
+// Candidates returns up to limit prefixes with the given number of bits which are within the free prefixes.
+// A limit of 0 returns all candidates.
+func Candidates(free []netip.Prefix, bits, limit int) []netip.Prefix {
+	candidates := []netip.Prefix{}
+	var collect func(prefix netip.Prefix)
+	collect = func(prefix netip.Prefix) {
+		if limit > 0 && len(candidates) >= limit {
+			return
+		}
+		if prefix.Bits() == bits {
+			candidates = append(candidates, prefix)
+			return
+		}
+		lower, upper := split(prefix)
+		collect(lower)
+		collect(upper)
+	}
+	for _, prefix := range free {
+		if prefix.Bits() > bits || bits > prefix.Addr().BitLen() {
+			continue
+		}
+		collect(prefix)
+	}
+	return candidates
+}
+
+// Check returns overlapping networks, networks outside of the network ranges and routes whose next hop is not in any network
+func Check(area *Area) []Issue {
+	issues := []Issue{}
+
+	type allocation struct {
+		network *Network
+		prefix  netip.Prefix
+	}
+	allocations := []allocation{}
+	for i := range area.Networks {
+		for _, prefix := range area.Networks[i].Prefixes {
+			allocations = append(allocations, allocation{network: &area.Networks[i], prefix: prefix})
+		}
+	}
+
+	for i, a := range allocations {
+		for _, b := range allocations[i+1:] {
+			if a.network == b.network || !a.prefix.Overlaps(b.prefix) {
+				continue
+			}
+			issues = append(issues, Issue{
+				Type:     IssueOverlap,
+				Resource: describeNetwork(a.network),
+				Prefix:   a.prefix.String(),
+				Details:  fmt.Sprintf("overlaps prefix %s of %s", b.prefix, describeNetwork(b.network)),
+			})
+		}
+
+		inRange := false
+		for _, r := range area.Ranges {
+			if r.Bits() <= a.prefix.Bits() && r.Contains(a.prefix.Addr()) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange && a.prefix.Addr().Is4() {
+			issues = append(issues, Issue{
+				Type:     IssueOutsideRange,
+				Resource: describeNetwork(a.network),
+				Prefix:   a.prefix.String(),
+				Details:  "prefix is not within any network range of the network area",
+			})
+		}
+	}
+
+	for _, route := range area.Routes {
+		reachable := false
+		for _, a := range allocations {
+			if a.prefix.Contains(route.Nexthop) {
+				reachable = true
+				break
+			}
+		}
+		if !reachable {
+			issues = append(issues, Issue{
+				Type:     IssueUnreachableNexthop,
+				Resource: fmt.Sprintf("route %s", route.RouteId),
+				Prefix:   route.Prefix.String(),
+				Details:  fmt.Sprintf("next hop %s is not within any network of the network area", route.Nexthop),
+			})
+		}
+	}
+	return issues
+}
+
+func describeNetwork(network *Network) string {
+	return fmt.Sprintf("network %q (%s) of project %s", network.Name, network.NetworkId, network.ProjectId)
+}
+
+// split returns the two halves of the prefix
+func split(prefix netip.Prefix) (lower, upper netip.Prefix) {
+	bits := prefix.Bits()
+	lower = netip.PrefixFrom(prefix.Addr(), bits+1)
+
+	addr := prefix.Addr().AsSlice()
+	addr[bits/8] |= 0x80 >> (bits % 8)
+	upperAddr, _ := netip.AddrFromSlice(addr)
+	upper = netip.PrefixFrom(upperAddr, bits+1)
+	return lower, upper
+}
+
+func comparePrefixes(a, b netip.Prefix) int {
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+	return a.Bits() - b.Bits()
+}
@@ -0,0 +1,197 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+func prefixes(values ...string) []netip.Prefix {
+	result := []netip.Prefix{}
+	for _, v := range values {
+		result = append(result, netip.MustParsePrefix(v))
+	}
+	return result
+}
+
+func TestFree(t *testing.T) {
+	tests := []struct {
+		description string
+		ranges      []netip.Prefix
+		allocated   []netip.Prefix
+		expected    []netip.Prefix
+	}{
+		{
+			description: "nothing allocated",
+			ranges:      prefixes("10.0.0.0/16"),
+			allocated:   prefixes(),
+			expected:    prefixes("10.0.0.0/16"),
+		},
+		{
+			description: "fully allocated",
+			ranges:      prefixes("10.0.0.0/24"),
+			allocated:   prefixes("10.0.0.0/16"),
+			expected:    prefixes(),
+		},
+		{
+			description: "partially allocated",
+			ranges:      prefixes("10.0.0.0/22"),
+			allocated:   prefixes("10.0.1.0/24", "192.168.0.0/24"),
+			expected:    prefixes("10.0.0.0/24", "10.0.2.0/23"),
+		},
+		{
+			description: "several ranges",
+			ranges:      prefixes("10.1.0.0/24", "10.0.0.0/24"),
+			allocated:   prefixes("10.0.0.0/25"),
+			expected:    prefixes("10.0.0.128/25", "10.1.0.0/24"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			free := Free(tt.ranges, tt.allocated)
+			diff := cmp.Diff(free, tt.expected, cmpopts.EquateComparable(netip.Prefix{}))
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		description string
+		free        []netip.Prefix
+		bits        int
+		limit       int
+		expected    []netip.Prefix
+	}{
+		{
+			description: "split free prefixes",
+			free:        prefixes("10.0.0.0/24", "10.0.2.0/23"),
+			bits:        24,
+			expected:    prefixes("10.0.0.0/24", "10.0.2.0/24", "10.0.3.0/24"),
+		},
+		{
+			description: "limit",
+			free:        prefixes("10.0.0.0/16"),
+			bits:        24,
+			limit:       2,
+			expected:    prefixes("10.0.0.0/24", "10.0.1.0/24"),
+		},
+		{
+			description: "free prefixes too small",
+			free:        prefixes("10.0.0.0/25"),
+			bits:        24,
+			expected:    prefixes(),
+		},
+		{
+			description: "invalid size",
+			free:        prefixes("10.0.0.0/24"),
+			bits:        33,
+			expected:    prefixes(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			candidates := Candidates(tt.free, tt.bits, tt.limit)
+			diff := cmp.Diff(candidates, tt.expected, cmpopts.EquateComparable(netip.Prefix{}))
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	area := &Area{
+		Ranges: prefixes("10.0.0.0/16"),
+		Networks: []Network{
+			{ProjectId: "p1", NetworkId: "n1", Name: "a", Prefixes: prefixes("10.0.0.0/24")},
+			{ProjectId: "p2", NetworkId: "n2", Name: "b", Prefixes: prefixes("10.0.0.128/25")},
+			{ProjectId: "p2", NetworkId: "n3", Name: "c", Prefixes: prefixes("192.168.0.0/24")},
+		},
+		Routes: []Route{
+			{RouteId: "r1", Prefix: netip.MustParsePrefix("0.0.0.0/0"), Nexthop: netip.MustParseAddr("10.0.0.1")},
+			{RouteId: "r2", Prefix: netip.MustParsePrefix("172.16.0.0/12"), Nexthop: netip.MustParseAddr("10.0.5.1")},
+		},
+	}
+	expected := []Issue{
+		{
+			Type:     IssueOverlap,
+			Resource: `network "a" (n1) of project p1`,
+			Prefix:   "10.0.0.0/24",
+			Details:  `overlaps prefix 10.0.0.128/25 of network "b" (n2) of project p2`,
+		},
+		{
+			Type:     IssueOutsideRange,
+			Resource: `network "c" (n3) of project p2`,
+			Prefix:   "192.168.0.0/24",
+			Details:  "prefix is not within any network range of the network area",
+		},
+		{
+			Type:     IssueUnreachableNexthop,
+			Resource: "route r2",
+			Prefix:   "172.16.0.0/12",
+			Details:  "next hop 10.0.5.1 is not within any network of the network area",
+		},
+	}
+
+	diff := cmp.Diff(Check(area), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestLoadArea(t *testing.T) {
+	organizationId := uuid.NewString()
+	areaId := uuid.NewString()
+	projectId := uuid.NewString()
+	areaPath := fmt.Sprintf("/v1/organizations/%s/network-areas/%s", organizationId, areaId)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case areaPath + "/network-ranges":
+			_, _ = fmt.Fprint(w, `{"items": [{"networkRangeId": "range", "prefix": "10.0.0.0/16"}]}`)
+		case areaPath + "/routes":
+			_, _ = fmt.Fprint(w, `{"items": [{"routeId": "route", "prefix": "0.0.0.0/0", "nexthop": "10.0.0.1"}]}`)
+		case areaPath + "/projects":
+			_, _ = fmt.Fprintf(w, `{"items": [%q]}`, projectId)
+		case fmt.Sprintf("/v1/projects/%s/networks", projectId):
+			_, _ = fmt.Fprint(w, `{"items": [{"networkId": "net", "name": "a", "state": "CREATED", "prefixes": ["10.0.0.0/24"]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+
+	area, err := LoadArea(context.Background(), apiClient, organizationId, areaId)
+	if err != nil {
+		t.Fatalf("failed to load area: %v", err)
+	}
+	expected := &Area{
+		Ranges:   prefixes("10.0.0.0/16"),
+		Networks: []Network{{ProjectId: projectId, NetworkId: "net", Name: "a", Prefixes: prefixes("10.0.0.0/24")}},
+		Routes:   []Route{{RouteId: "route", Prefix: netip.MustParsePrefix("0.0.0.0/0"), Nexthop: netip.MustParseAddr("10.0.0.1")}},
+	}
+	diff := cmp.Diff(area, expected, cmpopts.EquateComparable(netip.Prefix{}, netip.Addr{}))
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
@@ -0,0 +1,378 @@
+package labels
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	TypeImage         = "image"
+	TypeKeyPair       = "key-pair"
+	TypeNetwork       = "network"
+	TypePublicIp      = "public-ip"
+	TypeSecurityGroup = "security-group"
+	TypeServer        = "server"
+	TypeSnapshot      = "snapshot"
+	TypeVolume        = "volume"
+)
+
+// Types are the resource types which have labels
+var Types = []string{TypeImage, TypeKeyPair, TypeNetwork, TypePublicIp, TypeSecurityGroup, TypeServer, TypeSnapshot, TypeVolume}
+
+// Resource is a labeled IaaS resource. Key pairs are identified by their name.
+type Resource struct {
+	Type   string            `json:"type"`
+	Id     string            `json:"id"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+}
+
+type resourceType struct {
+	get    func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error)
+	list   func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error)
+	update func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error
+}
+
+var resourceTypes = map[string]resourceType{
+	TypeImage: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetImageExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeImage, resp.Id, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListImagesExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeImage, item.Id, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateImage(ctx, projectId, id).UpdateImagePayload(iaas.UpdateImagePayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeKeyPair: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, _, id string) (*Resource, error) {
+			resp, err := apiClient.GetKeyPairExecute(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeKeyPair, resp.Name, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, _ string) ([]Resource, error) {
+			resp, err := apiClient.ListKeyPairsExecute(ctx)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeKeyPair, item.Name, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, _, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateKeyPair(ctx, id).UpdateKeyPairPayload(iaas.UpdateKeyPairPayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeNetwork: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetNetworkExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeNetwork, resp.NetworkId, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListNetworksExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeNetwork, item.NetworkId, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			return apiClient.PartialUpdateNetwork(ctx, projectId, id).PartialUpdateNetworkPayload(iaas.PartialUpdateNetworkPayload{Labels: labels}).Execute()
+		},
+	},
+	TypePublicIp: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetPublicIPExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypePublicIp, resp.Id, resp.Ip, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListPublicIPsExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypePublicIp, item.Id, item.Ip, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdatePublicIP(ctx, projectId, id).UpdatePublicIPPayload(iaas.UpdatePublicIPPayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeSecurityGroup: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetSecurityGroupExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeSecurityGroup, resp.Id, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListSecurityGroupsExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeSecurityGroup, item.Id, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateSecurityGroup(ctx, projectId, id).UpdateSecurityGroupPayload(iaas.UpdateSecurityGroupPayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeServer: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetServerExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeServer, resp.Id, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListServersExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeServer, item.Id, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateServer(ctx, projectId, id).UpdateServerPayload(iaas.UpdateServerPayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeSnapshot: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetSnapshotExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeSnapshot, resp.Id, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListSnapshotsExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeSnapshot, item.Id, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateSnapshot(ctx, projectId, id).UpdateSnapshotPayload(iaas.UpdateSnapshotPayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+	TypeVolume: {
+		get: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string) (*Resource, error) {
+			resp, err := apiClient.GetVolumeExecute(ctx, projectId, id)
+			if err != nil {
+				return nil, err
+			}
+			return newResource(TypeVolume, resp.Id, resp.Name, resp.Labels), nil
+		},
+		list: func(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Resource, error) {
+			resp, err := apiClient.ListVolumesExecute(ctx, projectId)
+			if err != nil {
+				return nil, err
+			}
+			resources := []Resource{}
+			for _, item := range utils.PtrValue(resp.Items) {
+				resources = append(resources, *newResource(TypeVolume, item.Id, item.Name, item.Labels))
+			}
+			return resources, nil
+		},
+		update: func(ctx context.Context, apiClient *iaas.APIClient, projectId, id string, labels *map[string]interface{}) error {
+			_, err := apiClient.UpdateVolume(ctx, projectId, id).UpdateVolumePayload(iaas.UpdateVolumePayload{Labels: labels}).Execute()
+			return err
+		},
+	},
+}
+
+func newResource(resourceType string, id, name *string, labels *map[string]interface{}) *Resource {
+	return &Resource{
+		Type:   resourceType,
+		Id:     utils.PtrString(id),
+		Name:   utils.PtrString(name),
+		Labels: FromAPI(labels),
+	}
+}
+
+// FromAPI converts the labels of an API resource
+func FromAPI(labels *map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for k, v := range utils.PtrValue(labels) {
+		result[k] = fmt.Sprint(v)
+	}
+	return result
+}
+
+// ToAPI converts labels for an API payload. An empty map is kept, so that all labels are removed.
+func ToAPI(labels map[string]string) *map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range labels {
+		result[k] = v
+	}
+	return &result
+}
+
+// ParseRef parses a resource reference in the format "TYPE:ID"
+func ParseRef(ref string) (resourceType, id string, err error) {
+	resourceType, id, found := strings.Cut(ref, ":")
+	if !found || id == "" {
+		return "", "", fmt.Errorf("must be in the format TYPE:ID")
+	}
+	if _, ok := resourceTypes[resourceType]; !ok {
+		return "", "", fmt.Errorf("resource type %q is not supported, use one of %s", resourceType, strings.Join(Types, ", "))
+	}
+	if resourceType != TypeKeyPair {
+		if err := utils.ValidateUUID(id); err != nil {
+			return "", "", fmt.Errorf("ID %q of the %s is invalid: %w", id, resourceType, err)
+		}
+	}
+	return resourceType, id, nil
+}
+
+// ParseLabels parses labels in the format KEY=VALUE
+func ParseLabels(values []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, value := range values {
+		key, v, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("label %q must be in the format KEY=VALUE", value)
+		}
+		labels[key] = v
+	}
+	return labels, nil
+}
+
+// Get returns the resource of the given type with the given ID
+func Get(ctx context.Context, apiClient *iaas.APIClient, projectId, resourceType, id string) (*Resource, error) {
+	t, ok := resourceTypes[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("resource type %q is not supported", resourceType)
+	}
+	resource, err := t.get(ctx, apiClient, projectId, id)
+	if err != nil {
+		return nil, fmt.Errorf("get %s %q: %w", resourceType, id, err)
+	}
+	return resource, nil
+}
+
+// Find returns the resources of the given types whose labels match the selector
+func Find(ctx context.Context, apiClient *iaas.APIClient, projectId string, types []string, selector Selector) ([]Resource, error) {
+	resources := []Resource{}
+	for _, resourceType := range types {
+		t, ok := resourceTypes[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("resource type %q is not supported", resourceType)
+		}
+		items, err := t.list(ctx, apiClient, projectId)
+		if err != nil {
+			return nil, fmt.Errorf("list %s resources: %w", resourceType, err)
+		}
+		for i := range items {
+			if selector.Matches(items[i].Labels) {
+				resources = append(resources, items[i])
+			}
+		}
+	}
+	return resources, nil
+}
+
+// Update sets the labels of the resource, replacing all existing labels
+func Update(ctx context.Context, apiClient *iaas.APIClient, projectId string, resource *Resource) error {
+	t, ok := resourceTypes[resource.Type]
+	if !ok {
+		return fmt.Errorf("resource type %q is not supported", resource.Type)
+	}
+	err := t.update(ctx, apiClient, projectId, resource.Id, ToAPI(resource.Labels))
+	if err != nil {
+		return fmt.Errorf("update labels of %s %q: %w", resource.Type, resource.Id, err)
+	}
+	return nil
+}
+
+// Add returns the labels with the given labels added, and whether they changed
+func Add(labels, add map[string]string) (result map[string]string, changed bool) {
+	result = maps.Clone(labels)
+	if result == nil {
+		result = map[string]string{}
+	}
+	for k, v := range add {
+		if current, ok := result[k]; !ok || current != v {
+			result[k] = v
+			changed = true
+		}
+	}
+	return result, changed
+}
+
+// Remove returns the labels without the given keys, and whether they changed
+func Remove(labels map[string]string, keys []string) (result map[string]string, changed bool) {
+	result = maps.Clone(labels)
+	if result == nil {
+		result = map[string]string{}
+	}
+	for _, k := range keys {
+		if _, ok := result[k]; ok {
+			delete(result, k)
+			changed = true
+		}
+	}
+	return result, changed
+}
+
+// Format returns the labels as sorted KEY=VALUE lines
+func Format(labels map[string]string) string {
+	lines := []string{}
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	return strings.Join(lines, "\n")
+}
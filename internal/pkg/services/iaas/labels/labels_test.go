@@ -0,0 +1,319 @@
+package labels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var (
+	testProjectId = uuid.NewString()
+	testServerId  = uuid.NewString()
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		description  string
+		ref          string
+		isValid      bool
+		expectedType string
+		expectedId   string
+	}{
+		{
+			description:  "server",
+			ref:          "server:" + testServerId,
+			isValid:      true,
+			expectedType: TypeServer,
+			expectedId:   testServerId,
+		},
+		{
+			description:  "key pair by name",
+			ref:          "key-pair:my-key",
+			isValid:      true,
+			expectedType: TypeKeyPair,
+			expectedId:   "my-key",
+		},
+		{
+			description: "no type",
+			ref:         testServerId,
+			isValid:     false,
+		},
+		{
+			description: "no id",
+			ref:         "server:",
+			isValid:     false,
+		},
+		{
+			description: "unsupported type",
+			ref:         "load-balancer:" + testServerId,
+			isValid:     false,
+		},
+		{
+			description: "invalid id",
+			ref:         "volume:invalid-uuid",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			resourceType, id, err := ParseRef(tt.ref)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing reference: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if resourceType != tt.expectedType || id != tt.expectedId {
+				t.Fatalf("expected %s:%s, got %s:%s", tt.expectedType, tt.expectedId, resourceType, id)
+			}
+		})
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		description string
+		values      []string
+		isValid     bool
+		expected    map[string]string
+	}{
+		{
+			description: "base",
+			values:      []string{"env=prod", "team=web"},
+			isValid:     true,
+			expected:    map[string]string{"env": "prod", "team": "web"},
+		},
+		{
+			description: "empty value",
+			values:      []string{"env="},
+			isValid:     true,
+			expected:    map[string]string{"env": ""},
+		},
+		{
+			description: "no value",
+			values:      []string{"env"},
+			isValid:     false,
+		},
+		{
+			description: "no key",
+			values:      []string{"=prod"},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			labels, err := ParseLabels(tt.values)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing labels: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(labels, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		description     string
+		labels          map[string]string
+		add             map[string]string
+		expected        map[string]string
+		expectedChanged bool
+	}{
+		{
+			description:     "new label",
+			labels:          map[string]string{"env": "prod"},
+			add:             map[string]string{"team": "web"},
+			expected:        map[string]string{"env": "prod", "team": "web"},
+			expectedChanged: true,
+		},
+		{
+			description:     "changed value",
+			labels:          map[string]string{"env": "dev"},
+			add:             map[string]string{"env": "prod"},
+			expected:        map[string]string{"env": "prod"},
+			expectedChanged: true,
+		},
+		{
+			description:     "same value",
+			labels:          map[string]string{"env": "prod"},
+			add:             map[string]string{"env": "prod"},
+			expected:        map[string]string{"env": "prod"},
+			expectedChanged: false,
+		},
+		{
+			description:     "no labels",
+			labels:          nil,
+			add:             map[string]string{"env": "prod"},
+			expected:        map[string]string{"env": "prod"},
+			expectedChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			original := fmt.Sprint(tt.labels)
+			result, changed := Add(tt.labels, tt.add)
+			if changed != tt.expectedChanged {
+				t.Fatalf("expected changed %v, got %v", tt.expectedChanged, changed)
+			}
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+			if fmt.Sprint(tt.labels) != original {
+				t.Fatalf("input labels were modified")
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		description     string
+		labels          map[string]string
+		keys            []string
+		expected        map[string]string
+		expectedChanged bool
+	}{
+		{
+			description:     "existing label",
+			labels:          map[string]string{"env": "prod", "team": "web"},
+			keys:            []string{"team"},
+			expected:        map[string]string{"env": "prod"},
+			expectedChanged: true,
+		},
+		{
+			description:     "last label",
+			labels:          map[string]string{"env": "prod"},
+			keys:            []string{"env"},
+			expected:        map[string]string{},
+			expectedChanged: true,
+		},
+		{
+			description:     "missing label",
+			labels:          map[string]string{"env": "prod"},
+			keys:            []string{"team"},
+			expected:        map[string]string{"env": "prod"},
+			expectedChanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result, changed := Remove(tt.labels, tt.keys)
+			if changed != tt.expectedChanged {
+				t.Fatalf("expected changed %v, got %v", tt.expectedChanged, changed)
+			}
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	result := Format(map[string]string{"team": "web", "env": "prod"})
+	if result != "env=prod\nteam=web" {
+		t.Fatalf("unexpected format: %q", result)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *iaas.APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+	return apiClient
+}
+
+func TestFind(t *testing.T) {
+	otherServerId := uuid.NewString()
+	apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/projects/%s/servers", testProjectId):
+			_, _ = fmt.Fprintf(w, `{"items": [{"id": %q, "name": "web", "labels": {"env": "prod"}}, {"id": %q, "name": "test", "labels": {"env": "dev"}}]}`, testServerId, otherServerId)
+		case "/v1/keypairs":
+			_, _ = fmt.Fprint(w, `{"items": [{"name": "ops", "publicKey": "ssh-ed25519 xxx", "labels": {"env": "prod"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	selector, err := ParseSelector("env=prod")
+	if err != nil {
+		t.Fatalf("error parsing selector: %v", err)
+	}
+
+	resources, err := Find(context.Background(), apiClient, testProjectId, []string{TypeKeyPair, TypeServer}, selector)
+	if err != nil {
+		t.Fatalf("error finding resources: %v", err)
+	}
+	expected := []Resource{
+		{Type: TypeKeyPair, Id: "ops", Name: "ops", Labels: map[string]string{"env": "prod"}},
+		{Type: TypeServer, Id: testServerId, Name: "web", Labels: map[string]string{"env": "prod"}},
+	}
+	diff := cmp.Diff(resources, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+
+	_, err = Find(context.Background(), apiClient, testProjectId, []string{TypeVolume}, selector)
+	if err == nil {
+		t.Fatalf("did not fail on failed list request")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var payload map[string]interface{}
+	apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPatch || r.URL.Path != fmt.Sprintf("/v1/projects/%s/servers/%s", testProjectId, testServerId) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.Unmarshal(body, &payload)
+		_, _ = fmt.Fprintf(w, `{"id": %q, "name": "web"}`, testServerId)
+	})
+
+	resource := &Resource{Type: TypeServer, Id: testServerId, Labels: map[string]string{}}
+	err := Update(context.Background(), apiClient, testProjectId, resource)
+	if err != nil {
+		t.Fatalf("error updating labels: %v", err)
+	}
+	diff := cmp.Diff(payload, map[string]interface{}{"labels": map[string]interface{}{}})
+	if diff != "" {
+		t.Fatalf("Payload does not match, all labels must be removed: %s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	operatorEquals    = "="
+	operatorNotEquals = "!="
+	operatorExists    = "exists"
+	operatorNotExists = "!exists"
+)
+
+// Selector selects resources by their labels. It matches if all of its requirements match.
+type Selector []requirement
+
+type requirement struct {
+	key      string
+	operator string
+	value    string
+}
+
+// ParseSelector parses a comma separated list of requirements: "key=value", "key!=value",
+// "key" for resources with the label and "!key" for resources without it
+func ParseSelector(selector string) (Selector, error) {
+	result := Selector{}
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		var r requirement
+		switch {
+		case strings.Contains(term, "!="):
+			key, value, _ := strings.Cut(term, "!=")
+			r = requirement{key: key, operator: operatorNotEquals, value: value}
+		case strings.Contains(term, "=="):
+			key, value, _ := strings.Cut(term, "==")
+			r = requirement{key: key, operator: operatorEquals, value: value}
+		case strings.Contains(term, "="):
+			key, value, _ := strings.Cut(term, "=")
+			r = requirement{key: key, operator: operatorEquals, value: value}
+		case strings.HasPrefix(term, "!"):
+			r = requirement{key: strings.TrimPrefix(term, "!"), operator: operatorNotExists}
+		default:
+			r = requirement{key: term, operator: operatorExists}
+		}
+
+		r.key = strings.TrimSpace(r.key)
+		r.value = strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, fmt.Errorf("requirement %q has no label key", term)
+		}
+		result = append(result, r)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("selector must not be empty")
+	}
+	return result, nil
+}
+
+// Matches returns whether the labels match all requirements of the selector
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		value, ok := labels[r.key]
+		switch r.operator {
+		case operatorEquals:
+			if !ok || value != r.value {
+				return false
+			}
+		case operatorNotEquals:
+			if ok && value == r.value {
+				return false
+			}
+		case operatorExists:
+			if !ok {
+				return false
+			}
+		case operatorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package labels
+
+import (
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		description string
+		selector    string
+		isValid     bool
+		expected    Selector
+	}{
+		{
+			description: "equals",
+			selector:    "env=prod",
+			isValid:     true,
+			expected:    Selector{{key: "env", operator: operatorEquals, value: "prod"}},
+		},
+		{
+			description: "double equals",
+			selector:    "env==prod",
+			isValid:     true,
+			expected:    Selector{{key: "env", operator: operatorEquals, value: "prod"}},
+		},
+		{
+			description: "multiple requirements",
+			selector:    "env!=dev, team, !temporary",
+			isValid:     true,
+			expected: Selector{
+				{key: "env", operator: operatorNotEquals, value: "dev"},
+				{key: "team", operator: operatorExists},
+				{key: "temporary", operator: operatorNotExists},
+			},
+		},
+		{
+			description: "empty",
+			selector:    " , ",
+			isValid:     false,
+		},
+		{
+			description: "no key",
+			selector:    "=prod",
+			isValid:     false,
+		},
+		{
+			description: "no key for not exists",
+			selector:    "!",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			selector, err := ParseSelector(tt.selector)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing selector: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if len(selector) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, selector)
+			}
+			for i := range selector {
+				if selector[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, selector)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "web"}
+	tests := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "env=prod", expected: true},
+		{selector: "env=dev", expected: false},
+		{selector: "env!=dev", expected: true},
+		{selector: "env!=prod", expected: false},
+		{selector: "owner!=me", expected: true},
+		{selector: "team", expected: true},
+		{selector: "owner", expected: false},
+		{selector: "!owner", expected: true},
+		{selector: "!team", expected: false},
+		{selector: "env=prod,team=web", expected: true},
+		{selector: "env=prod,team=db", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("error parsing selector: %v", err)
+			}
+			if matches := selector.Matches(labels); matches != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, matches)
+			}
+		})
+	}
+}
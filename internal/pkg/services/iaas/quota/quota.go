@@ -0,0 +1,213 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+// Names of the project quotas, as used by the API
+const (
+	BackupGigabytes    = "backupGigabytes"
+	Backups            = "backups"
+	Gigabytes          = "gigabytes"
+	Networks           = "networks"
+	Nics               = "nics"
+	PublicIps          = "publicIps"
+	Ram                = "ram"
+	SecurityGroupRules = "securityGroupRules"
+	SecurityGroups     = "securityGroups"
+	Snapshots          = "snapshots"
+	Vcpu               = "vcpu"
+	Volumes            = "volumes"
+)
+
+// Quota is the limit and usage of a project quota
+type Quota struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Limit       int64  `json:"limit"`
+	Usage       int64  `json:"usage"`
+}
+
+// Percent returns the usage in percent of the limit
+func (q *Quota) Percent() float64 {
+	if q.Limit <= 0 {
+		if q.Usage > 0 {
+			return 100
+		}
+		return 0
+	}
+	return 100 * float64(q.Usage) / float64(q.Limit)
+}
+
+type quotaField struct {
+	name        string
+	description string
+	get         func(quotas *iaas.QuotaList) (limit, usage *int64, ok bool)
+}
+
+var quotaFields = []quotaField{
+	{BackupGigabytes, "Total size in GiB of backups [GiB]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.BackupGigabytes == nil {
+			return nil, nil, false
+		}
+		return q.BackupGigabytes.Limit, q.BackupGigabytes.Usage, true
+	}},
+	{Backups, "Number of backups [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Backups == nil {
+			return nil, nil, false
+		}
+		return q.Backups.Limit, q.Backups.Usage, true
+	}},
+	{Gigabytes, "Total size in GiB of volumes and snapshots [GiB]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Gigabytes == nil {
+			return nil, nil, false
+		}
+		return q.Gigabytes.Limit, q.Gigabytes.Usage, true
+	}},
+	{Networks, "Number of networks [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Networks == nil {
+			return nil, nil, false
+		}
+		return q.Networks.Limit, q.Networks.Usage, true
+	}},
+	{Nics, "Number of network interfaces (nics) [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Nics == nil {
+			return nil, nil, false
+		}
+		return q.Nics.Limit, q.Nics.Usage, true
+	}},
+	{PublicIps, "Number of public IP addresses [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.PublicIps == nil {
+			return nil, nil, false
+		}
+		return q.PublicIps.Limit, q.PublicIps.Usage, true
+	}},
+	{Ram, "Amount of server RAM in MiB [MiB]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Ram == nil {
+			return nil, nil, false
+		}
+		return q.Ram.Limit, q.Ram.Usage, true
+	}},
+	{SecurityGroupRules, "Number of security group rules [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.SecurityGroupRules == nil {
+			return nil, nil, false
+		}
+		return q.SecurityGroupRules.Limit, q.SecurityGroupRules.Usage, true
+	}},
+	{SecurityGroups, "Number of security groups [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.SecurityGroups == nil {
+			return nil, nil, false
+		}
+		return q.SecurityGroups.Limit, q.SecurityGroups.Usage, true
+	}},
+	{Snapshots, "Number of snapshots [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Snapshots == nil {
+			return nil, nil, false
+		}
+		return q.Snapshots.Limit, q.Snapshots.Usage, true
+	}},
+	{Vcpu, "Number of server cores (vcpu) [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Vcpu == nil {
+			return nil, nil, false
+		}
+		return q.Vcpu.Limit, q.Vcpu.Usage, true
+	}},
+	{Volumes, "Number of volumes [Count]", func(q *iaas.QuotaList) (limit, usage *int64, ok bool) {
+		if q.Volumes == nil {
+			return nil, nil, false
+		}
+		return q.Volumes.Limit, q.Volumes.Usage, true
+	}},
+}
+
+// ReportedQuota is a project quota as returned by the API, whose limit or usage may be missing
+type ReportedQuota struct {
+	Name        string
+	Description string
+	Limit       *int64
+	Usage       *int64
+}
+
+// AllFromAPI returns all quotas of the list, including the ones without a limit or usage
+func AllFromAPI(quotas *iaas.QuotaList) []ReportedQuota {
+	result := []ReportedQuota{}
+	if quotas == nil {
+		return result
+	}
+	for _, field := range quotaFields {
+		limit, usage, ok := field.get(quotas)
+		if !ok {
+			continue
+		}
+		result = append(result, ReportedQuota{
+			Name:        field.name,
+			Description: field.description,
+			Limit:       limit,
+			Usage:       usage,
+		})
+	}
+	return result
+}
+
+// FromAPI returns the quotas of the list which have a limit and usage
+func FromAPI(quotas *iaas.QuotaList) []Quota {
+	result := []Quota{}
+	for _, q := range AllFromAPI(quotas) {
+		if q.Limit == nil || q.Usage == nil {
+			continue
+		}
+		result = append(result, Quota{
+			Name:        q.Name,
+			Description: q.Description,
+			Limit:       *q.Limit,
+			Usage:       *q.Usage,
+		})
+	}
+	return result
+}
+
+// List returns the quotas of the project
+func List(ctx context.Context, apiClient *iaas.APIClient, projectId string) ([]Quota, error) {
+	resp, err := apiClient.ListQuotas(ctx, projectId).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list quotas: %w", err)
+	}
+	return FromAPI(resp.Quotas), nil
+}
+
+// Check returns a QuotaExceededError for the first quota which would be exceeded by the
+// requested amounts, keyed by quota name. Negative limits are treated as unlimited.
+func Check(quotas []Quota, requested map[string]int64) error {
+	for _, q := range quotas {
+		amount := requested[q.Name]
+		if amount <= 0 || q.Limit < 0 {
+			continue
+		}
+		if q.Usage+amount > q.Limit {
+			return &errors.QuotaExceededError{
+				Quota:     q.Name,
+				Limit:     q.Limit,
+				Usage:     q.Usage,
+				Requested: amount,
+			}
+		}
+	}
+	return nil
+}
+
+// Preflight checks that the requested amounts fit into the project quotas before a resource is created.
+// If the quotas can't be read, the check is skipped and the API decides.
+func Preflight(ctx context.Context, p *print.Printer, apiClient *iaas.APIClient, projectId string, requested map[string]int64) error {
+	quotas, err := List(ctx, apiClient, projectId)
+	if err != nil {
+		p.Debug(print.WarningLevel, "skip quota check: %v", err)
+		return nil
+	}
+	return Check(quotas, requested)
+}
@@ -0,0 +1,205 @@
+package quota
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+var testProjectId = uuid.NewString()
+
+func TestFromAPI(t *testing.T) {
+	quotas := &iaas.QuotaList{
+		Networks: &iaas.QuotaListNetworks{Limit: utils.Ptr(int64(10)), Usage: utils.Ptr(int64(3))},
+		Vcpu:     &iaas.QuotaListVcpu{Limit: utils.Ptr(int64(100)), Usage: utils.Ptr(int64(40))},
+		Volumes:  &iaas.QuotaListVolumes{Limit: utils.Ptr(int64(20))},
+	}
+	expected := []Quota{
+		{Name: Networks, Description: "Number of networks [Count]", Limit: 10, Usage: 3},
+		{Name: Vcpu, Description: "Number of server cores (vcpu) [Count]", Limit: 100, Usage: 40},
+	}
+
+	diff := cmp.Diff(FromAPI(quotas), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if result := FromAPI(nil); len(result) != 0 {
+		t.Fatalf("expected no quotas, got %v", result)
+	}
+}
+
+func TestAllFromAPI(t *testing.T) {
+	quotas := &iaas.QuotaList{
+		Networks: &iaas.QuotaListNetworks{Limit: utils.Ptr(int64(10)), Usage: utils.Ptr(int64(3))},
+		Volumes:  &iaas.QuotaListVolumes{Limit: utils.Ptr(int64(20))},
+		Vcpu:     &iaas.QuotaListVcpu{},
+	}
+	expected := []ReportedQuota{
+		{Name: Networks, Description: "Number of networks [Count]", Limit: utils.Ptr(int64(10)), Usage: utils.Ptr(int64(3))},
+		{Name: Vcpu, Description: "Number of server cores (vcpu) [Count]"},
+		{Name: Volumes, Description: "Number of volumes [Count]", Limit: utils.Ptr(int64(20))},
+	}
+
+	diff := cmp.Diff(AllFromAPI(quotas), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if result := AllFromAPI(nil); len(result) != 0 {
+		t.Fatalf("expected no quotas, got %v", result)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		description string
+		quota       Quota
+		expected    float64
+	}{
+		{
+			description: "base",
+			quota:       Quota{Limit: 8, Usage: 2},
+			expected:    25,
+		},
+		{
+			description: "exceeded",
+			quota:       Quota{Limit: 2, Usage: 3},
+			expected:    150,
+		},
+		{
+			description: "zero limit",
+			quota:       Quota{Limit: 0, Usage: 1},
+			expected:    100,
+		},
+		{
+			description: "zero limit without usage",
+			quota:       Quota{Limit: 0, Usage: 0},
+			expected:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if percent := tt.quota.Percent(); percent != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, percent)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	quotas := []Quota{
+		{Name: Networks, Limit: 10, Usage: 9},
+		{Name: Vcpu, Limit: 100, Usage: 96},
+		{Name: Ram, Limit: -1, Usage: 4096},
+	}
+	tests := []struct {
+		description   string
+		requested     map[string]int64
+		expectedError *errors.QuotaExceededError
+	}{
+		{
+			description: "within quota",
+			requested:   map[string]int64{Networks: 1, Vcpu: 4},
+		},
+		{
+			description: "quota not in list",
+			requested:   map[string]int64{PublicIps: 1},
+		},
+		{
+			description: "unlimited quota",
+			requested:   map[string]int64{Ram: 1024},
+		},
+		{
+			description:   "exceeded",
+			requested:     map[string]int64{Networks: 1, Vcpu: 8},
+			expectedError: &errors.QuotaExceededError{Quota: Vcpu, Limit: 100, Usage: 96, Requested: 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := Check(quotas, tt.requested)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("should not have failed: %v", err)
+				}
+				return
+			}
+			var quotaErr *errors.QuotaExceededError
+			if !stdErrors.As(err, &quotaErr) {
+				t.Fatalf("expected quota exceeded error, got %v", err)
+			}
+			diff := cmp.Diff(quotaErr, tt.expectedError)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	tests := []struct {
+		description string
+		status      int
+		requested   map[string]int64
+		isValid     bool
+	}{
+		{
+			description: "within quota",
+			status:      http.StatusOK,
+			requested:   map[string]int64{PublicIps: 1},
+			isValid:     true,
+		},
+		{
+			description: "exceeded",
+			status:      http.StatusOK,
+			requested:   map[string]int64{PublicIps: 2},
+			isValid:     false,
+		},
+		{
+			description: "quotas not readable",
+			status:      http.StatusForbidden,
+			requested:   map[string]int64{PublicIps: 2},
+			isValid:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != fmt.Sprintf("/v1/projects/%s/quotas", testProjectId) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, `{"quotas": {"publicIps": {"limit": 5, "usage": 4}}}`)
+			}))
+			defer server.Close()
+			apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("failed to create API client: %v", err)
+			}
+
+			err = Preflight(context.Background(), print.NewPrinter(), apiClient, testProjectId, tt.requested)
+			if tt.isValid && err != nil {
+				t.Fatalf("should not have failed: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("should have failed")
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package reachability
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/rulefile"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	protocolICMP   int64 = 1
+	protocolICMPv6 int64 = 58
+
+	// ICMP traffic is evaluated as echo request, as sent by ping
+	icmpEchoRequest   int64 = 8
+	icmpv6EchoRequest int64 = 128
+)
+
+// Protocols whose traffic has ports
+var portProtocols = []int64{6, 17, 33, 132, 136}
+
+// Traffic is the traffic to analyze, from one endpoint to the other
+type Traffic struct {
+	Protocol string `json:"protocol"`
+	Port     *int64 `json:"port,omitempty"`
+	number   int64
+}
+
+// Interface is an IP address of an endpoint, with the security groups applied to it
+type Interface struct {
+	NicId            string     `json:"nicId,omitempty"`
+	NetworkId        string     `json:"networkId,omitempty"`
+	Ip               netip.Addr `json:"ip"`
+	SecurityGroupIds []string   `json:"securityGroupIds,omitempty"`
+	NicSecurity      bool       `json:"nicSecurity"`
+	// External interfaces are outside of STACKIT, so no security groups are applied to them
+	External bool `json:"external"`
+}
+
+// Endpoint is one side of the traffic, such as a server with its NICs
+type Endpoint struct {
+	Label      string      `json:"label"`
+	Interfaces []Interface `json:"interfaces"`
+}
+
+// Verdict is the result of evaluating the security groups of an interface for one direction
+type Verdict struct {
+	Allowed           bool   `json:"allowed"`
+	SecurityGroupId   string `json:"securityGroupId,omitempty"`
+	SecurityGroupName string `json:"securityGroupName,omitempty"`
+	RuleId            string `json:"ruleId,omitempty"`
+	Reason            string `json:"reason"`
+}
+
+// Path is the result of the analysis between two interfaces
+type Path struct {
+	FromNicId string     `json:"fromNicId,omitempty"`
+	FromIp    netip.Addr `json:"fromIp"`
+	ToNicId   string     `json:"toNicId,omitempty"`
+	ToIp      netip.Addr `json:"toIp"`
+	Allowed   bool       `json:"allowed"`
+	Egress    Verdict    `json:"egress"`
+	Ingress   Verdict    `json:"ingress"`
+	Details   string     `json:"details,omitempty"`
+}
+
+// Result is the result of the analysis between two endpoints.
+// The traffic is allowed if it's allowed on at least one path.
+type Result struct {
+	From    string  `json:"from"`
+	To      string  `json:"to"`
+	Traffic Traffic `json:"traffic"`
+	Allowed bool    `json:"allowed"`
+	Paths   []Path  `json:"paths"`
+}
+
+// ParseTraffic reads traffic in the format PORT/PROTOCOL, PORT (for TCP) or PROTOCOL, e.g. "5432/tcp", "53/udp" or "icmp"
+func ParseTraffic(value string) (Traffic, error) {
+	portStr, protocol, found := strings.Cut(value, "/")
+	if !found {
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			portStr, protocol = value, "tcp"
+		} else {
+			portStr, protocol = "", value
+		}
+	}
+	protocol = strings.ToLower(protocol)
+
+	number, err := rulefile.ProtocolNumber(protocol)
+	if err != nil {
+		return Traffic{}, err
+	}
+	traffic := Traffic{Protocol: protocol, number: number}
+
+	if portStr == "" {
+		if slices.Contains(portProtocols, number) {
+			return Traffic{}, fmt.Errorf("a port is required for protocol %q", protocol)
+		}
+		return traffic, nil
+	}
+	if !slices.Contains(portProtocols, number) {
+		return Traffic{}, fmt.Errorf("protocol %q has no ports", protocol)
+	}
+	port, err := strconv.ParseInt(portStr, 10, 64)
+	if err != nil || port < 1 || port > 65535 {
+		return Traffic{}, fmt.Errorf("port %q is invalid", portStr)
+	}
+	traffic.Port = utils.Ptr(port)
+	return traffic, nil
+}
+
+// String returns the traffic in the format read by ParseTraffic
+func (t Traffic) String() string {
+	if t.Port == nil {
+		return t.Protocol
+	}
+	return fmt.Sprintf("%d/%s", *t.Port, t.Protocol)
+}
+
+// InterfacesFromNICs returns the interfaces of the IP addresses of the NICs
+func InterfacesFromNICs(nics []iaas.NIC) []Interface {
+	interfaces := []Interface{}
+	for i := range nics {
+		nic := nics[i]
+		for _, ipStr := range []string{utils.PtrString(nic.Ipv4), utils.PtrString(nic.Ipv6)} {
+			ip, err := netip.ParseAddr(ipStr)
+			if err != nil {
+				continue
+			}
+			interfaces = append(interfaces, Interface{
+				NicId:            utils.PtrString(nic.Id),
+				NetworkId:        utils.PtrString(nic.NetworkId),
+				Ip:               ip,
+				SecurityGroupIds: utils.PtrValue(nic.SecurityGroups),
+				NicSecurity:      utils.PtrValue(nic.NicSecurity),
+			})
+		}
+	}
+	return interfaces
+}
+
+// Analyze evaluates the egress rules of the source and the ingress rules of the destination for each pair of
+// interfaces with the same IP version. Security groups are looked up by ID in securityGroups.
+func Analyze(from, to Endpoint, traffic Traffic, securityGroups map[string]iaas.SecurityGroup) Result {
+	if traffic.number == 0 {
+		traffic.number, _ = rulefile.ProtocolNumber(traffic.Protocol)
+	}
+
+	result := Result{
+		From:    from.Label,
+		To:      to.Label,
+		Traffic: traffic,
+		Paths:   []Path{},
+	}
+	for _, src := range from.Interfaces {
+		for _, dst := range to.Interfaces {
+			if src.Ip.Is4() != dst.Ip.Is4() {
+				continue
+			}
+			path := Path{
+				FromNicId: src.NicId,
+				FromIp:    src.Ip,
+				ToNicId:   dst.NicId,
+				ToIp:      dst.Ip,
+				Egress:    evaluate(&src, &dst, rulefile.DirectionEgress, traffic, securityGroups),
+				Ingress:   evaluate(&dst, &src, rulefile.DirectionIngress, traffic, securityGroups),
+			}
+			path.Allowed = path.Egress.Allowed && path.Ingress.Allowed
+			if !src.External && !dst.External && src.NetworkId != dst.NetworkId {
+				path.Details = "interfaces are in different networks, routing between them is not evaluated"
+			}
+			result.Allowed = result.Allowed || path.Allowed
+			result.Paths = append(result.Paths, path)
+		}
+	}
+	return result
+}
+
+// evaluate returns the first rule of the security groups of the interface which matches the traffic with the remote interface
+func evaluate(iface, remote *Interface, direction string, traffic Traffic, securityGroups map[string]iaas.SecurityGroup) Verdict {
+	if iface.External {
+		return Verdict{Allowed: true, Reason: "not evaluated, the endpoint is outside of STACKIT"}
+	}
+	if !iface.NicSecurity {
+		return Verdict{Allowed: true, Reason: "NIC security is disabled"}
+	}
+	if len(iface.SecurityGroupIds) == 0 {
+		return Verdict{Allowed: false, Reason: "NIC has no security groups"}
+	}
+
+	names := []string{}
+	for _, id := range iface.SecurityGroupIds {
+		securityGroup, ok := securityGroups[id]
+		if !ok {
+			names = append(names, id)
+			continue
+		}
+		name := utils.PtrString(securityGroup.Name)
+		names = append(names, fmt.Sprintf("%q", name))
+		for _, rule := range utils.PtrValue(securityGroup.Rules) {
+			if !matches(&rule, direction, remote, traffic) {
+				continue
+			}
+			return Verdict{
+				Allowed:           true,
+				SecurityGroupId:   id,
+				SecurityGroupName: name,
+				RuleId:            utils.PtrString(rule.Id),
+				Reason:            fmt.Sprintf("allowed by rule %s of security group %q", describeRule(&rule), name),
+			}
+		}
+	}
+	return Verdict{
+		Allowed: false,
+		Reason:  fmt.Sprintf("no %s rule of security groups %s matches", direction, strings.Join(names, ", ")),
+	}
+}
+
+func matches(securityGroupRule *iaas.SecurityGroupRule, direction string, remote *Interface, traffic Traffic) bool {
+	rule := rulefile.FromSecurityGroupRule(securityGroupRule)
+	if rule.Direction != direction {
+		return false
+	}
+
+	ethertype := rule.Ethertype
+	if ethertype == "" {
+		ethertype = "IPv4"
+	}
+	if strings.EqualFold(ethertype, "IPv6") == remote.Ip.Is4() {
+		return false
+	}
+
+	if rule.Protocol != "" {
+		number, err := rulefile.ProtocolNumber(rule.Protocol)
+		if err != nil || number != traffic.number {
+			return false
+		}
+	}
+
+	if rule.PortRange != nil && traffic.Port != nil {
+		if *traffic.Port < rule.PortRange.Min || *traffic.Port > rule.PortRange.Max {
+			return false
+		}
+	}
+
+	if rule.IcmpParameters != nil {
+		switch traffic.number {
+		case protocolICMP:
+			if rule.IcmpParameters.Type != icmpEchoRequest {
+				return false
+			}
+		case protocolICMPv6:
+			if rule.IcmpParameters.Type != icmpv6EchoRequest {
+				return false
+			}
+		}
+	}
+
+	switch {
+	case rule.IpRange != "":
+		prefix, err := netip.ParsePrefix(rule.IpRange)
+		if err != nil || !prefix.Contains(remote.Ip) {
+			return false
+		}
+	case rule.RemoteSecurityGroupId != "":
+		if remote.External || !slices.Contains(remote.SecurityGroupIds, rule.RemoteSecurityGroupId) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeRule(securityGroupRule *iaas.SecurityGroupRule) string {
+	rule := rulefile.FromSecurityGroupRule(securityGroupRule)
+	parts := []string{}
+	if id := utils.PtrString(securityGroupRule.Id); id != "" {
+		parts = append(parts, id)
+	}
+	protocol := rule.Protocol
+	if protocol == "" {
+		protocol = "any protocol"
+	}
+	if rule.PortRange != nil {
+		protocol = fmt.Sprintf("%s %d-%d", protocol, rule.PortRange.Min, rule.PortRange.Max)
+	}
+	parts = append(parts, protocol)
+	switch {
+	case rule.IpRange != "":
+		parts = append(parts, rule.IpRange)
+	case rule.RemoteSecurityGroupId != "":
+		parts = append(parts, "remote security group "+rule.RemoteSecurityGroupId)
+	}
+	return fmt.Sprintf("(%s)", strings.Join(parts, ", "))
+}
@@ -0,0 +1,283 @@
+package reachability
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	webSecurityGroupId = "web-sg"
+	dbSecurityGroupId  = "db-sg"
+	networkId          = "network"
+)
+
+func fixtureSecurityGroups() map[string]iaas.SecurityGroup {
+	return map[string]iaas.SecurityGroup{
+		webSecurityGroupId: {
+			Id:   utils.Ptr(webSecurityGroupId),
+			Name: utils.Ptr("web"),
+			Rules: &[]iaas.SecurityGroupRule{
+				{Id: utils.Ptr("web-egress"), Direction: utils.Ptr("egress"), Ethertype: utils.Ptr("IPv4")},
+				{
+					Id:        utils.Ptr("web-https"),
+					Direction: utils.Ptr("ingress"),
+					Ethertype: utils.Ptr("IPv4"),
+					Protocol:  &iaas.Protocol{Name: utils.Ptr("tcp"), Number: utils.Ptr(int64(6))},
+					PortRange: &iaas.PortRange{Min: utils.Ptr(int64(443)), Max: utils.Ptr(int64(443))},
+					IpRange:   utils.Ptr("0.0.0.0/0"),
+				},
+			},
+		},
+		dbSecurityGroupId: {
+			Id:   utils.Ptr(dbSecurityGroupId),
+			Name: utils.Ptr("db"),
+			Rules: &[]iaas.SecurityGroupRule{
+				{
+					Id:                    utils.Ptr("db-postgres"),
+					Direction:             utils.Ptr("ingress"),
+					Ethertype:             utils.Ptr("IPv4"),
+					Protocol:              &iaas.Protocol{Name: utils.Ptr("tcp")},
+					PortRange:             &iaas.PortRange{Min: utils.Ptr(int64(5432)), Max: utils.Ptr(int64(5432))},
+					RemoteSecurityGroupId: utils.Ptr(webSecurityGroupId),
+				},
+				{
+					Id:             utils.Ptr("db-ping"),
+					Direction:      utils.Ptr("ingress"),
+					Ethertype:      utils.Ptr("IPv4"),
+					Protocol:       &iaas.Protocol{Number: utils.Ptr(int64(1))},
+					IcmpParameters: &iaas.ICMPParameters{Type: utils.Ptr(int64(8)), Code: utils.Ptr(int64(0))},
+					IpRange:        utils.Ptr("10.0.0.0/24"),
+				},
+			},
+		},
+	}
+}
+
+func fixtureEndpoint(label, ip string, securityGroupIds ...string) Endpoint {
+	return Endpoint{
+		Label: label,
+		Interfaces: []Interface{
+			{
+				NicId:            label + "-nic",
+				NetworkId:        networkId,
+				Ip:               netip.MustParseAddr(ip),
+				SecurityGroupIds: securityGroupIds,
+				NicSecurity:      true,
+			},
+		},
+	}
+}
+
+func mustParseTraffic(t *testing.T, value string) Traffic {
+	t.Helper()
+	traffic, err := ParseTraffic(value)
+	if err != nil {
+		t.Fatalf("failed to parse traffic %q: %v", value, err)
+	}
+	return traffic
+}
+
+func TestParseTraffic(t *testing.T) {
+	tests := []struct {
+		value            string
+		isValid          bool
+		expectedProtocol string
+		expectedPort     *int64
+	}{
+		{"5432/tcp", true, "tcp", utils.Ptr(int64(5432))},
+		{"53/UDP", true, "udp", utils.Ptr(int64(53))},
+		{"22", true, "tcp", utils.Ptr(int64(22))},
+		{"icmp", true, "icmp", nil},
+		{"tcp", false, "", nil},
+		{"8/icmp", false, "", nil},
+		{"0/tcp", false, "", nil},
+		{"70000/tcp", false, "", nil},
+		{"22/foo", false, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			traffic, err := ParseTraffic(tt.value)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing traffic: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if traffic.Protocol != tt.expectedProtocol {
+				t.Fatalf("expected protocol %q, got %q", tt.expectedProtocol, traffic.Protocol)
+			}
+			diff := cmp.Diff(traffic.Port, tt.expectedPort)
+			if diff != "" {
+				t.Fatalf("Port does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestInterfacesFromNICs(t *testing.T) {
+	nics := []iaas.NIC{
+		{
+			Id:             utils.Ptr("nic"),
+			NetworkId:      utils.Ptr(networkId),
+			Ipv4:           utils.Ptr("10.0.0.5"),
+			Ipv6:           utils.Ptr("2001:db8::5"),
+			SecurityGroups: &[]string{webSecurityGroupId},
+			NicSecurity:    utils.Ptr(true),
+		},
+	}
+	expected := []Interface{
+		{NicId: "nic", NetworkId: networkId, Ip: netip.MustParseAddr("10.0.0.5"), SecurityGroupIds: []string{webSecurityGroupId}, NicSecurity: true},
+		{NicId: "nic", NetworkId: networkId, Ip: netip.MustParseAddr("2001:db8::5"), SecurityGroupIds: []string{webSecurityGroupId}, NicSecurity: true},
+	}
+
+	diff := cmp.Diff(InterfacesFromNICs(nics), expected, cmpopts.EquateComparable(netip.Addr{}))
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	web := fixtureEndpoint("web", "10.0.0.5", webSecurityGroupId)
+	db := fixtureEndpoint("db", "10.0.1.5", dbSecurityGroupId)
+	other := fixtureEndpoint("other", "10.0.0.6", dbSecurityGroupId)
+
+	tests := []struct {
+		description     string
+		from            Endpoint
+		to              Endpoint
+		traffic         string
+		expectedAllowed bool
+		expectedEgress  Verdict
+		expectedIngress Verdict
+	}{
+		{
+			description:     "allowed by remote security group",
+			from:            web,
+			to:              db,
+			traffic:         "5432/tcp",
+			expectedAllowed: true,
+			expectedEgress: Verdict{
+				Allowed: true, SecurityGroupId: webSecurityGroupId, SecurityGroupName: "web", RuleId: "web-egress",
+				Reason: `allowed by rule (web-egress, any protocol) of security group "web"`,
+			},
+			expectedIngress: Verdict{
+				Allowed: true, SecurityGroupId: dbSecurityGroupId, SecurityGroupName: "db", RuleId: "db-postgres",
+				Reason: `allowed by rule (db-postgres, tcp 5432-5432, remote security group web-sg) of security group "db"`,
+			},
+		},
+		{
+			description:     "wrong port",
+			from:            web,
+			to:              db,
+			traffic:         "5433/tcp",
+			expectedAllowed: false,
+			expectedEgress: Verdict{
+				Allowed: true, SecurityGroupId: webSecurityGroupId, SecurityGroupName: "web", RuleId: "web-egress",
+				Reason: `allowed by rule (web-egress, any protocol) of security group "web"`,
+			},
+			expectedIngress: Verdict{Reason: `no ingress rule of security groups "db" matches`},
+		},
+		{
+			description:     "no egress rule",
+			from:            db,
+			to:              web,
+			traffic:         "443/tcp",
+			expectedAllowed: false,
+			expectedEgress:  Verdict{Reason: `no egress rule of security groups "db" matches`},
+			expectedIngress: Verdict{
+				Allowed: true, SecurityGroupId: webSecurityGroupId, SecurityGroupName: "web", RuleId: "web-https",
+				Reason: `allowed by rule (web-https, tcp 443-443, 0.0.0.0/0) of security group "web"`,
+			},
+		},
+		{
+			description:     "icmp allowed by ip range",
+			from:            web,
+			to:              db,
+			traffic:         "icmp",
+			expectedAllowed: true,
+			expectedEgress: Verdict{
+				Allowed: true, SecurityGroupId: webSecurityGroupId, SecurityGroupName: "web", RuleId: "web-egress",
+				Reason: `allowed by rule (web-egress, any protocol) of security group "web"`,
+			},
+			expectedIngress: Verdict{
+				Allowed: true, SecurityGroupId: dbSecurityGroupId, SecurityGroupName: "db", RuleId: "db-ping",
+				Reason: `allowed by rule (db-ping, 1, 10.0.0.0/24) of security group "db"`,
+			},
+		},
+		{
+			description:     "remote security group not matching",
+			from:            other,
+			to:              db,
+			traffic:         "5432/tcp",
+			expectedAllowed: false,
+			expectedEgress:  Verdict{Reason: `no egress rule of security groups "db" matches`},
+			expectedIngress: Verdict{Reason: `no ingress rule of security groups "db" matches`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := Analyze(tt.from, tt.to, mustParseTraffic(t, tt.traffic), fixtureSecurityGroups())
+			if result.Allowed != tt.expectedAllowed {
+				t.Fatalf("expected allowed %t, got %t", tt.expectedAllowed, result.Allowed)
+			}
+			if len(result.Paths) != 1 {
+				t.Fatalf("expected 1 path, got %d", len(result.Paths))
+			}
+			diff := cmp.Diff(result.Paths[0].Egress, tt.expectedEgress)
+			if diff != "" {
+				t.Fatalf("Egress does not match: %s", diff)
+			}
+			diff = cmp.Diff(result.Paths[0].Ingress, tt.expectedIngress)
+			if diff != "" {
+				t.Fatalf("Ingress does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeInterfaces(t *testing.T) {
+	server := Endpoint{
+		Label: "server",
+		Interfaces: []Interface{
+			{NicId: "v4", NetworkId: "a", Ip: netip.MustParseAddr("10.0.0.5"), NicSecurity: false},
+			{NicId: "v6", NetworkId: "a", Ip: netip.MustParseAddr("2001:db8::5"), SecurityGroupIds: []string{webSecurityGroupId}, NicSecurity: true},
+		},
+	}
+	internet := Endpoint{
+		Label:      "internet",
+		Interfaces: []Interface{{Ip: netip.MustParseAddr("203.0.113.1"), External: true}},
+	}
+	noSecurityGroups := Endpoint{
+		Label:      "no-sg",
+		Interfaces: []Interface{{NicId: "nic", NetworkId: "b", Ip: netip.MustParseAddr("10.0.1.5"), NicSecurity: true}},
+	}
+
+	result := Analyze(internet, server, mustParseTraffic(t, "22/tcp"), fixtureSecurityGroups())
+	if !result.Allowed || len(result.Paths) != 1 {
+		t.Fatalf("expected 1 allowed path, got %+v", result)
+	}
+	if result.Paths[0].Ingress.Reason != "NIC security is disabled" {
+		t.Fatalf("unexpected ingress verdict: %+v", result.Paths[0].Ingress)
+	}
+
+	result = Analyze(server, noSecurityGroups, mustParseTraffic(t, "22/tcp"), fixtureSecurityGroups())
+	if result.Allowed || len(result.Paths) != 1 {
+		t.Fatalf("expected 1 blocked path, got %+v", result)
+	}
+	if result.Paths[0].Ingress.Reason != "NIC has no security groups" {
+		t.Fatalf("unexpected ingress verdict: %+v", result.Paths[0].Ingress)
+	}
+	if result.Paths[0].Details == "" {
+		t.Fatalf("expected details about different networks")
+	}
+}
@@ -0,0 +1,280 @@
+package rulefile
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const (
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+
+	defaultEthertype = "IPv4"
+	anyProtocol      = "any"
+)
+
+// Protocol numbers of the protocol names supported by the API, see https://www.iana.org/assignments/protocol-numbers
+var protocolNumbers = map[string]int64{
+	"icmp":       1,
+	"igmp":       2,
+	"ipip":       4,
+	"tcp":        6,
+	"egp":        8,
+	"udp":        17,
+	"dccp":       33,
+	"ipv6-encap": 41,
+	"ipv6-route": 43,
+	"ipv6-frag":  44,
+	"rsvp":       46,
+	"gre":        47,
+	"esp":        50,
+	"ah":         51,
+	"ipv6-icmp":  58,
+	"ipv6-nonxt": 59,
+	"ipv6-opts":  60,
+	"ospf":       89,
+	"vrrp":       112,
+	"pgm":        113,
+	"sctp":       132,
+	"udplite":    136,
+}
+
+// File is the format of a file with security group rules
+type File struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule is a security group rule, without the fields set by the API
+type Rule struct {
+	Description           string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Direction             string          `json:"direction" yaml:"direction"`
+	Ethertype             string          `json:"ethertype,omitempty" yaml:"ethertype,omitempty"`
+	Protocol              string          `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	PortRange             *PortRange      `json:"portRange,omitempty" yaml:"portRange,omitempty"`
+	IcmpParameters        *IcmpParameters `json:"icmpParameters,omitempty" yaml:"icmpParameters,omitempty"`
+	IpRange               string          `json:"ipRange,omitempty" yaml:"ipRange,omitempty"`
+	RemoteSecurityGroupId string          `json:"remoteSecurityGroupId,omitempty" yaml:"remoteSecurityGroupId,omitempty"`
+}
+
+type PortRange struct {
+	Min int64 `json:"min" yaml:"min"`
+	Max int64 `json:"max" yaml:"max"`
+}
+
+type IcmpParameters struct {
+	Type int64 `json:"type" yaml:"type"`
+	Code int64 `json:"code" yaml:"code"`
+}
+
+// Parse reads the rules of a YAML or JSON file and validates them
+func Parse(content []byte) ([]Rule, error) {
+	var file File
+	err := yaml.UnmarshalWithOptions(content, &file, yaml.Strict())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range file.Rules {
+		err = file.Rules[i].validate()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// Format writes the rules in the YAML format read by Parse
+func Format(rules []Rule) ([]byte, error) {
+	return yaml.MarshalWithOptions(File{Rules: rules}, yaml.IndentSequence(true))
+}
+
+func (r *Rule) validate() error {
+	if r.Direction != DirectionIngress && r.Direction != DirectionEgress {
+		return fmt.Errorf("direction must be %q or %q", DirectionIngress, DirectionEgress)
+	}
+	if r.Protocol != "" {
+		if _, err := ProtocolNumber(r.Protocol); err != nil {
+			return err
+		}
+	}
+	if r.PortRange != nil && (r.PortRange.Min < 1 || r.PortRange.Max > 65535 || r.PortRange.Min > r.PortRange.Max) {
+		return fmt.Errorf("port range %d-%d is invalid", r.PortRange.Min, r.PortRange.Max)
+	}
+	if r.IpRange != "" {
+		if _, err := netip.ParsePrefix(r.IpRange); err != nil {
+			return fmt.Errorf("ip range %q is invalid: %w", r.IpRange, err)
+		}
+	}
+	if r.IpRange != "" && r.RemoteSecurityGroupId != "" {
+		return fmt.Errorf("ip range and remote security group ID can't be set together")
+	}
+	return nil
+}
+
+// FromSecurityGroupRule returns the rule of a security group rule returned by the API
+func FromSecurityGroupRule(rule *iaas.SecurityGroupRule) Rule {
+	r := Rule{
+		Description:           utils.PtrString(rule.Description),
+		Direction:             utils.PtrString(rule.Direction),
+		Ethertype:             utils.PtrString(rule.Ethertype),
+		IpRange:               utils.PtrString(rule.IpRange),
+		RemoteSecurityGroupId: utils.PtrString(rule.RemoteSecurityGroupId),
+	}
+	if rule.Protocol != nil {
+		switch {
+		case rule.Protocol.Name != nil:
+			r.Protocol = *rule.Protocol.Name
+		case rule.Protocol.Number != nil:
+			r.Protocol = strconv.FormatInt(*rule.Protocol.Number, 10)
+		}
+	}
+	if rule.PortRange != nil {
+		r.PortRange = &PortRange{
+			Min: utils.PtrValue(rule.PortRange.Min),
+			Max: utils.PtrValue(rule.PortRange.Max),
+		}
+	}
+	if rule.IcmpParameters != nil {
+		r.IcmpParameters = &IcmpParameters{
+			Type: utils.PtrValue(rule.IcmpParameters.Type),
+			Code: utils.PtrValue(rule.IcmpParameters.Code),
+		}
+	}
+	return r
+}
+
+// CreatePayload returns the payload to create the rule
+func (r *Rule) CreatePayload() iaas.CreateSecurityGroupRulePayload {
+	payload := iaas.CreateSecurityGroupRulePayload{
+		Direction: utils.Ptr(r.Direction),
+	}
+	if r.Description != "" {
+		payload.Description = utils.Ptr(r.Description)
+	}
+	if r.Ethertype != "" {
+		payload.Ethertype = utils.Ptr(r.Ethertype)
+	}
+	if r.IpRange != "" {
+		payload.IpRange = utils.Ptr(r.IpRange)
+	}
+	if r.RemoteSecurityGroupId != "" {
+		payload.RemoteSecurityGroupId = utils.Ptr(r.RemoteSecurityGroupId)
+	}
+	if r.Protocol != "" {
+		if number, err := strconv.ParseInt(r.Protocol, 10, 64); err == nil {
+			payload.Protocol = &iaas.CreateProtocol{Int64: utils.Ptr(number)}
+		} else {
+			payload.Protocol = &iaas.CreateProtocol{String: utils.Ptr(strings.ToLower(r.Protocol))}
+		}
+	}
+	if r.PortRange != nil {
+		payload.PortRange = &iaas.PortRange{
+			Min: utils.Ptr(r.PortRange.Min),
+			Max: utils.Ptr(r.PortRange.Max),
+		}
+	}
+	if r.IcmpParameters != nil {
+		payload.IcmpParameters = &iaas.ICMPParameters{
+			Type: utils.Ptr(r.IcmpParameters.Type),
+			Code: utils.Ptr(r.IcmpParameters.Code),
+		}
+	}
+	return payload
+}
+
+// Key returns the semantic fields of the rule, so that rules with the same key match the same traffic.
+// The description is not part of the key, as it doesn't change what the rule matches.
+func (r *Rule) Key() string {
+	ethertype := r.Ethertype
+	if ethertype == "" {
+		ethertype = defaultEthertype
+	}
+
+	protocol := anyProtocol
+	if r.Protocol != "" {
+		protocol = strings.ToLower(r.Protocol)
+		if number, err := ProtocolNumber(r.Protocol); err == nil {
+			protocol = strconv.FormatInt(number, 10)
+		}
+	}
+
+	portRange := ""
+	if r.PortRange != nil {
+		portRange = fmt.Sprintf("%d-%d", r.PortRange.Min, r.PortRange.Max)
+	}
+
+	icmpParameters := ""
+	if r.IcmpParameters != nil {
+		icmpParameters = fmt.Sprintf("%d/%d", r.IcmpParameters.Type, r.IcmpParameters.Code)
+	}
+
+	ipRange := r.IpRange
+	if prefix, err := netip.ParsePrefix(r.IpRange); err == nil {
+		ipRange = prefix.Masked().String()
+	}
+
+	return strings.Join([]string{
+		strings.ToLower(r.Direction),
+		strings.ToLower(ethertype),
+		protocol,
+		portRange,
+		icmpParameters,
+		ipRange,
+		strings.ToLower(r.RemoteSecurityGroupId),
+	}, "|")
+}
+
+// Plan returns the rules to create and the existing rules to delete, so that the existing rules match the desired ones.
+// Rules are compared by their semantic fields, see Rule.Key, and duplicated existing rules are deleted.
+func Plan(desired []Rule, existing []iaas.SecurityGroupRule) (toCreate []Rule, toDelete []iaas.SecurityGroupRule) {
+	desiredKeys := map[string]bool{}
+	for i := range desired {
+		desiredKeys[desired[i].Key()] = true
+	}
+
+	existingKeys := map[string]bool{}
+	toDelete = []iaas.SecurityGroupRule{}
+	for i := range existing {
+		rule := FromSecurityGroupRule(&existing[i])
+		key := rule.Key()
+		if !desiredKeys[key] || existingKeys[key] {
+			toDelete = append(toDelete, existing[i])
+			continue
+		}
+		existingKeys[key] = true
+	}
+
+	toCreate = []Rule{}
+	for i := range desired {
+		key := desired[i].Key()
+		if existingKeys[key] {
+			continue
+		}
+		// Avoid creating rules defined more than once
+		existingKeys[key] = true
+		toCreate = append(toCreate, desired[i])
+	}
+	return toCreate, toDelete
+}
+
+// ProtocolNumber returns the number of a protocol given by name or number
+func ProtocolNumber(protocol string) (int64, error) {
+	if number, ok := protocolNumbers[strings.ToLower(protocol)]; ok {
+		return number, nil
+	}
+	number, err := strconv.ParseInt(protocol, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("protocol %q is not supported", protocol)
+	}
+	if number < 0 || number > 255 {
+		return 0, fmt.Errorf("protocol number %d is invalid", number)
+	}
+	return number, nil
+}
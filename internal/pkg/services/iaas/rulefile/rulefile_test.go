@@ -0,0 +1,313 @@
+package rulefile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+const testRulesFile = `rules:
+  - description: SSH
+    direction: ingress
+    protocol: tcp
+    portRange:
+      min: 22
+      max: 22
+    ipRange: 10.0.0.0/8
+  - direction: egress
+    ethertype: IPv6
+    protocol: 58
+    icmpParameters:
+      type: 128
+      code: 0
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		content     string
+		isValid     bool
+		expected    []Rule
+	}{
+		{
+			description: "base",
+			content:     testRulesFile,
+			isValid:     true,
+			expected: []Rule{
+				{
+					Description: "SSH",
+					Direction:   DirectionIngress,
+					Protocol:    "tcp",
+					PortRange:   &PortRange{Min: 22, Max: 22},
+					IpRange:     "10.0.0.0/8",
+				},
+				{
+					Direction:      DirectionEgress,
+					Ethertype:      "IPv6",
+					Protocol:       "58",
+					IcmpParameters: &IcmpParameters{Type: 128, Code: 0},
+				},
+			},
+		},
+		{
+			description: "json",
+			content:     `{"rules": [{"direction": "egress"}]}`,
+			isValid:     true,
+			expected:    []Rule{{Direction: DirectionEgress}},
+		},
+		{
+			description: "invalid direction",
+			content:     "rules:\n  - direction: inbound\n",
+			isValid:     false,
+		},
+		{
+			description: "unknown protocol",
+			content:     "rules:\n  - direction: ingress\n    protocol: foo\n",
+			isValid:     false,
+		},
+		{
+			description: "invalid protocol number",
+			content:     "rules:\n  - direction: ingress\n    protocol: 256\n",
+			isValid:     false,
+		},
+		{
+			description: "invalid port range",
+			content:     "rules:\n  - direction: ingress\n    portRange:\n      min: 443\n      max: 80\n",
+			isValid:     false,
+		},
+		{
+			description: "invalid ip range",
+			content:     "rules:\n  - direction: ingress\n    ipRange: 10.0.0.0\n",
+			isValid:     false,
+		},
+		{
+			description: "ip range and remote security group",
+			content:     "rules:\n  - direction: ingress\n    ipRange: 10.0.0.0/8\n    remoteSecurityGroupId: xxx\n",
+			isValid:     false,
+		},
+		{
+			description: "unknown field",
+			content:     "rules:\n  - direction: ingress\n    port: 22\n",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			rules, err := Parse([]byte(tt.content))
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing rules: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(rules, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	rules := []Rule{
+		{
+			Description: "SSH",
+			Direction:   DirectionIngress,
+			Protocol:    "tcp",
+			PortRange:   &PortRange{Min: 22, Max: 22},
+			IpRange:     "10.0.0.0/8",
+		},
+	}
+
+	content, err := Format(rules)
+	if err != nil {
+		t.Fatalf("error formatting rules: %v", err)
+	}
+	parsed, err := Parse(content)
+	if err != nil {
+		t.Fatalf("error parsing formatted rules: %v", err)
+	}
+	diff := cmp.Diff(parsed, rules)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestFromSecurityGroupRule(t *testing.T) {
+	rule := &iaas.SecurityGroupRule{
+		Id:              utils.Ptr("rule-id"),
+		SecurityGroupId: utils.Ptr("sg-id"),
+		Description:     utils.Ptr("HTTPS"),
+		Direction:       utils.Ptr(DirectionIngress),
+		Ethertype:       utils.Ptr("IPv4"),
+		Protocol:        &iaas.Protocol{Name: utils.Ptr("tcp"), Number: utils.Ptr(int64(6))},
+		PortRange:       &iaas.PortRange{Min: utils.Ptr(int64(443)), Max: utils.Ptr(int64(443))},
+		IpRange:         utils.Ptr("0.0.0.0/0"),
+	}
+	expected := Rule{
+		Description: "HTTPS",
+		Direction:   DirectionIngress,
+		Ethertype:   "IPv4",
+		Protocol:    "tcp",
+		PortRange:   &PortRange{Min: 443, Max: 443},
+		IpRange:     "0.0.0.0/0",
+	}
+
+	diff := cmp.Diff(FromSecurityGroupRule(rule), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestCreatePayload(t *testing.T) {
+	tests := []struct {
+		description string
+		rule        Rule
+		expected    iaas.CreateSecurityGroupRulePayload
+	}{
+		{
+			description: "protocol name",
+			rule: Rule{
+				Description: "SSH",
+				Direction:   DirectionIngress,
+				Protocol:    "TCP",
+				PortRange:   &PortRange{Min: 22, Max: 22},
+				IpRange:     "10.0.0.0/8",
+			},
+			expected: iaas.CreateSecurityGroupRulePayload{
+				Description: utils.Ptr("SSH"),
+				Direction:   utils.Ptr(DirectionIngress),
+				Protocol:    &iaas.CreateProtocol{String: utils.Ptr("tcp")},
+				PortRange:   &iaas.PortRange{Min: utils.Ptr(int64(22)), Max: utils.Ptr(int64(22))},
+				IpRange:     utils.Ptr("10.0.0.0/8"),
+			},
+		},
+		{
+			description: "protocol number",
+			rule: Rule{
+				Direction:             DirectionEgress,
+				Ethertype:             "IPv6",
+				Protocol:              "58",
+				IcmpParameters:        &IcmpParameters{Type: 128},
+				RemoteSecurityGroupId: "sg-id",
+			},
+			expected: iaas.CreateSecurityGroupRulePayload{
+				Direction:             utils.Ptr(DirectionEgress),
+				Ethertype:             utils.Ptr("IPv6"),
+				Protocol:              &iaas.CreateProtocol{Int64: utils.Ptr(int64(58))},
+				IcmpParameters:        &iaas.ICMPParameters{Type: utils.Ptr(int64(128)), Code: utils.Ptr(int64(0))},
+				RemoteSecurityGroupId: utils.Ptr("sg-id"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			diff := cmp.Diff(tt.rule.CreatePayload(), tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		description string
+		a           Rule
+		b           Rule
+		equal       bool
+	}{
+		{
+			description: "protocol name and number",
+			a:           Rule{Direction: DirectionIngress, Protocol: "tcp"},
+			b:           Rule{Direction: DirectionIngress, Protocol: "6"},
+			equal:       true,
+		},
+		{
+			description: "default ethertype",
+			a:           Rule{Direction: DirectionIngress},
+			b:           Rule{Direction: DirectionIngress, Ethertype: "IPv4"},
+			equal:       true,
+		},
+		{
+			description: "unmasked ip range",
+			a:           Rule{Direction: DirectionIngress, IpRange: "10.1.2.3/8"},
+			b:           Rule{Direction: DirectionIngress, IpRange: "10.0.0.0/8"},
+			equal:       true,
+		},
+		{
+			description: "different description",
+			a:           Rule{Direction: DirectionIngress, Description: "a"},
+			b:           Rule{Direction: DirectionIngress, Description: "b"},
+			equal:       true,
+		},
+		{
+			description: "different direction",
+			a:           Rule{Direction: DirectionIngress},
+			b:           Rule{Direction: DirectionEgress},
+			equal:       false,
+		},
+		{
+			description: "different port range",
+			a:           Rule{Direction: DirectionIngress, Protocol: "tcp", PortRange: &PortRange{Min: 22, Max: 22}},
+			b:           Rule{Direction: DirectionIngress, Protocol: "tcp", PortRange: &PortRange{Min: 22, Max: 23}},
+			equal:       false,
+		},
+		{
+			description: "any protocol",
+			a:           Rule{Direction: DirectionIngress},
+			b:           Rule{Direction: DirectionIngress, Protocol: "tcp"},
+			equal:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			equal := tt.a.Key() == tt.b.Key()
+			if equal != tt.equal {
+				t.Fatalf("expected keys %q and %q to be equal: %t", tt.a.Key(), tt.b.Key(), tt.equal)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	ssh := iaas.SecurityGroupRule{
+		Id:        utils.Ptr("ssh"),
+		Direction: utils.Ptr(DirectionIngress),
+		Ethertype: utils.Ptr("IPv4"),
+		Protocol:  &iaas.Protocol{Name: utils.Ptr("tcp"), Number: utils.Ptr(int64(6))},
+		PortRange: &iaas.PortRange{Min: utils.Ptr(int64(22)), Max: utils.Ptr(int64(22))},
+	}
+	sshDuplicate := ssh
+	sshDuplicate.Id = utils.Ptr("ssh-duplicate")
+	egress := iaas.SecurityGroupRule{
+		Id:        utils.Ptr("egress"),
+		Direction: utils.Ptr(DirectionEgress),
+		Ethertype: utils.Ptr("IPv4"),
+	}
+
+	desired := []Rule{
+		{Direction: DirectionIngress, Protocol: "6", PortRange: &PortRange{Min: 22, Max: 22}, Description: "SSH"},
+		{Direction: DirectionIngress, Protocol: "tcp", PortRange: &PortRange{Min: 443, Max: 443}},
+		{Direction: DirectionIngress, Protocol: "TCP", PortRange: &PortRange{Min: 443, Max: 443}},
+	}
+
+	toCreate, toDelete := Plan(desired, []iaas.SecurityGroupRule{ssh, sshDuplicate, egress})
+
+	diff := cmp.Diff(toCreate, []Rule{desired[1]})
+	if diff != "" {
+		t.Fatalf("Rules to create do not match: %s", diff)
+	}
+	diff = cmp.Diff(toDelete, []iaas.SecurityGroupRule{sshDuplicate, egress})
+	if diff != "" {
+		t.Fatalf("Rules to delete do not match: %s", diff)
+	}
+}
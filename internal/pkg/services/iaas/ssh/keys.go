@@ -0,0 +1,128 @@
+package ssh
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	cryptoSsh "golang.org/x/crypto/ssh"
+)
+
+const (
+	KeyTypeEd25519 = "ed25519"
+	KeyTypeRsa4096 = "rsa-4096"
+
+	rsaKeyBits = 4096
+)
+
+// KeyTypes are the key types supported by GenerateKey
+var KeyTypes = []string{KeyTypeEd25519, KeyTypeRsa4096}
+
+// GeneratedKey is a locally generated key pair
+type GeneratedKey struct {
+	// PrivateKey is the private key in the OpenSSH format, encrypted if a passphrase was given
+	PrivateKey []byte
+	// PublicKey is the public key in the authorized_keys format, without a trailing newline
+	PublicKey string
+}
+
+// GenerateKey generates a key pair of the given type. The comment is appended to the public key, if set.
+func GenerateKey(keyType, comment string, passphrase []byte) (*GeneratedKey, error) {
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+	switch keyType {
+	case KeyTypeEd25519:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ed25519 key: %w", err)
+		}
+		privateKey, publicKey = private, public
+	case KeyTypeRsa4096:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("generate rsa key: %w", err)
+		}
+		privateKey, publicKey = private, &private.PublicKey
+	default:
+		return nil, fmt.Errorf("key type %q is not supported, use one of %s", keyType, strings.Join(KeyTypes, ", "))
+	}
+
+	var block *pem.Block
+	var err error
+	if len(passphrase) > 0 {
+		block, err = cryptoSsh.MarshalPrivateKeyWithPassphrase(privateKey, comment, passphrase)
+	} else {
+		block, err = cryptoSsh.MarshalPrivateKey(privateKey, comment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key: %w", err)
+	}
+
+	sshPublicKey, err := cryptoSsh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("convert public key: %w", err)
+	}
+	authorizedKey := strings.TrimSuffix(string(cryptoSsh.MarshalAuthorizedKey(sshPublicKey)), "\n")
+	if comment != "" {
+		authorizedKey = fmt.Sprintf("%s %s", authorizedKey, comment)
+	}
+
+	return &GeneratedKey{
+		PrivateKey: pem.EncodeToMemory(block),
+		PublicKey:  authorizedKey,
+	}, nil
+}
+
+// CheckKeyFiles returns an error if the private key file or its public key file next to it already exists
+func CheckKeyFiles(path string) error {
+	path = expandHome(path)
+	for _, p := range []string{path, path + ".pub"} {
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("file %q already exists", p)
+		}
+	}
+	return nil
+}
+
+// WriteKeyFiles writes the private key to the given path, readable only by the user,
+// and the public key next to it with the ".pub" suffix, like ssh-keygen does.
+// Existing files are not overwritten.
+func WriteKeyFiles(path string, key *GeneratedKey) error {
+	path = expandHome(path)
+	if err := writeNewFile(path, key.PrivateKey, 0o600); err != nil {
+		return fmt.Errorf("write private key: %w", err)
+	}
+	if err := writeNewFile(path+".pub", []byte(key.PublicKey+"\n"), 0o644); err != nil {
+		return fmt.Errorf("write public key: %w", err)
+	}
+	return nil
+}
+
+func writeNewFile(path string, content []byte, perm os.FileMode) (err error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if inner := file.Close(); inner != nil && err == nil {
+			err = inner
+		}
+	}()
+	_, err = file.Write(content)
+	return err
+}
+
+// Fingerprints returns the SHA256 and MD5 fingerprints of a public key in the authorized_keys format,
+// as shown by "ssh-keygen -l" and "ssh-keygen -l -E md5"
+func Fingerprints(publicKey string) (sha256Fingerprint, md5Fingerprint string, err error) {
+	key, _, _, _, err := cryptoSsh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", "", fmt.Errorf("parse public key: %w", err)
+	}
+	return cryptoSsh.FingerprintSHA256(key), "MD5:" + cryptoSsh.FingerprintLegacyMD5(key), nil
+}
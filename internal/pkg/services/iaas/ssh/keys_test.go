@@ -0,0 +1,179 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cryptoSsh "golang.org/x/crypto/ssh"
+)
+
+func TestGenerateKey(t *testing.T) {
+	tests := []struct {
+		description    string
+		keyType        string
+		comment        string
+		passphrase     []byte
+		isValid        bool
+		expectedPrefix string
+	}{
+		{
+			description:    "ed25519",
+			keyType:        KeyTypeEd25519,
+			comment:        "my-key",
+			isValid:        true,
+			expectedPrefix: "ssh-ed25519 ",
+		},
+		{
+			description:    "ed25519 with passphrase",
+			keyType:        KeyTypeEd25519,
+			passphrase:     []byte("secret"),
+			isValid:        true,
+			expectedPrefix: "ssh-ed25519 ",
+		},
+		{
+			description:    "rsa",
+			keyType:        KeyTypeRsa4096,
+			comment:        "my-key",
+			isValid:        true,
+			expectedPrefix: "ssh-rsa ",
+		},
+		{
+			description: "unsupported type",
+			keyType:     "dsa",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			key, err := GenerateKey(tt.keyType, tt.comment, tt.passphrase)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error generating key: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			if !strings.HasPrefix(key.PublicKey, tt.expectedPrefix) {
+				t.Fatalf("public key %q doesn't start with %q", key.PublicKey, tt.expectedPrefix)
+			}
+			publicKey, comment, _, _, err := cryptoSsh.ParseAuthorizedKey([]byte(key.PublicKey))
+			if err != nil {
+				t.Fatalf("error parsing public key: %v", err)
+			}
+			if comment != tt.comment {
+				t.Fatalf("comment does not match: expected %q, got %q", tt.comment, comment)
+			}
+
+			var signer cryptoSsh.Signer
+			if len(tt.passphrase) > 0 {
+				if _, err := cryptoSsh.ParsePrivateKey(key.PrivateKey); err == nil {
+					t.Fatalf("private key is not encrypted")
+				}
+				signer, err = cryptoSsh.ParsePrivateKeyWithPassphrase(key.PrivateKey, tt.passphrase)
+			} else {
+				signer, err = cryptoSsh.ParsePrivateKey(key.PrivateKey)
+			}
+			if err != nil {
+				t.Fatalf("error parsing private key: %v", err)
+			}
+			if string(signer.PublicKey().Marshal()) != string(publicKey.Marshal()) {
+				t.Fatalf("public key doesn't belong to the private key")
+			}
+		})
+	}
+}
+
+func TestWriteKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "id_test")
+	key := &GeneratedKey{
+		PrivateKey: []byte("private"),
+		PublicKey:  "ssh-ed25519 public",
+	}
+
+	if err := CheckKeyFiles(path); err != nil {
+		t.Fatalf("check of new key files failed: %v", err)
+	}
+	if err := WriteKeyFiles(path, key); err != nil {
+		t.Fatalf("error writing key files: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("error reading private key file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("private key file has permissions %o, expected 600", perm)
+	}
+	content, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		t.Fatalf("error reading public key file: %v", err)
+	}
+	if string(content) != "ssh-ed25519 public\n" {
+		t.Fatalf("public key file content does not match: %q", string(content))
+	}
+
+	if err := CheckKeyFiles(path); err == nil {
+		t.Fatalf("check of existing key files did not fail")
+	}
+	if err := WriteKeyFiles(path, key); err == nil {
+		t.Fatalf("existing key files were overwritten")
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	tests := []struct {
+		description    string
+		publicKey      string
+		isValid        bool
+		expectedSha256 string
+		expectedMd5    string
+	}{
+		{
+			description:    "ed25519",
+			publicKey:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKqsh3YBljguJga+BgZeMju/7TDz3VmfG6iAZF6XlJro test",
+			isValid:        true,
+			expectedSha256: "SHA256:1yYcuc676JMJgxpH5P7L1QJ7s/tCv0eRfHAZdaI22dA",
+			expectedMd5:    "MD5:d1:f5:49:49:9f:6e:63:06:1c:44:92:fb:10:dc:c8:5d",
+		},
+		{
+			description:    "without comment",
+			publicKey:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKqsh3YBljguJga+BgZeMju/7TDz3VmfG6iAZF6XlJro",
+			isValid:        true,
+			expectedSha256: "SHA256:1yYcuc676JMJgxpH5P7L1QJ7s/tCv0eRfHAZdaI22dA",
+			expectedMd5:    "MD5:d1:f5:49:49:9f:6e:63:06:1c:44:92:fb:10:dc:c8:5d",
+		},
+		{
+			description: "invalid",
+			publicKey:   "ssh-ed25519 invalid",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			sha256Fingerprint, md5Fingerprint, err := Fingerprints(tt.publicKey)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error computing fingerprints: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if sha256Fingerprint != tt.expectedSha256 {
+				t.Fatalf("SHA256 fingerprint does not match: expected %q, got %q", tt.expectedSha256, sha256Fingerprint)
+			}
+			if md5Fingerprint != tt.expectedMd5 {
+				t.Fatalf("MD5 fingerprint does not match: expected %q, got %q", tt.expectedMd5, md5Fingerprint)
+			}
+		})
+	}
+}
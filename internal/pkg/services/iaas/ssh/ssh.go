@@ -0,0 +1,224 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+// DefaultKeyDir is searched for private keys named like the key pairs
+const DefaultKeyDir = "~/.ssh"
+
+// Host holds how to connect to a server with ssh
+type Host struct {
+	Alias        string `json:"alias"`
+	ServerId     string `json:"serverId"`
+	HostName     string `json:"hostName"`
+	User         string `json:"user,omitempty"`
+	IdentityFile string `json:"identityFile,omitempty"`
+	// ProxyJump is the host through which the server is reached, if it has no public IP
+	ProxyJump *Host `json:"proxyJump,omitempty"`
+}
+
+// Options holds the settings which apply to all hosts
+type Options struct {
+	User string
+	// IdentityFiles maps key pair names to the paths of the local private keys
+	IdentityFiles map[string]string
+	// KeyDir is searched for a private key named like the key pair, if the key pair has no identity file
+	KeyDir string
+}
+
+// ParseIdentityFiles parses a comma separated list of KEY_PAIR_NAME=PATH pairs
+func ParseIdentityFiles(value string) (map[string]string, error) {
+	identityFiles := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, path, found := strings.Cut(pair, "=")
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid identity file %q, expected KEY_PAIR_NAME=PATH", pair)
+		}
+		identityFiles[name] = path
+	}
+	return identityFiles, nil
+}
+
+// FindServer returns the server with the given ID or name, including its NICs
+func FindServer(ctx context.Context, apiClient *iaas.APIClient, projectId, ref string) (*iaas.Server, error) {
+	if utils.ValidateUUID(ref) == nil {
+		server, err := apiClient.GetServer(ctx, projectId, ref).Details(true).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("get server: %w", err)
+		}
+		return server, nil
+	}
+
+	resp, err := apiClient.ListServers(ctx, projectId).Details(true).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list servers: %w", err)
+	}
+	var found *iaas.Server
+	for i := range utils.PtrValue(resp.Items) {
+		server := &(*resp.Items)[i]
+		if utils.PtrString(server.Name) != ref {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found several servers named %q, use the server ID instead", ref)
+		}
+		found = server
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no server named %q found", ref)
+	}
+	return found, nil
+}
+
+// PublicIp returns the first public IP of the NICs of the server
+func PublicIp(server *iaas.Server) string {
+	for _, nic := range utils.PtrValue(server.Nics) {
+		if ip := utils.PtrString(nic.PublicIp); ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
+// PrivateIp returns the first IPv4 address of the NICs of the server, or the first IPv6 address if there is no IPv4 address
+func PrivateIp(server *iaas.Server) string {
+	for _, nic := range utils.PtrValue(server.Nics) {
+		if ip := utils.PtrString(nic.Ipv4); ip != "" {
+			return ip
+		}
+	}
+	for _, nic := range utils.PtrValue(server.Nics) {
+		if ip := utils.PtrString(nic.Ipv6); ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
+// NewHost returns how to connect to the server.
+// The public IP of the server is used if it has one, otherwise its private IP is reached through the bastion host.
+func NewHost(server *iaas.Server, opts *Options, bastion *Host) (*Host, error) {
+	host := &Host{
+		Alias:        utils.PtrString(server.Name),
+		ServerId:     utils.PtrString(server.Id),
+		User:         opts.User,
+		IdentityFile: opts.identityFile(utils.PtrString(server.KeypairName)),
+	}
+
+	if ip := PublicIp(server); ip != "" {
+		host.HostName = ip
+		return host, nil
+	}
+	if bastion == nil {
+		return nil, fmt.Errorf("server %q has no public IP and no bastion server is configured", host.Alias)
+	}
+	ip := PrivateIp(server)
+	if ip == "" {
+		return nil, fmt.Errorf("server %q has no IP address", host.Alias)
+	}
+	host.HostName = ip
+	host.ProxyJump = bastion
+	return host, nil
+}
+
+func (o *Options) identityFile(keypairName string) string {
+	if keypairName == "" {
+		return ""
+	}
+	if path, ok := o.IdentityFiles[keypairName]; ok {
+		return expandHome(path)
+	}
+	if o.KeyDir == "" {
+		return ""
+	}
+	path := filepath.Join(expandHome(o.KeyDir), keypairName)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// Destination returns the ssh destination of the host, with the user if set
+func (h *Host) Destination() string {
+	if h.User == "" {
+		return h.HostName
+	}
+	return fmt.Sprintf("%s@%s", h.User, h.HostName)
+}
+
+// Args returns the arguments to pass to ssh to connect to the host.
+// The bastion host is used as proxy command, so that its own identity file is used.
+func (h *Host) Args() []string {
+	args := []string{}
+	if h.IdentityFile != "" {
+		args = append(args, "-i", h.IdentityFile)
+	}
+	if h.ProxyJump != nil {
+		proxyCommand := []string{"ssh"}
+		if h.ProxyJump.IdentityFile != "" {
+			proxyCommand = append(proxyCommand, "-i", shellQuote(h.ProxyJump.IdentityFile))
+		}
+		forward := "%h:%p"
+		if ip := net.ParseIP(h.HostName); ip != nil && ip.To4() == nil {
+			// IPv6 addresses must be enclosed in brackets to be separated from the port
+			forward = "[%h]:%p"
+		}
+		proxyCommand = append(proxyCommand, "-W", forward, shellQuote(h.ProxyJump.Destination()))
+		args = append(args, "-o", "ProxyCommand="+strings.Join(proxyCommand, " "))
+	}
+	return append(args, h.Destination())
+}
+
+// Config returns the ssh config blocks for the hosts.
+// Hosts reached through a bastion host refer to it by its alias, so the bastion host must be part of the hosts.
+func Config(hosts []Host) string {
+	var b strings.Builder
+	for i, host := range hosts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "# server %s\n", host.ServerId)
+		fmt.Fprintf(&b, "Host %s\n", host.Alias)
+		fmt.Fprintf(&b, "  HostName %s\n", host.HostName)
+		if host.User != "" {
+			fmt.Fprintf(&b, "  User %s\n", host.User)
+		}
+		if host.IdentityFile != "" {
+			fmt.Fprintf(&b, "  IdentityFile %q\n", host.IdentityFile)
+			b.WriteString("  IdentitiesOnly yes\n")
+		}
+		if host.ProxyJump != nil {
+			fmt.Fprintf(&b, "  ProxyJump %s\n", host.ProxyJump.Alias)
+		}
+	}
+	return b.String()
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,259 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+)
+
+func fixtureServer(name, keypairName string, nics ...iaas.ServerNetwork) *iaas.Server {
+	return &iaas.Server{
+		Id:          utils.Ptr(name + "-id"),
+		Name:        utils.Ptr(name),
+		KeypairName: utils.Ptr(keypairName),
+		Nics:        &nics,
+	}
+}
+
+func TestParseIdentityFiles(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		isValid     bool
+		expected    map[string]string
+	}{
+		{
+			description: "base",
+			value:       "default=~/.ssh/id_ed25519, ops=/keys/ops",
+			isValid:     true,
+			expected:    map[string]string{"default": "~/.ssh/id_ed25519", "ops": "/keys/ops"},
+		},
+		{
+			description: "empty",
+			value:       "",
+			isValid:     true,
+			expected:    map[string]string{},
+		},
+		{
+			description: "missing path",
+			value:       "default=",
+			isValid:     false,
+		},
+		{
+			description: "missing separator",
+			value:       "default",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			identityFiles, err := ParseIdentityFiles(tt.value)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("parse identity files: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(identityFiles, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewHost(t *testing.T) {
+	keyDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(keyDir, "found"), []byte("key"), 0o600)
+	if err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	opts := &Options{
+		User:          "ubuntu",
+		IdentityFiles: map[string]string{"mapped": "/keys/mapped"},
+		KeyDir:        keyDir,
+	}
+	bastion := &Host{Alias: "bastion", HostName: "203.0.113.1", User: "ubuntu"}
+
+	tests := []struct {
+		description string
+		server      *iaas.Server
+		bastion     *Host
+		isValid     bool
+		expected    *Host
+	}{
+		{
+			description: "public ip",
+			server: fixtureServer("web", "mapped",
+				iaas.ServerNetwork{Ipv4: utils.Ptr("10.0.0.5")},
+				iaas.ServerNetwork{Ipv4: utils.Ptr("10.0.1.5"), PublicIp: utils.Ptr("203.0.113.10")},
+			),
+			bastion:  bastion,
+			isValid:  true,
+			expected: &Host{Alias: "web", ServerId: "web-id", HostName: "203.0.113.10", User: "ubuntu", IdentityFile: "/keys/mapped"},
+		},
+		{
+			description: "private ip through bastion",
+			server:      fixtureServer("db", "found", iaas.ServerNetwork{Ipv4: utils.Ptr("10.0.0.6")}),
+			bastion:     bastion,
+			isValid:     true,
+			expected:    &Host{Alias: "db", ServerId: "db-id", HostName: "10.0.0.6", User: "ubuntu", IdentityFile: filepath.Join(keyDir, "found"), ProxyJump: bastion},
+		},
+		{
+			description: "ipv6 only and unknown key pair",
+			server:      fixtureServer("v6", "unknown", iaas.ServerNetwork{Ipv6: utils.Ptr("2001:db8::6")}),
+			bastion:     bastion,
+			isValid:     true,
+			expected:    &Host{Alias: "v6", ServerId: "v6-id", HostName: "2001:db8::6", User: "ubuntu", ProxyJump: bastion},
+		},
+		{
+			description: "no public ip and no bastion",
+			server:      fixtureServer("db", "found", iaas.ServerNetwork{Ipv4: utils.Ptr("10.0.0.6")}),
+			isValid:     false,
+		},
+		{
+			description: "no ip",
+			server:      fixtureServer("db", "found"),
+			bastion:     bastion,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			host, err := NewHost(tt.server, opts, tt.bastion)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("new host: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(host, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestArgs(t *testing.T) {
+	bastion := &Host{Alias: "bastion", HostName: "203.0.113.1", User: "ops", IdentityFile: "/keys/it's"}
+	tests := []struct {
+		description string
+		host        *Host
+		expected    []string
+	}{
+		{
+			description: "public ip without user and key",
+			host:        &Host{HostName: "203.0.113.10"},
+			expected:    []string{"203.0.113.10"},
+		},
+		{
+			description: "through bastion",
+			host:        &Host{HostName: "10.0.0.6", User: "ubuntu", IdentityFile: "/keys/db", ProxyJump: bastion},
+			expected: []string{
+				"-i", "/keys/db",
+				"-o", `ProxyCommand=ssh -i '/keys/it'\''s' -W %h:%p 'ops@203.0.113.1'`,
+				"ubuntu@10.0.0.6",
+			},
+		},
+		{
+			description: "through bastion with ipv6",
+			host:        &Host{HostName: "fd00::6", User: "ubuntu", ProxyJump: bastion},
+			expected: []string{
+				"-o", `ProxyCommand=ssh -i '/keys/it'\''s' -W [%h]:%p 'ops@203.0.113.1'`,
+				"ubuntu@fd00::6",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			diff := cmp.Diff(tt.host.Args(), tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	bastion := Host{Alias: "bastion", ServerId: "b", HostName: "203.0.113.1"}
+	hosts := []Host{
+		bastion,
+		{Alias: "db", ServerId: "d", HostName: "10.0.0.6", User: "ubuntu", IdentityFile: "/keys/db", ProxyJump: &bastion},
+	}
+	expected := `# server b
+Host bastion
+  HostName 203.0.113.1
+
+# server d
+Host db
+  HostName 10.0.0.6
+  User ubuntu
+  IdentityFile "/keys/db"
+  IdentitiesOnly yes
+  ProxyJump bastion
+`
+	diff := cmp.Diff(Config(hosts), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestFindServer(t *testing.T) {
+	projectId := uuid.NewString()
+	serverId := uuid.NewString()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1/projects/%s/servers/%s", projectId, serverId):
+			_, _ = fmt.Fprintf(w, `{"id": %q, "name": "web"}`, serverId)
+		case fmt.Sprintf("/v1/projects/%s/servers", projectId):
+			_, _ = fmt.Fprintf(w, `{"items": [{"id": %q, "name": "web"}, {"id": "a", "name": "dup"}, {"id": "b", "name": "dup"}]}`, serverId)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := iaas.NewAPIClient(sdkConfig.WithEndpoint(server.URL), sdkConfig.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create API client: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, ref := range []string{serverId, "web"} {
+		found, err := FindServer(ctx, apiClient, projectId, ref)
+		if err != nil {
+			t.Fatalf("find server %q: %v", ref, err)
+		}
+		if utils.PtrString(found.Id) != serverId {
+			t.Fatalf("expected server %q, got %q", serverId, utils.PtrString(found.Id))
+		}
+	}
+	for _, ref := range []string{"dup", "unknown"} {
+		_, err := FindServer(ctx, apiClient, projectId, ref)
+		if err == nil {
+			t.Fatalf("expected error for server %q", ref)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package userdata
+
+import (
+	"fmt"
+	"slices"
+)
+
+// check validates the value of a cloud-config key
+type check func(value interface{}) error
+
+// schema holds the top-level keys of the cloud-init schema.
+// Keys with a nil check are accepted with any value.
+var schema = map[string]check{
+	"allow_public_ssh_keys":      isBool,
+	"apk_repos":                  isMapping,
+	"apt":                        isMapping,
+	"apt_pipelining":             nil,
+	"apt_reboot_if_required":     isBool,
+	"apt_update":                 isBool,
+	"apt_upgrade":                isBool,
+	"autoinstall":                isMapping,
+	"bootcmd":                    listOf(isCommand),
+	"byobu_by_default":           isString,
+	"ca-certs":                   isMapping,
+	"ca_certs":                   isMapping,
+	"chef":                       isMapping,
+	"chpasswd":                   isMapping,
+	"cloud_config_modules":       isList,
+	"cloud_final_modules":        isList,
+	"cloud_init_modules":         isList,
+	"create_hostname_file":       isBool,
+	"datasource":                 isMapping,
+	"datasource_list":            listOf(isString),
+	"device_aliases":             isMapping,
+	"disable_ec2_metadata":       isBool,
+	"disable_root":               isBool,
+	"disable_root_opts":          isString,
+	"disk_setup":                 isMapping,
+	"drivers":                    isMapping,
+	"fan":                        isMapping,
+	"final_message":              isString,
+	"fqdn":                       isString,
+	"fs_setup":                   listOf(isMapping),
+	"groups":                     nil,
+	"growpart":                   isMapping,
+	"hostname":                   isString,
+	"keyboard":                   isMapping,
+	"landscape":                  isMapping,
+	"locale":                     nil,
+	"locale_configfile":          isString,
+	"lxd":                        isMapping,
+	"manage_etc_hosts":           nil,
+	"manage_resolv_conf":         isBool,
+	"mcollective":                isMapping,
+	"merge_how":                  nil,
+	"merge_type":                 nil,
+	"mount_default_fields":       isList,
+	"mounts":                     listOf(isList),
+	"no_ssh_fingerprints":        isBool,
+	"ntp":                        isMapping,
+	"output":                     isMapping,
+	"package_reboot_if_required": isBool,
+	"package_update":             isBool,
+	"package_upgrade":            isBool,
+	"packages":                   listOf(isPackage),
+	"password":                   isString,
+	"phone_home":                 isMapping,
+	"power_state":                isMapping,
+	"prefer_fqdn_over_hostname":  isBool,
+	"preserve_hostname":          isBool,
+	"puppet":                     isMapping,
+	"random_seed":                isMapping,
+	"reporting":                  isMapping,
+	"resize_rootfs":              nil,
+	"resolv_conf":                isMapping,
+	"rh_subscription":            isMapping,
+	"rsyslog":                    isMapping,
+	"runcmd":                     listOf(isCommand),
+	"salt_minion":                isMapping,
+	"snap":                       isMapping,
+	"spacewalk":                  isMapping,
+	"ssh":                        isMapping,
+	"ssh_authorized_keys":        listOf(isString),
+	"ssh_deletekeys":             isBool,
+	"ssh_fp_console_blacklist":   listOf(isString),
+	"ssh_genkeytypes":            listOf(isString),
+	"ssh_import_id":              listOf(isString),
+	"ssh_key_console_blacklist":  listOf(isString),
+	"ssh_keys":                   isMapping,
+	"ssh_publish_hostkeys":       isMapping,
+	"ssh_pwauth":                 nil,
+	"ssh_quiet_keygen":           isBool,
+	"ssh_redirect_user":          isBool,
+	"swap":                       isMapping,
+	"system_info":                isMapping,
+	"timezone":                   isString,
+	"ubuntu_advantage":           isMapping,
+	"ubuntu_pro":                 isMapping,
+	"updates":                    isMapping,
+	"user":                       nil,
+	"users":                      nil,
+	"vendor_data":                isMapping,
+	"wireguard":                  isMapping,
+	"write_files":                listOf(isWriteFile),
+	"yum_repo_dir":               isString,
+	"yum_repos":                  isMapping,
+	"zypper":                     isMapping,
+}
+
+// writeFileEncodings holds the encodings cloud-init supports for the content of write_files entries
+var writeFileEncodings = []string{"b64", "base64", "gz", "gzip", "gz+b64", "gz+base64", "gzip+b64", "gzip+base64", "text/plain"}
+
+func isBool(value interface{}) error {
+	if _, ok := value.(bool); !ok {
+		return fmt.Errorf("must be a boolean, got %v", value)
+	}
+	return nil
+}
+
+func isString(value interface{}) error {
+	if _, ok := value.(string); !ok {
+		return fmt.Errorf("must be a string, got %v", value)
+	}
+	return nil
+}
+
+func isList(value interface{}) error {
+	if _, ok := value.([]interface{}); !ok {
+		return fmt.Errorf("must be a list")
+	}
+	return nil
+}
+
+func isMapping(value interface{}) error {
+	if _, ok := value.(map[string]interface{}); !ok {
+		return fmt.Errorf("must be a mapping")
+	}
+	return nil
+}
+
+// listOf returns a check for a list whose items all pass the item check
+func listOf(item check) check {
+	return func(value interface{}) error {
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("must be a list")
+		}
+		for i, v := range list {
+			if err := item(v); err != nil {
+				return fmt.Errorf("item %d: %w", i+1, err)
+			}
+		}
+		return nil
+	}
+}
+
+// isCommand checks a runcmd or bootcmd entry, which is either a shell command or a list of arguments
+func isCommand(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return nil
+	case []interface{}:
+		return listOf(isString)(v)
+	default:
+		return fmt.Errorf("must be a string or a list of strings, got %v", value)
+	}
+}
+
+// isPackage checks a packages entry, which is either a package name or a list of name and version
+func isPackage(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return nil
+	case []interface{}:
+		if len(v) != 2 {
+			return fmt.Errorf("must be a package name or a list of package name and version")
+		}
+		return nil
+	case map[string]interface{}:
+		// Packages grouped by package manager, e.g. "apt" or "snap"
+		return nil
+	default:
+		return fmt.Errorf("must be a package name or a list of package name and version, got %v", value)
+	}
+}
+
+func isWriteFile(value interface{}) error {
+	file, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("must be a mapping")
+	}
+	if path, ok := file["path"].(string); !ok || path == "" {
+		return fmt.Errorf(`"path" is required`)
+	}
+	for key, v := range file {
+		var err error
+		switch key {
+		case "path", "content", "owner":
+			err = isString(v)
+		case "permissions":
+			// Unquoted octal permissions like 0644 are parsed as numbers, cloud-init accepts them too
+			switch v.(type) {
+			case string, int, int64, uint64:
+			default:
+				err = fmt.Errorf("must be a string like \"0644\", got %v", v)
+			}
+		case "append", "defer":
+			err = isBool(v)
+		case "encoding":
+			err = isString(v)
+			if err == nil && !slices.Contains(writeFileEncodings, v.(string)) {
+				err = fmt.Errorf("must be one of %v, got %q", writeFileEncodings, v)
+			}
+		case "source":
+			err = isMapping(v)
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package userdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// MaxEncodedSize is the maximum size of the base64 encoded user data accepted by the API
+	MaxEncodedSize = 65535
+
+	boundary = "==STACKIT-CLI-USER-DATA=="
+)
+
+// Part is a single user data document, e.g. a cloud-config or a shell script
+type Part struct {
+	// Name is the file name of the part, empty if the part was passed inline
+	Name    string
+	Content string
+}
+
+// contentTypes maps the header a part starts with to the MIME type cloud-init expects for it
+var contentTypes = []struct {
+	header      string
+	contentType string
+}{
+	{"#cloud-config-archive", "text/cloud-config-archive"},
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include", "text/x-include-url"},
+	{"#part-handler", "text/part-handler"},
+	{"## template: jinja", "text/jinja2"},
+	{"#!", "text/x-shellscript"},
+}
+
+// ReadParts returns the parts for the values of a user data flag.
+// Values starting with "@" are read from the file with that path, other values are used as they are.
+func ReadParts(values []string) ([]Part, error) {
+	parts := []Part{}
+	for _, value := range values {
+		if !strings.HasPrefix(value, "@") {
+			parts = append(parts, Part{Content: value})
+			continue
+		}
+		path := strings.Trim(value[1:], `"'`)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read user data from file: %w", err)
+		}
+		parts = append(parts, Part{Name: filepath.Base(path), Content: string(content)})
+	}
+	return parts, nil
+}
+
+// Label returns how the part is referred to in messages
+func (p *Part) Label(index int) string {
+	if p.Name != "" {
+		return fmt.Sprintf("%q", p.Name)
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// ContentType returns the MIME type of the part, based on the header it starts with.
+// Returns "" if the type can't be determined.
+func (p *Part) ContentType() string {
+	content := strings.TrimLeft(p.Content, "\r\n")
+	for _, t := range contentTypes {
+		if strings.HasPrefix(content, t.header) {
+			return t.contentType
+		}
+	}
+	return ""
+}
+
+// Render executes the parts as Go templates with the given variables, e.g. "{{ .hostname }}".
+// Parts are returned unchanged if there are no variables, so that scripts containing "{{" don't need escaping.
+func Render(parts []Part, vars map[string]string) ([]Part, error) {
+	if len(vars) == 0 {
+		return parts, nil
+	}
+
+	rendered := make([]Part, 0, len(parts))
+	for i, part := range parts {
+		tmpl, err := template.New(part.Label(i)).Option("missingkey=error").Parse(part.Content)
+		if err != nil {
+			return nil, fmt.Errorf("parse template of user data part %s: %w", part.Label(i), err)
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, vars)
+		if err != nil {
+			return nil, fmt.Errorf("render user data part %s: %w", part.Label(i), err)
+		}
+		rendered = append(rendered, Part{Name: part.Name, Content: buf.String()})
+	}
+	return rendered, nil
+}
+
+// Compose returns the user data for the parts.
+// A single part is returned as it is, several parts are combined into a multipart MIME document.
+func Compose(parts []Part) (string, error) {
+	if len(parts) == 0 {
+		return "", fmt.Errorf("no user data")
+	}
+	if len(parts) == 1 {
+		return parts[0].Content, nil
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\nMIME-Version: 1.0\n\n", boundary)
+	writer := multipart.NewWriter(&buf)
+	err := writer.SetBoundary(boundary)
+	if err != nil {
+		return "", fmt.Errorf("set MIME boundary: %w", err)
+	}
+	for i, part := range parts {
+		contentType := part.ContentType()
+		if contentType == "" {
+			return "", fmt.Errorf("user data part %s has an unknown type, it must start with a header like \"#cloud-config\" or \"#!\"", part.Label(i))
+		}
+		if strings.Contains(part.Content, boundary) {
+			return "", fmt.Errorf("user data part %s contains the MIME boundary %q", part.Label(i), boundary)
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", contentType))
+		header.Set("MIME-Version", "1.0")
+		if part.Name != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.Name))
+		}
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("create MIME part: %w", err)
+		}
+		_, err = w.Write([]byte(part.Content))
+		if err != nil {
+			return "", fmt.Errorf("write MIME part: %w", err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("close MIME document: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Compress returns the gzip compressed user data, which cloud-init decompresses before processing it
+func Compress(userData string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(userData))
+	if err != nil {
+		return nil, fmt.Errorf("compress user data: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("compress user data: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Build renders and validates the parts and returns the user data to submit, together with validation warnings
+func Build(parts []Part, vars map[string]string, compress bool) (userData []byte, warnings []string, err error) {
+	parts, err = Render(parts, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range parts {
+		partWarnings, err := Validate(&parts[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("user data part %s is invalid: %w", parts[i].Label(i), err)
+		}
+		for _, w := range partWarnings {
+			warnings = append(warnings, fmt.Sprintf("user data part %s: %s", parts[i].Label(i), w))
+		}
+	}
+
+	composed, err := Compose(parts)
+	if err != nil {
+		return nil, nil, err
+	}
+	userData = []byte(composed)
+	if compress {
+		userData, err = Compress(composed)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if size := base64.StdEncoding.EncodedLen(len(userData)); size > MaxEncodedSize {
+		warnings = append(warnings, fmt.Sprintf("the encoded user data has %d bytes, which is more than the %d bytes accepted by the API", size, MaxEncodedSize))
+	}
+	return userData, warnings, nil
+}
+
+// Validate checks "#cloud-config" parts against the cloud-init schema.
+// Errors are returned for invalid YAML and values of the wrong type, warnings for keys cloud-init doesn't know.
+// Other parts are not validated.
+func Validate(part *Part) (warnings []string, err error) {
+	if part.ContentType() != "text/cloud-config" {
+		return nil, nil
+	}
+
+	var config interface{}
+	err = yaml.Unmarshal([]byte(part.Content), &config)
+	if err != nil {
+		return nil, fmt.Errorf("parse YAML:\n%s", yaml.FormatError(err, false, true))
+	}
+	if config == nil {
+		return nil, nil
+	}
+	configMap, ok := config.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cloud-config must be a mapping of module settings")
+	}
+
+	keys := make([]string, 0, len(configMap))
+	for key := range configMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := configMap[key]
+		check, ok := schema[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown cloud-config key %q", key))
+			continue
+		}
+		if check == nil {
+			continue
+		}
+		err = check(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,380 @@
+package userdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testCloudConfig = "#cloud-config\npackages:\n  - nginx\nruncmd:\n  - [systemctl, enable, nginx]\n"
+	testScript      = "#!/bin/sh\necho hello\n"
+)
+
+func TestReadParts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "base.yaml")
+	err := os.WriteFile(path, []byte(testCloudConfig), 0o600)
+	if err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	tests := []struct {
+		description   string
+		values        []string
+		isValid       bool
+		expectedParts []Part
+	}{
+		{
+			description:   "inline",
+			values:        []string{testScript},
+			isValid:       true,
+			expectedParts: []Part{{Content: testScript}},
+		},
+		{
+			description: "file and inline",
+			values:      []string{"@" + path, testScript},
+			isValid:     true,
+			expectedParts: []Part{
+				{Name: "base.yaml", Content: testCloudConfig},
+				{Content: testScript},
+			},
+		},
+		{
+			description:   "quoted file",
+			values:        []string{`@"` + path + `"`},
+			isValid:       true,
+			expectedParts: []Part{{Name: "base.yaml", Content: testCloudConfig}},
+		},
+		{
+			description: "file not found",
+			values:      []string{"@" + filepath.Join(dir, "missing.yaml")},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			parts, err := ReadParts(tt.values)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("read parts: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(parts, tt.expectedParts)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{testCloudConfig, "text/cloud-config"},
+		{"\n" + testCloudConfig, "text/cloud-config"},
+		{"#cloud-config-archive\n- type: text/cloud-config\n", "text/cloud-config-archive"},
+		{testScript, "text/x-shellscript"},
+		{"#cloud-boothook\n#!/bin/sh\n", "text/cloud-boothook"},
+		{"#include\nhttps://example.com/config\n", "text/x-include-url"},
+		{"## template: jinja\n#cloud-config\n", "text/jinja2"},
+		{"packages: [nginx]\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			part := Part{Content: tt.content}
+			if got := part.ContentType(); got != tt.expected {
+				t.Fatalf("expected content type %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		description   string
+		parts         []Part
+		vars          map[string]string
+		isValid       bool
+		expectedParts []Part
+	}{
+		{
+			description:   "variables",
+			parts:         []Part{{Name: "base.yaml", Content: "#cloud-config\nhostname: {{ .hostname }}\n"}},
+			vars:          map[string]string{"hostname": "web-1"},
+			isValid:       true,
+			expectedParts: []Part{{Name: "base.yaml", Content: "#cloud-config\nhostname: web-1\n"}},
+		},
+		{
+			description:   "no variables",
+			parts:         []Part{{Content: "#!/bin/sh\ndocker inspect --format '{{.State}}' app\n"}},
+			isValid:       true,
+			expectedParts: []Part{{Content: "#!/bin/sh\ndocker inspect --format '{{.State}}' app\n"}},
+		},
+		{
+			description: "missing variable",
+			parts:       []Part{{Content: "#cloud-config\nhostname: {{ .hostname }}\n"}},
+			vars:        map[string]string{"other": "value"},
+			isValid:     false,
+		},
+		{
+			description: "invalid template",
+			parts:       []Part{{Content: "#cloud-config\nhostname: {{ .hostname \n"}},
+			vars:        map[string]string{"hostname": "web-1"},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			parts, err := Render(tt.parts, tt.vars)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("render: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(parts, tt.expectedParts)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		description      string
+		content          string
+		isValid          bool
+		expectedWarnings []string
+	}{
+		{
+			description: "valid",
+			content:     testCloudConfig,
+			isValid:     true,
+		},
+		{
+			description: "script is not validated",
+			content:     "#!/bin/sh\npackages: [\n",
+			isValid:     true,
+		},
+		{
+			description: "empty cloud-config",
+			content:     "#cloud-config\n",
+			isValid:     true,
+		},
+		{
+			description: "write files",
+			content:     "#cloud-config\nwrite_files:\n  - path: /etc/motd\n    content: hello\n    permissions: 0644\n    encoding: text/plain\n",
+			isValid:     true,
+		},
+		{
+			description:      "unknown keys",
+			content:          "#cloud-config\npackage: [nginx]\nrun_cmd: [ls]\n",
+			isValid:          true,
+			expectedWarnings: []string{`unknown cloud-config key "package"`, `unknown cloud-config key "run_cmd"`},
+		},
+		{
+			description: "invalid YAML",
+			content:     "#cloud-config\npackages:\n  - nginx\n - curl\n",
+			isValid:     false,
+		},
+		{
+			description: "not a mapping",
+			content:     "#cloud-config\n- nginx\n",
+			isValid:     false,
+		},
+		{
+			description: "wrong type",
+			content:     "#cloud-config\npackages: nginx\n",
+			isValid:     false,
+		},
+		{
+			description: "wrong item type",
+			content:     "#cloud-config\nruncmd:\n  - {echo: hello}\n",
+			isValid:     false,
+		},
+		{
+			description: "write file without path",
+			content:     "#cloud-config\nwrite_files:\n  - content: hello\n",
+			isValid:     false,
+		},
+		{
+			description: "write file with unknown encoding",
+			content:     "#cloud-config\nwrite_files:\n  - path: /etc/motd\n    encoding: zip\n",
+			isValid:     false,
+		},
+		{
+			description: "wrong bool",
+			content:     "#cloud-config\npackage_update: sometimes\n",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			warnings, err := Validate(&Part{Content: tt.content})
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("validate: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(warnings, tt.expectedWarnings)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompose(t *testing.T) {
+	t.Run("single part", func(t *testing.T) {
+		userData, err := Compose([]Part{{Content: "test-user-data"}})
+		if err != nil {
+			t.Fatalf("compose: %v", err)
+		}
+		if userData != "test-user-data" {
+			t.Fatalf("expected user data to be unchanged, got %q", userData)
+		}
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		userData, err := Compose([]Part{{Name: "base.yaml", Content: testCloudConfig}, {Content: testScript}})
+		if err != nil {
+			t.Fatalf("compose: %v", err)
+		}
+
+		header, body, ok := strings.Cut(userData, "\n\n")
+		if !ok {
+			t.Fatalf("no header in %q", userData)
+		}
+		mediaType, params, err := mime.ParseMediaType(strings.TrimPrefix(strings.Split(header, "\n")[0], "Content-Type: "))
+		if err != nil {
+			t.Fatalf("parse content type: %v", err)
+		}
+		if mediaType != "multipart/mixed" {
+			t.Fatalf("expected multipart/mixed, got %q", mediaType)
+		}
+
+		reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+		expected := []struct {
+			contentType string
+			fileName    string
+			content     string
+		}{
+			{`text/cloud-config; charset="utf-8"`, "base.yaml", testCloudConfig},
+			{`text/x-shellscript; charset="utf-8"`, "", testScript},
+		}
+		for _, e := range expected {
+			part, err := reader.NextPart()
+			if err != nil {
+				t.Fatalf("read part: %v", err)
+			}
+			if got := part.Header.Get("Content-Type"); got != e.contentType {
+				t.Fatalf("expected content type %q, got %q", e.contentType, got)
+			}
+			if got := part.FileName(); got != e.fileName {
+				t.Fatalf("expected file name %q, got %q", e.fileName, got)
+			}
+			content, err := io.ReadAll(part)
+			if err != nil {
+				t.Fatalf("read part content: %v", err)
+			}
+			if string(content) != e.content {
+				t.Fatalf("expected content %q, got %q", e.content, content)
+			}
+		}
+		if _, err := reader.NextPart(); err != io.EOF {
+			t.Fatalf("expected 2 parts, got more: %v", err)
+		}
+	})
+
+	t.Run("unknown part type", func(t *testing.T) {
+		_, err := Compose([]Part{{Content: testScript}, {Content: "packages: [nginx]\n"}})
+		if err == nil {
+			t.Fatalf("did not fail on invalid input")
+		}
+	})
+
+	t.Run("no parts", func(t *testing.T) {
+		_, err := Compose([]Part{})
+		if err == nil {
+			t.Fatalf("did not fail on invalid input")
+		}
+	})
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("compressed", func(t *testing.T) {
+		userData, warnings, err := Build([]Part{{Content: testCloudConfig}}, nil, true)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		if len(warnings) != 0 {
+			t.Fatalf("expected no warnings, got %v", warnings)
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(userData))
+		if err != nil {
+			t.Fatalf("open gzip: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("decompress: %v", err)
+		}
+		if string(content) != testCloudConfig {
+			t.Fatalf("expected %q, got %q", testCloudConfig, content)
+		}
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		_, warnings, err := Build([]Part{{Name: "base.yaml", Content: "#cloud-config\npackage: [nginx]\n"}}, nil, false)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		diff := cmp.Diff(warnings, []string{`user data part "base.yaml": unknown cloud-config key "package"`})
+		if diff != "" {
+			t.Fatalf("Data does not match: %s", diff)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, warnings, err := Build([]Part{{Content: "#!/bin/sh\n" + strings.Repeat("# padding\n", MaxEncodedSize/10)}}, nil, false)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		if len(warnings) != 1 {
+			t.Fatalf("expected a size warning, got %v", warnings)
+		}
+	})
+
+	t.Run("invalid part", func(t *testing.T) {
+		_, _, err := Build([]Part{{Content: "#cloud-config\npackages: nginx\n"}}, nil, false)
+		if err == nil {
+			t.Fatalf("did not fail on invalid input")
+		}
+	})
+}
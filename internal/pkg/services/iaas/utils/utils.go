@@ -2,11 +2,22 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
+	"github.com/stackitcloud/stackit-sdk-go/core/wait"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
 )
 
+const (
+	SnapshotAvailableStatus = "AVAILABLE"
+	SnapshotDeletedStatus   = "DELETED"
+	SnapshotErrorStatus     = "ERROR"
+)
+
 type IaaSClient interface {
 	GetSecurityGroupRuleExecute(ctx context.Context, projectId, securityGroupRuleId, securityGroupId string) (*iaas.SecurityGroupRule, error)
 	GetSecurityGroupExecute(ctx context.Context, projectId, securityGroupId string) (*iaas.SecurityGroup, error)
@@ -19,6 +30,7 @@ type IaaSClient interface {
 	GetNetworkAreaRangeExecute(ctx context.Context, organizationId, areaId, networkRangeId string) (*iaas.NetworkRange, error)
 	GetImageExecute(ctx context.Context, projectId string, imageId string) (*iaas.Image, error)
 	GetAffinityGroupExecute(ctx context.Context, projectId string, affinityGroupId string) (*iaas.AffinityGroup, error)
+	GetSnapshotExecute(ctx context.Context, projectId, snapshotId string) (*iaas.Snapshot, error)
 }
 
 func GetSecurityGroupRuleName(ctx context.Context, apiClient IaaSClient, projectId, securityGroupRuleId, securityGroupId string) (string, error) {
@@ -141,3 +153,57 @@ func GetAffinityGroupName(ctx context.Context, apiClient IaaSClient, projectId,
 	}
 	return *resp.Name, nil
 }
+
+func GetSnapshotName(ctx context.Context, apiClient IaaSClient, projectId, snapshotId string) (string, error) {
+	resp, err := apiClient.GetSnapshotExecute(ctx, projectId, snapshotId)
+	if err != nil {
+		return "", fmt.Errorf("get snapshot: %w", err)
+	}
+	if resp.Name == nil {
+		return "", nil
+	}
+	return *resp.Name, nil
+}
+
+// CreateSnapshotWaitHandler waits until the snapshot is available
+func CreateSnapshotWaitHandler(ctx context.Context, apiClient IaaSClient, projectId, snapshotId string) *wait.AsyncActionHandler[iaas.Snapshot] {
+	handler := wait.New(func() (waitFinished bool, response *iaas.Snapshot, err error) {
+		snapshot, err := apiClient.GetSnapshotExecute(ctx, projectId, snapshotId)
+		if err != nil {
+			return false, snapshot, err
+		}
+		if snapshot.Status == nil {
+			return false, snapshot, fmt.Errorf("create failed for snapshot with id %s, the response is not valid: the status is missing", snapshotId)
+		}
+		switch *snapshot.Status {
+		case SnapshotAvailableStatus:
+			return true, snapshot, nil
+		case SnapshotErrorStatus:
+			return true, snapshot, fmt.Errorf("create failed for snapshot with id %s", snapshotId)
+		default:
+			return false, snapshot, nil
+		}
+	})
+	handler.SetTimeout(30 * time.Minute)
+	return handler
+}
+
+// DeleteSnapshotWaitHandler waits until the snapshot is deleted
+func DeleteSnapshotWaitHandler(ctx context.Context, apiClient IaaSClient, projectId, snapshotId string) *wait.AsyncActionHandler[iaas.Snapshot] {
+	handler := wait.New(func() (waitFinished bool, response *iaas.Snapshot, err error) {
+		snapshot, err := apiClient.GetSnapshotExecute(ctx, projectId, snapshotId)
+		if err == nil {
+			if snapshot != nil && snapshot.Status != nil && *snapshot.Status == SnapshotDeletedStatus {
+				return true, snapshot, nil
+			}
+			return false, nil, nil
+		}
+		var oapiErr *oapierror.GenericOpenAPIError
+		if !errors.As(err, &oapiErr) || oapiErr.StatusCode != http.StatusNotFound {
+			return false, nil, err
+		}
+		return true, nil, nil
+	})
+	handler.SetTimeout(30 * time.Minute)
+	return handler
+}
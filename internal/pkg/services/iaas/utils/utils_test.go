@@ -3,10 +3,13 @@ package utils
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
 	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
 )
 
@@ -33,6 +36,8 @@ type IaaSClientMocked struct {
 	GetImageResp              *iaas.Image
 	GetAffinityGroupsFails    bool
 	GetAffinityGroupResp      *iaas.AffinityGroup
+	GetSnapshotFails          bool
+	GetSnapshotResp           *iaas.Snapshot
 }
 
 func (m *IaaSClientMocked) GetAffinityGroupExecute(_ context.Context, _, _ string) (*iaas.AffinityGroup, error) {
@@ -112,6 +117,13 @@ func (m *IaaSClientMocked) GetImageExecute(_ context.Context, _, _ string) (*iaa
 	return m.GetImageResp, nil
 }
 
+func (m *IaaSClientMocked) GetSnapshotExecute(_ context.Context, _, _ string) (*iaas.Snapshot, error) {
+	if m.GetSnapshotFails {
+		return nil, &oapierror.GenericOpenAPIError{StatusCode: http.StatusNotFound}
+	}
+	return m.GetSnapshotResp, nil
+}
+
 func TestGetSecurityGroupRuleName(t *testing.T) {
 	type args struct {
 		getInstanceFails bool
@@ -769,3 +781,124 @@ func TestGetAffinityGroupName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSnapshotName(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshotResp *iaas.Snapshot
+		snapshotErr  bool
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "successful retrieval",
+			snapshotResp: &iaas.Snapshot{Name: utils.Ptr("test-snapshot")},
+			want:         "test-snapshot",
+			wantErr:      false,
+		},
+		{
+			name:        "error on retrieval",
+			snapshotErr: true,
+			wantErr:     true,
+		},
+		{
+			name:         "nil name",
+			snapshotResp: &iaas.Snapshot{},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &IaaSClientMocked{
+				GetSnapshotFails: tt.snapshotErr,
+				GetSnapshotResp:  tt.snapshotResp,
+			}
+			got, err := GetSnapshotName(context.Background(), client, "", "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSnapshotName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetSnapshotName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateSnapshotWaitHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshotResp *iaas.Snapshot
+		wantErr      bool
+	}{
+		{
+			name:         "available",
+			snapshotResp: &iaas.Snapshot{Status: utils.Ptr(SnapshotAvailableStatus)},
+			wantErr:      false,
+		},
+		{
+			name:         "error status",
+			snapshotResp: &iaas.Snapshot{Status: utils.Ptr(SnapshotErrorStatus)},
+			wantErr:      true,
+		},
+		{
+			name:         "still creating",
+			snapshotResp: &iaas.Snapshot{Status: utils.Ptr("CREATING")},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &IaaSClientMocked{
+				GetSnapshotResp: tt.snapshotResp,
+			}
+			handler := CreateSnapshotWaitHandler(context.Background(), client, "", "")
+			handler.SetThrottle(10 * time.Millisecond)
+			handler.SetTimeout(50 * time.Millisecond)
+			_, err := handler.WaitWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSnapshotWaitHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteSnapshotWaitHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshotResp *iaas.Snapshot
+		snapshotErr  bool
+		wantErr      bool
+	}{
+		{
+			name:        "not found",
+			snapshotErr: true,
+			wantErr:     false,
+		},
+		{
+			name:         "deleted status",
+			snapshotResp: &iaas.Snapshot{Status: utils.Ptr(SnapshotDeletedStatus)},
+			wantErr:      false,
+		},
+		{
+			name:         "still deleting",
+			snapshotResp: &iaas.Snapshot{Status: utils.Ptr("DELETING")},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &IaaSClientMocked{
+				GetSnapshotFails: tt.snapshotErr,
+				GetSnapshotResp:  tt.snapshotResp,
+			}
+			handler := DeleteSnapshotWaitHandler(context.Background(), client, "", "")
+			handler.SetThrottle(10 * time.Millisecond)
+			handler.SetTimeout(50 * time.Millisecond)
+			_, err := handler.WaitWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteSnapshotWaitHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
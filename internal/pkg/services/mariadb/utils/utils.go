@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/recommend"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
 )
@@ -65,6 +67,37 @@ func LoadPlanId(planName, version string, offerings *mariadb.ListOfferingsRespon
 	}
 }
 
+// GetPlanCandidates returns the plans of the given version (or of all versions, if version is empty) whose resources are known
+func GetPlanCandidates(version string, offerings *mariadb.ListOfferingsResponse) []recommend.Candidate {
+	candidates := []recommend.Candidate{}
+	if offerings == nil || offerings.Offerings == nil {
+		return candidates
+	}
+	for _, offer := range *offerings.Offerings {
+		if version != "" && !strings.EqualFold(utils.PtrString(offer.Version), version) {
+			continue
+		}
+		for _, plan := range utils.PtrValue(offer.Plans) {
+			candidate, ok := recommend.NewDSAPlanCandidate(plan.Id, plan.Name, offer.Version, plan.Description)
+			if ok {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// LoadPlanIdFromResources returns the ID of the smallest plan of the given version with at least the given CPU and RAM
+func LoadPlanIdFromResources(cpu, ram *int64, version string, offerings *mariadb.ListOfferingsResponse) (*string, error) {
+	availableVersions := []string{}
+	if offerings != nil && offerings.Offerings != nil {
+		for _, offer := range *offerings.Offerings {
+			availableVersions = append(availableVersions, utils.PtrString(offer.Version))
+		}
+	}
+	return recommend.LoadDSAPlanId(service, cpu, ram, version, availableVersions, GetPlanCandidates(version, offerings))
+}
+
 type MariaDBClient interface {
 	GetInstanceExecute(ctx context.Context, projectId, instanceId string) (*mariadb.Instance, error)
 	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*mariadb.CredentialsResponse, error)
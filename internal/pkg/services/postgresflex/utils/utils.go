@@ -7,8 +7,11 @@ import (
 	"strings"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
 
 	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex/wait"
 	"golang.org/x/mod/semver"
 )
 
@@ -24,6 +27,12 @@ type PostgresFlexClient interface {
 	GetUserExecute(ctx context.Context, projectId, region, instanceId, userId string) (*postgresflex.GetUserResponse, error)
 }
 
+type PostgresFlexCloneClient interface {
+	CloneInstance(ctx context.Context, projectId, region, instanceId string) postgresflex.ApiCloneInstanceRequest
+	GetInstanceExecute(ctx context.Context, projectId, region, instanceId string) (*postgresflex.InstanceResponse, error)
+	ListStoragesExecute(ctx context.Context, projectId, region, flavorId string) (*postgresflex.ListStoragesResponse, error)
+}
+
 func AvailableInstanceTypes() []string {
 	instanceTypes := make([]string, len(instanceTypeToReplicas))
 	i := 0
@@ -164,3 +173,55 @@ func GetUserName(ctx context.Context, apiClient PostgresFlexClient, projectId, r
 	}
 	return *resp.Item.Username, nil
 }
+
+// BuildCloneInstanceRequest builds the request to clone the instance to a new instance from the point in time of the recovery timestamp.
+// If a storage class or size is set, it is validated for the flavor of the instance, with the storage of the instance for the value not set.
+func BuildCloneInstanceRequest(ctx context.Context, apiClient PostgresFlexCloneClient, projectId, region, instanceId string, storageClass *string, storageSize *int64, recoveryTimestamp string) (postgresflex.ApiCloneInstanceRequest, error) {
+	req := apiClient.CloneInstance(ctx, projectId, region, instanceId)
+
+	if storageClass != nil || storageSize != nil {
+		currentInstance, err := apiClient.GetInstanceExecute(ctx, projectId, region, instanceId)
+		if err != nil {
+			return req, fmt.Errorf("get PostgreSQL Flex instance: %w", err)
+		}
+		validationFlavorId := currentInstance.Item.Flavor.Id
+
+		storages, err := apiClient.ListStoragesExecute(ctx, projectId, region, *validationFlavorId)
+		if err != nil {
+			return req, fmt.Errorf("get PostgreSQL Flex storages: %w", err)
+		}
+
+		validationStorageClass := storageClass
+		if validationStorageClass == nil {
+			validationStorageClass = currentInstance.Item.Storage.Class
+		}
+		validationStorageSize := storageSize
+		if validationStorageSize == nil {
+			validationStorageSize = currentInstance.Item.Storage.Size
+		}
+		err = ValidateStorage(validationStorageClass, validationStorageSize, storages, *validationFlavorId)
+		if err != nil {
+			return req, err
+		}
+	}
+
+	req = req.CloneInstancePayload(postgresflex.CloneInstancePayload{
+		Class:     storageClass,
+		Size:      storageSize,
+		Timestamp: &recoveryTimestamp,
+	})
+	return req, nil
+}
+
+// WaitForClonedInstance waits until the instance created by a clone request is ready, showing a spinner
+func WaitForClonedInstance(ctx context.Context, p *print.Printer, apiClient *postgresflex.APIClient, projectId, region, instanceId string) error {
+	s := spinner.New(p)
+	s.Start("Cloning instance")
+	_, err := wait.CreateInstanceWaitHandler(ctx, apiClient, projectId, region, instanceId).WaitWithContext(ctx)
+	if err != nil {
+		s.StopWithError()
+		return fmt.Errorf("wait for PostgreSQL Flex instance cloning: %w", err)
+	}
+	s.Stop()
+	return nil
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -142,3 +143,139 @@ func TestGetCredentialsUsername(t *testing.T) {
 		})
 	}
 }
+
+func fixtureOfferings() *rabbitmq.ListOfferingsResponse {
+	return &rabbitmq.ListOfferingsResponse{
+		Offerings: &[]rabbitmq.Offering{
+			{
+				Version: utils.Ptr("6"),
+				Plans: &[]rabbitmq.Plan{
+					{Id: utils.Ptr("large"), Name: utils.Ptr("stackit-rabbitmq-4.16.80-single")},
+					{Id: utils.Ptr("small"), Name: utils.Ptr("stackit-rabbitmq-1.2.10-single")},
+					{Id: utils.Ptr("unknown"), Name: utils.Ptr("stackit-rabbitmq-custom")},
+				},
+			},
+			{
+				Version: utils.Ptr("7"),
+				Plans: &[]rabbitmq.Plan{
+					{Id: utils.Ptr("medium"), Name: utils.Ptr("stackit-rabbitmq-2.4.20-single")},
+				},
+			},
+		},
+	}
+}
+
+func TestGetPlanCandidates(t *testing.T) {
+	tests := []struct {
+		description string
+		version     string
+		expectedIds []string
+	}{
+		{
+			description: "all versions",
+			version:     "",
+			expectedIds: []string{"large", "small", "medium"},
+		},
+		{
+			description: "version 6",
+			version:     "6",
+			expectedIds: []string{"large", "small"},
+		},
+		{
+			description: "unknown version",
+			version:     "5",
+			expectedIds: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			candidates := GetPlanCandidates(tt.version, fixtureOfferings())
+			ids := []string{}
+			for _, c := range candidates {
+				ids = append(ids, c.Id)
+			}
+			if !slices.Equal(ids, tt.expectedIds) {
+				t.Errorf("expected candidates %v, got %v", tt.expectedIds, ids)
+			}
+		})
+	}
+}
+
+func TestLoadPlanIdFromResources(t *testing.T) {
+	tests := []struct {
+		description    string
+		cpu            *int64
+		ram            *int64
+		version        string
+		noOfferings    bool
+		offerings      *rabbitmq.ListOfferingsResponse
+		isValid        bool
+		expectedPlanId string
+	}{
+		{
+			description:    "smallest matching plan",
+			cpu:            utils.Ptr(int64(1)),
+			ram:            utils.Ptr(int64(2)),
+			version:        "6",
+			isValid:        true,
+			expectedPlanId: "small",
+		},
+		{
+			description:    "only ram",
+			ram:            utils.Ptr(int64(4)),
+			version:        "6",
+			isValid:        true,
+			expectedPlanId: "large",
+		},
+		{
+			description: "no matching plan",
+			cpu:         utils.Ptr(int64(8)),
+			version:     "6",
+			isValid:     false,
+		},
+		{
+			description: "nil offerings",
+			cpu:         utils.Ptr(int64(1)),
+			version:     "6",
+			noOfferings: true,
+			isValid:     false,
+		},
+		{
+			description: "empty offerings",
+			cpu:         utils.Ptr(int64(1)),
+			version:     "6",
+			noOfferings: true,
+			offerings:   &rabbitmq.ListOfferingsResponse{},
+			isValid:     false,
+		},
+		{
+			description: "invalid version",
+			cpu:         utils.Ptr(int64(1)),
+			version:     "5",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			offerings := fixtureOfferings()
+			if tt.noOfferings {
+				offerings = tt.offerings
+			}
+			planId, err := LoadPlanIdFromResources(tt.cpu, tt.ram, tt.version, offerings)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+			if !tt.isValid {
+				return
+			}
+			if *planId != tt.expectedPlanId {
+				t.Errorf("expected plan ID to be %s, got %s", tt.expectedPlanId, *planId)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package retention
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
+)
+
+const (
+	// BackupStatusCompleted is the status of backups which can be restored.
+	// Only completed backups are counted by the retention policy and pruned.
+	BackupStatusCompleted = "completed"
+
+	ReasonDaily        = "daily"
+	ReasonWeekly       = "weekly"
+	ReasonMonthly      = "monthly"
+	ReasonNotCompleted = "not completed"
+	ReasonUnknownTime  = "unknown creation time"
+)
+
+// Policy is a grandfather-father-son retention policy.
+// For every period (day, ISO week, month) with backups, the newest backup of the period is kept,
+// until the number of periods of the respective kind is reached.
+type Policy struct {
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+}
+
+// Decision is the result of applying the policy to a single backup
+type Decision struct {
+	Backup  serverbackup.Backup `json:"backup"`
+	Keep    bool                `json:"keep"`
+	Reasons []string            `json:"reasons"`
+}
+
+func (p Policy) IsEmpty() bool {
+	return p.KeepDaily <= 0 && p.KeepWeekly <= 0 && p.KeepMonthly <= 0
+}
+
+// Apply returns the decisions for the backups, sorted by creation time with the newest backup first.
+// Backups which are not completed, or whose creation time can't be parsed, are always kept.
+func Apply(policy Policy, backups []serverbackup.Backup) ([]Decision, error) {
+	if policy.IsEmpty() {
+		return nil, fmt.Errorf("the retention policy must keep at least one backup")
+	}
+
+	type candidate struct {
+		decision  *Decision
+		createdAt time.Time
+	}
+	decisions := make([]Decision, len(backups))
+	candidates := []candidate{}
+	for i := range backups {
+		decisions[i] = Decision{Backup: backups[i], Reasons: []string{}}
+		if utils.PtrString(backups[i].Status) != BackupStatusCompleted {
+			decisions[i].Keep = true
+			decisions[i].Reasons = append(decisions[i].Reasons, ReasonNotCompleted)
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, utils.PtrString(backups[i].CreatedAt))
+		if err != nil {
+			decisions[i].Keep = true
+			decisions[i].Reasons = append(decisions[i].Reasons, ReasonUnknownTime)
+			continue
+		}
+		candidates = append(candidates, candidate{decision: &decisions[i], createdAt: createdAt.UTC()})
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return b.createdAt.Compare(a.createdAt)
+	})
+
+	rules := []struct {
+		reason string
+		keep   int
+		period func(t time.Time) string
+	}{
+		{ReasonDaily, policy.KeepDaily, func(t time.Time) string { return t.Format(time.DateOnly) }},
+		{ReasonWeekly, policy.KeepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{ReasonMonthly, policy.KeepMonthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, rule := range rules {
+		seen := map[string]bool{}
+		for _, c := range candidates {
+			if len(seen) >= rule.keep {
+				break
+			}
+			period := rule.period(c.createdAt)
+			if seen[period] {
+				continue
+			}
+			seen[period] = true
+			c.decision.Keep = true
+			c.decision.Reasons = append(c.decision.Reasons, rule.reason)
+		}
+	}
+
+	// Sort the decisions by creation time, newest first, with backups of unknown creation time at the end
+	createdAt := func(d Decision) time.Time {
+		t, _ := time.Parse(time.RFC3339, utils.PtrString(d.Backup.CreatedAt))
+		return t
+	}
+	slices.SortStableFunc(decisions, func(a, b Decision) int {
+		return createdAt(b).Compare(createdAt(a))
+	})
+	return decisions, nil
+}
@@ -0,0 +1,106 @@
+package retention
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
+)
+
+func fixtureBackup(id, createdAt, status string) serverbackup.Backup {
+	return serverbackup.Backup{
+		Id:        utils.Ptr(id),
+		CreatedAt: utils.Ptr(createdAt),
+		Status:    utils.Ptr(status),
+	}
+}
+
+func TestApply(t *testing.T) {
+	backups := []serverbackup.Backup{
+		fixtureBackup("mar-01", "2025-03-01T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("mar-14", "2025-03-14T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("mar-16", "2025-03-16T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("mar-17-early", "2025-03-17T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("mar-17-late", "2025-03-17T14:00:00Z", BackupStatusCompleted),
+		fixtureBackup("mar-18", "2025-03-18T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("feb-20", "2025-02-20T02:00:00Z", BackupStatusCompleted),
+		fixtureBackup("running", "2025-03-19T02:00:00Z", "in-progress"),
+		fixtureBackup("invalid", "yesterday", BackupStatusCompleted),
+	}
+
+	type result struct {
+		Id      string
+		Keep    bool
+		Reasons []string
+	}
+	tests := []struct {
+		description string
+		policy      Policy
+		isValid     bool
+		expected    []result
+	}{
+		{
+			description: "daily, weekly and monthly",
+			policy:      Policy{KeepDaily: 2, KeepWeekly: 2, KeepMonthly: 2},
+			isValid:     true,
+			expected: []result{
+				{"running", true, []string{ReasonNotCompleted}},
+				{"mar-18", true, []string{ReasonDaily, ReasonWeekly, ReasonMonthly}},
+				{"mar-17-late", true, []string{ReasonDaily}},
+				{"mar-17-early", false, []string{}},
+				{"mar-16", true, []string{ReasonWeekly}},
+				{"mar-14", false, []string{}},
+				{"mar-01", false, []string{}},
+				{"feb-20", true, []string{ReasonMonthly}},
+				{"invalid", true, []string{ReasonUnknownTime}},
+			},
+		},
+		{
+			description: "daily only",
+			policy:      Policy{KeepDaily: 1},
+			isValid:     true,
+			expected: []result{
+				{"running", true, []string{ReasonNotCompleted}},
+				{"mar-18", true, []string{ReasonDaily}},
+				{"mar-17-late", false, []string{}},
+				{"mar-17-early", false, []string{}},
+				{"mar-16", false, []string{}},
+				{"mar-14", false, []string{}},
+				{"mar-01", false, []string{}},
+				{"feb-20", false, []string{}},
+				{"invalid", true, []string{ReasonUnknownTime}},
+			},
+		},
+		{
+			description: "empty policy",
+			policy:      Policy{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			decisions, err := Apply(tt.policy, backups)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("apply policy: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			results := []result{}
+			for _, d := range decisions {
+				results = append(results, result{utils.PtrString(d.Backup.Id), d.Keep, d.Reasons})
+			}
+			diff := cmp.Diff(results, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
@@ -3,7 +3,11 @@ package utils
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/core/wait"
 	"github.com/stackitcloud/stackit-sdk-go/services/serverbackup"
 )
 
@@ -12,6 +16,10 @@ type ServerBackupClient interface {
 	ListBackupsExecute(ctx context.Context, projectId, serverId, region string) (*serverbackup.GetBackupsListResponse, error)
 }
 
+type ServerBackupGetClient interface {
+	GetBackupExecute(ctx context.Context, projectId, serverId, region, backupId string) (*serverbackup.Backup, error)
+}
+
 func CanDisableBackupService(ctx context.Context, client ServerBackupClient, projectId, serverId, region string) (bool, error) {
 	schedules, err := client.ListBackupSchedulesExecute(ctx, projectId, serverId, region)
 	if err != nil {
@@ -32,3 +40,27 @@ func CanDisableBackupService(ctx context.Context, client ServerBackupClient, pro
 	// no backups and no backup schedules found for this server => can disable backup service
 	return true, nil
 }
+
+// RestoreVolumeBackupWaitHandler waits until the volume backup was restored to the given volume.
+// The restore is finished when the volume backup reports the volume as its last restored volume.
+func RestoreVolumeBackupWaitHandler(ctx context.Context, client ServerBackupGetClient, projectId, serverId, region, backupId, volumeBackupId, volumeId string) *wait.AsyncActionHandler[serverbackup.BackupVolumeBackupsInner] {
+	handler := wait.New(func() (waitFinished bool, response *serverbackup.BackupVolumeBackupsInner, err error) {
+		backup, err := client.GetBackupExecute(ctx, projectId, serverId, region, backupId)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := range utils.PtrValue(backup.VolumeBackups) {
+			volumeBackup := &(*backup.VolumeBackups)[i]
+			if utils.PtrString(volumeBackup.Id) != volumeBackupId {
+				continue
+			}
+			if utils.PtrString(volumeBackup.LastRestoredVolumeId) == volumeId && volumeBackup.LastRestoredAt != nil {
+				return true, volumeBackup, nil
+			}
+			return false, volumeBackup, nil
+		}
+		return true, nil, fmt.Errorf("volume backup %q not found in backup %q", volumeBackupId, backupId)
+	})
+	handler.SetTimeout(60 * time.Minute)
+	return handler
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -144,3 +145,65 @@ func TestCanDisableBackupService(t *testing.T) {
 		})
 	}
 }
+
+type serverbackupGetClientMocked struct {
+	getBackupFails bool
+	getBackupResp  *serverbackup.Backup
+}
+
+func (m *serverbackupGetClientMocked) GetBackupExecute(_ context.Context, _, _, _, _ string) (*serverbackup.Backup, error) {
+	if m.getBackupFails {
+		return nil, fmt.Errorf("could not get backup")
+	}
+	return m.getBackupResp, nil
+}
+
+func TestRestoreVolumeBackupWaitHandler(t *testing.T) {
+	testBackupId := uuid.NewString()
+	testVolumeBackupId := uuid.NewString()
+	testVolumeId := uuid.NewString()
+
+	tests := []struct {
+		description    string
+		getBackupFails bool
+		volumeBackups  []serverbackup.BackupVolumeBackupsInner
+		wantErr        bool
+	}{
+		{
+			description: "restored",
+			volumeBackups: []serverbackup.BackupVolumeBackupsInner{
+				{Id: utils.Ptr(uuid.NewString())},
+				{Id: utils.Ptr(testVolumeBackupId), LastRestoredVolumeId: utils.Ptr(testVolumeId), LastRestoredAt: utils.Ptr("2025-03-18T02:00:00Z")},
+			},
+			wantErr: false,
+		},
+		{
+			description:   "volume backup not found",
+			volumeBackups: []serverbackup.BackupVolumeBackupsInner{{Id: utils.Ptr(uuid.NewString())}},
+			wantErr:       true,
+		},
+		{
+			description:    "get backup fails",
+			getBackupFails: true,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &serverbackupGetClientMocked{
+				getBackupFails: tt.getBackupFails,
+				getBackupResp:  &serverbackup.Backup{VolumeBackups: &tt.volumeBackups},
+			}
+			handler := RestoreVolumeBackupWaitHandler(context.Background(), client, testProjectId, testServerId, testRegion, testBackupId, testVolumeBackupId, testVolumeId)
+			handler.SetThrottle(time.Millisecond).SetTempErrRetryLimit(0)
+			volumeBackup, err := handler.WaitWithContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wait error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && utils.PtrString(volumeBackup.LastRestoredVolumeId) != testVolumeId {
+				t.Fatalf("expected last restored volume %q, got %q", testVolumeId, utils.PtrString(volumeBackup.LastRestoredVolumeId))
+			}
+		})
+	}
+}